@@ -3,8 +3,10 @@ package modemmanager
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"os"
 	"path"
 	"sort"
 	"strconv"
@@ -15,13 +17,23 @@ import (
 
 // A Bearer handles the cellular connection state of a Modem.
 type Bearer struct {
-	Index                  int
-	Connected              bool
-	Interface              string
-	IPTimeout              time.Duration
-	IPv4Config, IPv6Config *IPConfig
-	Stats                  *BearerStats
-	Suspended              bool
+	Index                int               `json:"index"`
+	Connected            bool              `json:"connected"`
+	Interface            string            `json:"interface"`
+	IPTimeout            time.Duration     `json:"ip_timeout"`
+	IPv4Config           *IPConfig         `json:"ipv4_config,omitempty"`
+	IPv6Config           *IPConfig         `json:"ipv6_config,omitempty"`
+	Multiplexed          bool              `json:"multiplexed"`
+	ProfileID            int               `json:"profile_id"`
+	Properties           *BearerProperties `json:"properties,omitempty"`
+	ReloadStatsSupported bool              `json:"reload_stats_supported"`
+	Stats                *BearerStats      `json:"stats,omitempty"`
+	Suspended            bool              `json:"suspended"`
+	Type                 BearerType        `json:"type"`
+
+	// Warnings reports properties which were skipped because they could not
+	// be parsed. See Client.Lenient.
+	Warnings []error `json:"-"`
 
 	c *Client
 }
@@ -39,58 +51,257 @@ const (
 	BearerIPMethodDHCP
 )
 
+// A BearerType indicates the role of a Bearer, such as the attach bearer
+// established automatically when registering with an LTE network.
+type BearerType int
+
+// Possible BearerType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMBearerType.
+const (
+	BearerTypeUnknown BearerType = iota
+	BearerTypeDefault
+	BearerTypeDefaultAttach
+	BearerTypeDedicated
+)
+
 // An IPConfig is a Bearer's IPv4 or IPv6 configuration.
 type IPConfig struct {
-	Address *net.IPNet
-	DNS     []net.IP
-	Gateway net.IP
-	Method  BearerIPMethod
-	MTU     int
+	Address *net.IPNet     `json:"address"`
+	DNS     []net.IP       `json:"dns"`
+	Gateway net.IP         `json:"gateway"`
+	Method  BearerIPMethod `json:"method"`
+	MTU     int            `json:"mtu"`
 }
 
-// BearerStats contains statistics for a Bearer.
+// BearerStats contains statistics for a Bearer. The Total fields accumulate
+// across all connection attempts, while the others apply only to the current
+// or most recent connection. StartDate and the link speeds are only reported
+// by newer versions of ModemManager and are zero otherwise.
 type BearerStats struct {
-	Attempts, FailedAttempts                     int
-	Duration, TotalDuration                      time.Duration
-	RXBytes, TXBytes, TotalRXBytes, TotalTXBytes uint64
+	Attempts       int           `json:"attempts"`
+	FailedAttempts int           `json:"failed_attempts"`
+	Duration       time.Duration `json:"duration"`
+	TotalDuration  time.Duration `json:"total_duration"`
+	RXBytes        uint64        `json:"rx_bytes"`
+	TXBytes        uint64        `json:"tx_bytes"`
+	TotalRXBytes   uint64        `json:"total_rx_bytes"`
+	TotalTXBytes   uint64        `json:"total_tx_bytes"`
+	StartDate      time.Time     `json:"start_date"`
+	UplinkSpeed    uint64        `json:"uplink_speed"`
+	DownlinkSpeed  uint64        `json:"downlink_speed"`
+}
+
+// A BearerIPFamily is a bitmask of IP address families used by a Bearer.
+type BearerIPFamily uint32
+
+// Possible BearerIPFamily values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMBearerIpFamily.
+const (
+	BearerIPFamilyNone   BearerIPFamily = 0
+	BearerIPFamilyIPv4   BearerIPFamily = 1 << 0
+	BearerIPFamilyIPv6   BearerIPFamily = 1 << 1
+	BearerIPFamilyIPv4v6 BearerIPFamily = 1 << 2
+	BearerIPFamilyNonIP  BearerIPFamily = 1 << 3
+	BearerIPFamilyAny    BearerIPFamily = 0xfffffff7
+)
+
+// A BearerAllowedAuth is a bitmask of authentication methods a Bearer may
+// use.
+type BearerAllowedAuth uint32
+
+// Possible BearerAllowedAuth values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMBearerAllowedAuth.
+const (
+	BearerAllowedAuthUnknown  BearerAllowedAuth = 0
+	BearerAllowedAuthNone     BearerAllowedAuth = 1 << 0
+	BearerAllowedAuthPAP      BearerAllowedAuth = 1 << 1
+	BearerAllowedAuthCHAP     BearerAllowedAuth = 1 << 2
+	BearerAllowedAuthMSCHAP   BearerAllowedAuth = 1 << 3
+	BearerAllowedAuthMSCHAPv2 BearerAllowedAuth = 1 << 4
+	BearerAllowedAuthEAP      BearerAllowedAuth = 1 << 5
+)
+
+// BearerProperties are the connection settings used to create a Bearer. Zero
+// values are unset and left to the modem's defaults.
+//
+// AllowRoaming is a pointer so that roaming may be explicitly disallowed;
+// nil leaves the roaming policy unset. Password is omitted when encoding to
+// JSON so that it does not appear in logs.
+type BearerProperties struct {
+	APN          string            `json:"apn"`
+	AllowRoaming *bool             `json:"allow_roaming,omitempty"`
+	AllowedAuth  BearerAllowedAuth `json:"allowed_auth"`
+	IPType       BearerIPFamily    `json:"ip_type"`
+	Password     string            `json:"-"`
+	User         string            `json:"user"`
+}
+
+// A BearerChange is an event which occurs when a Bearer's connection state
+// changes, such as when its data session connects or drops.
+type BearerChange struct {
+	Connected, Suspended bool
+	Interface            string
 }
 
 // Bearers returns all of the Bearers for a Modem.
 func (m *Modem) Bearers(ctx context.Context) ([]*Bearer, error) {
 	bs := make([]*Bearer, 0, len(m.bearers))
 	for _, op := range m.bearers {
-		// Fetch all of the properties from the Bearers associated with this
-		// Modem.
-		ps, err := m.c.getAll(
-			ctx,
-			op,
-			interfacePath("Bearer"),
-		)
+		b, err := m.c.bearer(ctx, op)
 		if err != nil {
 			return nil, err
 		}
 
-		// Note the Bearer's index in the struct by fetching that index from
-		// the last element of the D-Bus object path.
-		idx, err := strconv.Atoi(path.Base(string(op)))
-		if err != nil {
-			return nil, err
-		}
+		bs = append(bs, b)
+	}
 
-		// Parse all of the properties into the Bearer's exported fields.
-		b := &Bearer{
-			Index: idx,
-			c:     m.c,
-		}
+	return bs, nil
+}
 
-		if err := b.parse(ps); err != nil {
-			return nil, err
+// Bearer fetches a Bearer by its D-Bus object path, such as
+// "/org/freedesktop/ModemManager1/Bearer/0". If the bearer does not exist, an
+// error compatible with 'errors.Is(err, os.ErrNotExist)' is returned.
+func (c *Client) Bearer(ctx context.Context, op dbus.ObjectPath) (*Bearer, error) {
+	if path.Dir(string(op)) != string(objectPath("Bearer")) {
+		return nil, fmt.Errorf("invalid bearer object path: %q", op)
+	}
+
+	b, err := c.bearer(ctx, op)
+	if err != nil {
+		// Unknown method indicates that the bearer doesn't exist.
+		return nil, toNotExist(err, unknownMethodError)
+	}
+
+	return b, nil
+}
+
+// bearer fetches a Bearer by its object path.
+func (c *Client) bearer(ctx context.Context, op dbus.ObjectPath) (*Bearer, error) {
+	// Fetch all of the properties from the Bearer.
+	ps, err := c.getAll(
+		ctx,
+		op,
+		interfacePath("Bearer"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Note the Bearer's index in the struct by fetching that index from the
+	// last element of the D-Bus object path.
+	idx, err := strconv.Atoi(path.Base(string(op)))
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse all of the properties into the Bearer's exported fields.
+	b := &Bearer{
+		Index: idx,
+		c:     c,
+	}
+
+	if err := b.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// Property fetches a raw D-Bus property by name from the input D-Bus interface
+// on the Bearer's object, such as "org.freedesktop.ModemManager1.Bearer". It
+// can be used to access properties which are not yet exposed by this package.
+func (b *Bearer) Property(ctx context.Context, iface, name string) (dbus.Variant, error) {
+	return b.c.get(ctx, objectPath("Bearer", strconv.Itoa(b.Index)), iface, name)
+}
+
+// AllProperties fetches all raw D-Bus properties from the input D-Bus interface
+// on the Bearer's object. It can be used to access properties which are not yet
+// exposed by this package.
+func (b *Bearer) AllProperties(ctx context.Context, iface string) (map[string]dbus.Variant, error) {
+	return b.c.getAll(ctx, objectPath("Bearer", strconv.Itoa(b.Index)), iface)
+}
+
+// Watch watches for changes to the Bearer's connection state. Each change is
+// delivered with the Bearer's current state on the returned channel, which is
+// closed when the context is canceled.
+func (b *Bearer) Watch(ctx context.Context) (<-chan BearerChange, error) {
+	changes, err := b.c.watchProperties(
+		ctx,
+		objectPath("Bearer", strconv.Itoa(b.Index)),
+		interfacePath("Bearer"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track the connection state locally; the Bearer itself is not updated.
+	bc := BearerChange{
+		Connected: b.Connected,
+		Suspended: b.Suspended,
+		Interface: b.Interface,
+	}
+
+	return forward(ctx, changes, func(ps map[string]dbus.Variant) (BearerChange, error) {
+		// Apply the changes to a copy so a malformed change leaves the
+		// tracked state untouched.
+		next := bc
+
+		var changed bool
+		for k, v := range ps {
+			vp := newValueParser(v)
+			switch k {
+			case "Connected":
+				next.Connected = vp.Bool()
+			case "Interface":
+				next.Interface = vp.String()
+			case "Suspended":
+				next.Suspended = vp.Bool()
+			default:
+				continue
+			}
+
+			if err := vp.Err(); err != nil {
+				return BearerChange{}, fmt.Errorf("error parsing %q: %v", k, err)
+			}
+
+			changed = true
+		}
+		if !changed {
+			return BearerChange{}, errors.New("no bearer connection changes")
 		}
 
-		bs = append(bs, b)
+		bc = next
+		return bc, nil
+	}), nil
+}
+
+// NetInterface returns the kernel network interface used by the Bearer's data
+// connection. If the Bearer has no interface or the interface has not yet
+// appeared on the host, an error compatible with 'errors.Is(err,
+// os.ErrNotExist)' is returned.
+func (b *Bearer) NetInterface() (*net.Interface, error) {
+	return b.netInterface(net.Interfaces)
+}
+
+// netInterface implements NetInterface using the input interface listing
+// function.
+func (b *Bearer) netInterface(interfaces func() ([]net.Interface, error)) (*net.Interface, error) {
+	if b.Interface == "" {
+		return nil, fmt.Errorf("bearer %d has no network interface: %w", b.Index, os.ErrNotExist)
 	}
 
-	return bs, nil
+	ifis, err := interfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ifi := range ifis {
+		if ifi.Name == b.Interface {
+			return &ifi, nil
+		}
+	}
+
+	return nil, fmt.Errorf("network interface %q not found: %w", b.Interface, os.ErrNotExist)
 }
 
 // Friendly names for IPv4/6 control flow booleans.
@@ -102,8 +313,11 @@ const (
 // parse parses a properties map into the Bearer's fields.
 func (b *Bearer) parse(ps map[string]dbus.Variant) error {
 	for k, v := range ps {
+		var perr error
 		vp := newValueParser(v)
 		switch k {
+		case "BearerType":
+			b.Type = BearerType(vp.Int())
 		case "Connected":
 			b.Connected = vp.Bool()
 		case "Interface":
@@ -111,21 +325,57 @@ func (b *Bearer) parse(ps map[string]dbus.Variant) error {
 		case "IpTimeout":
 			b.IPTimeout = time.Duration(vp.Int()) * time.Second
 		case "Ip4Config":
-			c, err := parseIPConfig(vp.Properties(), isIPv4)
+			nested := vp.Properties()
+			if vp.Err() != nil {
+				break
+			}
+
+			c, err := parseIPConfig(nested, isIPv4)
 			if err != nil {
-				return fmt.Errorf("error parsing IPv4 config: %v", err)
+				perr = fmt.Errorf("error parsing IPv4 config: %v", err)
+				break
 			}
 			b.IPv4Config = c
 		case "Ip6Config":
-			c, err := parseIPConfig(vp.Properties(), isIPv6)
+			nested := vp.Properties()
+			if vp.Err() != nil {
+				break
+			}
+
+			c, err := parseIPConfig(nested, isIPv6)
 			if err != nil {
-				return fmt.Errorf("error parsing IPv6 config: %v", err)
+				perr = fmt.Errorf("error parsing IPv6 config: %v", err)
+				break
 			}
 			b.IPv6Config = c
+		case "Multiplexed":
+			b.Multiplexed = vp.Bool()
+		case "ProfileId":
+			b.ProfileID = vp.Int()
+		case "Properties":
+			nested := vp.Properties()
+			if vp.Err() != nil {
+				break
+			}
+
+			p, err := parseBearerProperties(nested)
+			if err != nil {
+				perr = fmt.Errorf("error parsing bearer properties: %v", err)
+				break
+			}
+			b.Properties = p
+		case "ReloadStatsSupported":
+			b.ReloadStatsSupported = vp.Bool()
 		case "Stats":
-			bs, err := parseBearerStats(vp.Properties())
+			nested := vp.Properties()
+			if vp.Err() != nil {
+				break
+			}
+
+			bs, err := parseBearerStats(nested)
 			if err != nil {
-				return fmt.Errorf("error parsing bearer stats: %v", err)
+				perr = fmt.Errorf("error parsing bearer stats: %v", err)
+				break
 			}
 			b.Stats = bs
 		case "Suspended":
@@ -133,7 +383,14 @@ func (b *Bearer) parse(ps map[string]dbus.Variant) error {
 		}
 
 		if err := vp.Err(); err != nil {
-			return fmt.Errorf("error parsing %q: %v", k, err)
+			perr = fmt.Errorf("error parsing %q: %v", k, err)
+		}
+		if perr != nil {
+			if b.c == nil || !b.c.Lenient {
+				return perr
+			}
+
+			b.Warnings = append(b.Warnings, perr)
 		}
 	}
 
@@ -188,6 +445,61 @@ func parseIPConfig(ps map[string]dbus.Variant, ip6 bool) (*IPConfig, error) {
 	return &c, nil
 }
 
+// parseBearerProperties parses BearerProperties from a properties map.
+func parseBearerProperties(ps map[string]dbus.Variant) (*BearerProperties, error) {
+	var p BearerProperties
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "allow-roaming":
+			roaming := vp.Bool()
+			p.AllowRoaming = &roaming
+		case "allowed-auth":
+			p.AllowedAuth = BearerAllowedAuth(vp.Int())
+		case "apn":
+			p.APN = vp.String()
+		case "ip-type":
+			p.IPType = BearerIPFamily(vp.Int())
+		case "password":
+			p.Password = vp.String()
+		case "user":
+			p.User = vp.String()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &p, nil
+}
+
+// properties packs BearerProperties into a properties map, omitting any unset
+// values.
+func (p BearerProperties) properties() map[string]dbus.Variant {
+	ps := make(map[string]dbus.Variant)
+	if p.AllowRoaming != nil {
+		ps["allow-roaming"] = dbus.MakeVariant(*p.AllowRoaming)
+	}
+	if p.AllowedAuth != BearerAllowedAuthUnknown {
+		ps["allowed-auth"] = dbus.MakeVariant(uint32(p.AllowedAuth))
+	}
+	if p.APN != "" {
+		ps["apn"] = dbus.MakeVariant(p.APN)
+	}
+	if p.IPType != BearerIPFamilyNone {
+		ps["ip-type"] = dbus.MakeVariant(uint32(p.IPType))
+	}
+	if p.Password != "" {
+		ps["password"] = dbus.MakeVariant(p.Password)
+	}
+	if p.User != "" {
+		ps["user"] = dbus.MakeVariant(p.User)
+	}
+
+	return ps
+}
+
 // parseBearerStats parses BearerStats from a properties map.
 func parseBearerStats(ps map[string]dbus.Variant) (*BearerStats, error) {
 	var bs BearerStats
@@ -210,6 +522,15 @@ func parseBearerStats(ps map[string]dbus.Variant) (*BearerStats, error) {
 			bs.TotalRXBytes = vp.Uint64()
 		case "total-tx-bytes":
 			bs.TotalTXBytes = vp.Uint64()
+		case "start-date":
+			// Seconds since the UNIX epoch, or 0 when not connected.
+			if secs := vp.Uint64(); secs != 0 {
+				bs.StartDate = time.Unix(int64(secs), 0)
+			}
+		case "uplink-speed":
+			bs.UplinkSpeed = vp.Uint64()
+		case "downlink-speed":
+			bs.DownlinkSpeed = vp.Uint64()
 		}
 
 		if err := vp.Err(); err != nil {
@@ -2,7 +2,9 @@ package modemmanager
 
 import (
 	"context"
+	"errors"
 	"net"
+	"os"
 	"path"
 	"strings"
 	"testing"
@@ -32,9 +34,10 @@ func TestModemBearers(t *testing.T) {
 
 			// Test data copied from mdlayher's modem with some tweaks.
 			return map[string]dbus.Variant{
-				"Connected": dbus.MakeVariant(true),
-				"Interface": dbus.MakeVariant("wwan0"),
-				"IpTimeout": dbus.MakeVariant(uint32(20)),
+				"BearerType": dbus.MakeVariant(uint32(BearerTypeDefault)),
+				"Connected":  dbus.MakeVariant(true),
+				"Interface":  dbus.MakeVariant("wwan0"),
+				"IpTimeout":  dbus.MakeVariant(uint32(20)),
 				"Ip4Config": dbus.MakeVariant(map[string]dbus.Variant{
 					"address": dbus.MakeVariant("192.0.2.10"),
 					"dns1":    dbus.MakeVariant("192.0.2.0"),
@@ -53,6 +56,14 @@ func TestModemBearers(t *testing.T) {
 					"mtu":     dbus.MakeVariant(uint32(1500)),
 					"prefix":  dbus.MakeVariant(uint32(64)),
 				}),
+				"Multiplexed": dbus.MakeVariant(true),
+				"ProfileId":   dbus.MakeVariant(int32(2)),
+				"Properties": dbus.MakeVariant(map[string]dbus.Variant{
+					"allow-roaming": dbus.MakeVariant(false),
+					"apn":           dbus.MakeVariant("broadband"),
+					"ip-type":       dbus.MakeVariant(uint32(BearerIPFamilyIPv4v6)),
+				}),
+				"ReloadStatsSupported": dbus.MakeVariant(true),
 				"Stats": dbus.MakeVariant(map[string]dbus.Variant{
 					"attempts":        dbus.MakeVariant(uint32(1)),
 					"failed-attempts": dbus.MakeVariant(uint32(2)),
@@ -62,6 +73,9 @@ func TestModemBearers(t *testing.T) {
 					"tx-bytes":        dbus.MakeVariant(uint64(6)),
 					"total-rx-bytes":  dbus.MakeVariant(uint64(7)),
 					"total-tx-bytes":  dbus.MakeVariant(uint64(8)),
+					"start-date":      dbus.MakeVariant(uint64(1600000000)),
+					"uplink-speed":    dbus.MakeVariant(uint64(50000000)),
+					"downlink-speed":  dbus.MakeVariant(uint64(150000000)),
 				}),
 				"Suspended": dbus.MakeVariant(false),
 			}, nil
@@ -78,6 +92,7 @@ func TestModemBearers(t *testing.T) {
 		t.Fatalf("failed to get bearers: %v", err)
 	}
 
+	roaming := false
 	want := []*Bearer{
 		{
 			Index:     0,
@@ -110,6 +125,14 @@ func TestModemBearers(t *testing.T) {
 				Method:  BearerIPMethodStatic,
 				MTU:     1500,
 			},
+			Multiplexed: true,
+			ProfileID:   2,
+			Properties: &BearerProperties{
+				APN:          "broadband",
+				AllowRoaming: &roaming,
+				IPType:       BearerIPFamilyIPv4v6,
+			},
+			ReloadStatsSupported: true,
 			Stats: &BearerStats{
 				Attempts:       1,
 				FailedAttempts: 2,
@@ -119,8 +142,12 @@ func TestModemBearers(t *testing.T) {
 				TXBytes:        6,
 				TotalRXBytes:   7,
 				TotalTXBytes:   8,
+				StartDate:      time.Unix(1600000000, 0),
+				UplinkSpeed:    50000000,
+				DownlinkSpeed:  150000000,
 			},
 			Suspended: false,
+			Type:      BearerTypeDefault,
 		},
 		{
 			Index:     1,
@@ -132,3 +159,253 @@ func TestModemBearers(t *testing.T) {
 		t.Fatalf("unexpected Bearers (-want +got):\n%s", diff)
 	}
 }
+
+func TestClientBearer(t *testing.T) {
+	c := &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+		if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/2"), op); diff != "" {
+			t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+		}
+
+		if diff := cmp.Diff("org.freedesktop.ModemManager1.Bearer", dInterface); diff != "" {
+			t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+		}
+
+		return map[string]dbus.Variant{
+			"Connected": dbus.MakeVariant(true),
+			"Interface": dbus.MakeVariant("wwan0"),
+		}, nil
+	}}
+
+	b, err := c.Bearer(context.Background(), "/org/freedesktop/ModemManager1/Bearer/2")
+	if err != nil {
+		t.Fatalf("failed to get bearer: %v", err)
+	}
+
+	want := &Bearer{
+		Index:     2,
+		Connected: true,
+		Interface: "wwan0",
+	}
+
+	if diff := cmp.Diff(want, b, cmpopts.IgnoreUnexported(Bearer{})); diff != "" {
+		t.Fatalf("unexpected Bearer (-want +got):\n%s", diff)
+	}
+}
+
+func TestClientBearerErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       dbus.ObjectPath
+		notExist bool
+	}{
+		{
+			name: "not a bearer",
+			op:   "/org/freedesktop/ModemManager1/Modem/0",
+		},
+		{
+			name:     "not found",
+			op:       "/org/freedesktop/ModemManager1/Bearer/0",
+			notExist: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Client{getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				// D-Bus returns "unknown method" when a bearer doesn't exist.
+				return nil, dbus.Error{Name: unknownMethodError}
+			}}
+
+			_, err := c.Bearer(context.Background(), tt.op)
+			if err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+
+			if diff := cmp.Diff(tt.notExist, errors.Is(err, os.ErrNotExist)); diff != "" {
+				t.Fatalf("unexpected not exist error (-want +got):\n%s", diff)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
+
+func TestClientBearerLenient(t *testing.T) {
+	c := &Client{
+		Lenient: true,
+		getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+			// Connected must be a boolean and Stats must be a map.
+			return map[string]dbus.Variant{
+				"Connected": dbus.MakeVariant("true"),
+				"Interface": dbus.MakeVariant("wwan0"),
+				"Stats":     dbus.MakeVariant(1),
+			}, nil
+		},
+	}
+
+	b, err := c.Bearer(context.Background(), "/org/freedesktop/ModemManager1/Bearer/0")
+	if err != nil {
+		t.Fatalf("failed to get bearer: %v", err)
+	}
+
+	want := &Bearer{Interface: "wwan0"}
+	if diff := cmp.Diff(want, b, cmpopts.IgnoreUnexported(Bearer{}), cmpopts.IgnoreFields(Bearer{}, "Warnings")); diff != "" {
+		t.Fatalf("unexpected Bearer (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(2, len(b.Warnings)); diff != "" {
+		t.Fatalf("unexpected number of warnings (-want +got):\n%s", diff)
+	}
+}
+
+func TestBearerWatch(t *testing.T) {
+	b := &Bearer{
+		Index:     1,
+		Connected: true,
+		Interface: "wwan0",
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			const iface = "org.freedesktop.ModemManager1.Bearer"
+
+			// The bearer is suspended, an unrelated property changes, a
+			// malformed change is skipped, and then the bearer disconnects.
+			sigs := make(chan *dbus.Signal, 4)
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"Suspended": dbus.MakeVariant(true)},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"IpTimeout": dbus.MakeVariant(uint32(20))},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant("foo"),
+					"Interface": dbus.MakeVariant("wwan1"),
+				},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(false),
+					"Suspended": dbus.MakeVariant(false),
+				},
+				[]string{},
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := b.Watch(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch bearer: %v", err)
+	}
+
+	var got []BearerChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []BearerChange{
+		{
+			Connected: true,
+			Suspended: true,
+			Interface: "wwan0",
+		},
+		{Interface: "wwan0"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected bearer changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestBearerNetInterface(t *testing.T) {
+	ifis := []net.Interface{
+		{Index: 1, Name: "lo", Flags: net.FlagUp | net.FlagLoopback},
+		{
+			Index:        3,
+			Name:         "wwan0",
+			HardwareAddr: net.HardwareAddr{0xde, 0xad, 0xbe, 0xef, 0xde, 0xad},
+			Flags:        net.FlagUp | net.FlagPointToPoint,
+		},
+	}
+
+	tests := []struct {
+		name  string
+		iface string
+		ifi   *net.Interface
+		ok    bool
+	}{
+		{
+			name: "no interface",
+		},
+		{
+			name:  "not found",
+			iface: "wwan1",
+		},
+		{
+			name:  "OK",
+			iface: "wwan0",
+			ifi:   &ifis[1],
+			ok:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &Bearer{Interface: tt.iface}
+			ifi, err := b.netInterface(func() ([]net.Interface, error) {
+				return ifis, nil
+			})
+			if tt.ok && err != nil {
+				t.Fatalf("failed to get network interface: %v", err)
+			}
+			if !tt.ok && !errors.Is(err, os.ErrNotExist) {
+				t.Fatalf("expected is not exist error, but got: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.ifi, ifi); diff != "" {
+				t.Fatalf("unexpected network interface (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestBearerAllProperties(t *testing.T) {
+	b := &Bearer{
+		Index: 1,
+		// Verify all of the expected inputs before returning canned properties.
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Bearer", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"Connected": dbus.MakeVariant(true),
+			}, nil
+		}},
+	}
+
+	ps, err := b.AllProperties(context.Background(), "org.freedesktop.ModemManager1.Bearer")
+	if err != nil {
+		t.Fatalf("failed to get all properties: %v", err)
+	}
+
+	if diff := cmp.Diff(true, ps["Connected"].Value()); diff != "" {
+		t.Fatalf("unexpected Connected property (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,143 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrUnsupported indicates that a Modem or one of its objects does not
+// support the requested operation.
+var ErrUnsupported = errors.New("operation not supported")
+
+// A BearerStatsDelta is the change in a Bearer's statistics between two polls.
+type BearerStatsDelta struct {
+	// The number of bytes received and transmitted, and the connection time
+	// elapsed, since the previous poll.
+	RXBytes, TXBytes uint64
+	Duration         time.Duration
+
+	// Reset reports whether the Bearer's counters were reset since the
+	// previous poll, such as by a reconnect. If so, the deltas are counted
+	// from zero rather than from the previous statistics.
+	Reset bool
+
+	// Stats are the statistics reported by the Bearer at this poll.
+	Stats *BearerStats
+}
+
+// PollStats polls the Bearer's statistics at the specified interval and
+// delivers the change since the previous poll on the returned channel until
+// the context is canceled. Polls which fail are skipped.
+//
+// If the Bearer does not support reloading its statistics, ErrUnsupported is
+// returned. This method requires ModemManager 1.20 or newer.
+func (b *Bearer) PollStats(ctx context.Context, interval time.Duration) (<-chan *BearerStatsDelta, error) {
+	v, err := b.c.get(
+		ctx,
+		objectPath("Bearer", strconv.Itoa(b.Index)),
+		interfacePath("Bearer"),
+		"ReloadStatsSupported",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	supported := vp.Bool()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("error parsing %q: %v", "ReloadStatsSupported", err)
+	}
+	if !supported {
+		return nil, ErrUnsupported
+	}
+
+	// Fetch the current statistics as a baseline so the first delta covers
+	// only the first interval.
+	prev, err := b.stats(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan *BearerStatsDelta)
+	go func() {
+		defer close(out)
+
+		t := time.NewTicker(interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-t.C:
+			case <-ctx.Done():
+				return
+			}
+
+			curr, err := b.stats(ctx)
+			if err != nil {
+				continue
+			}
+
+			d := statsDelta(prev, curr)
+			prev = curr
+
+			select {
+			case out <- d:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// stats fetches the Bearer's current statistics.
+func (b *Bearer) stats(ctx context.Context) (*BearerStats, error) {
+	v, err := b.c.get(
+		ctx,
+		objectPath("Bearer", strconv.Itoa(b.Index)),
+		interfacePath("Bearer"),
+		"Stats",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	ps := vp.Properties()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("error parsing %q: %v", "Stats", err)
+	}
+
+	return parseBearerStats(ps)
+}
+
+// statsDelta computes the change from prev to curr BearerStats.
+func statsDelta(prev, curr *BearerStats) *BearerStatsDelta {
+	// Counters which move backwards or a new connection start date indicate
+	// that the Bearer reconnected and its counters began again from zero.
+	reset := curr.RXBytes < prev.RXBytes ||
+		curr.TXBytes < prev.TXBytes ||
+		curr.Duration < prev.Duration ||
+		(!prev.StartDate.IsZero() && !curr.StartDate.Equal(prev.StartDate))
+
+	if reset {
+		return &BearerStatsDelta{
+			RXBytes:  curr.RXBytes,
+			TXBytes:  curr.TXBytes,
+			Duration: curr.Duration,
+			Reset:    true,
+			Stats:    curr,
+		}
+	}
+
+	return &BearerStatsDelta{
+		RXBytes:  curr.RXBytes - prev.RXBytes,
+		TXBytes:  curr.TXBytes - prev.TXBytes,
+		Duration: curr.Duration - prev.Duration,
+		Stats:    curr,
+	}
+}
@@ -0,0 +1,175 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBearerPollStats(t *testing.T) {
+	// Each poll returns the next statistics, with the final poll simulating
+	// a reconnect which resets the counters.
+	stats := []map[string]dbus.Variant{
+		{
+			"duration": dbus.MakeVariant(uint32(10)),
+			"rx-bytes": dbus.MakeVariant(uint64(100)),
+			"tx-bytes": dbus.MakeVariant(uint64(50)),
+		},
+		{
+			"duration": dbus.MakeVariant(uint32(15)),
+			"rx-bytes": dbus.MakeVariant(uint64(300)),
+			"tx-bytes": dbus.MakeVariant(uint64(60)),
+		},
+		{
+			"duration": dbus.MakeVariant(uint32(2)),
+			"rx-bytes": dbus.MakeVariant(uint64(20)),
+			"tx-bytes": dbus.MakeVariant(uint64(10)),
+		},
+	}
+
+	var i int
+	b := &Bearer{
+		Index: 1,
+		c: &Client{get: func(_ context.Context, op dbus.ObjectPath, iface, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Bearer", iface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			switch prop {
+			case "ReloadStatsSupported":
+				return dbus.MakeVariant(true), nil
+			case "Stats":
+				if i == len(stats) {
+					return dbus.Variant{}, errors.New("no more stats")
+				}
+
+				s := stats[i]
+				i++
+				return dbus.MakeVariant(s), nil
+			default:
+				t.Fatalf("unexpected property: %q", prop)
+				panic("unreachable")
+			}
+		}},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	deltas, err := b.PollStats(ctx, time.Millisecond)
+	if err != nil {
+		t.Fatalf("failed to poll stats: %v", err)
+	}
+
+	got := []*BearerStatsDelta{<-deltas, <-deltas}
+	cancel()
+
+	want := []*BearerStatsDelta{
+		{
+			RXBytes:  200,
+			TXBytes:  10,
+			Duration: 5 * time.Second,
+			Stats: &BearerStats{
+				Duration: 15 * time.Second,
+				RXBytes:  300,
+				TXBytes:  60,
+			},
+		},
+		{
+			RXBytes:  20,
+			TXBytes:  10,
+			Duration: 2 * time.Second,
+			Reset:    true,
+			Stats: &BearerStats{
+				Duration: 2 * time.Second,
+				RXBytes:  20,
+				TXBytes:  10,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected BearerStatsDeltas (-want +got):\n%s", diff)
+	}
+
+	// The channel must be closed after cancelation.
+	for range deltas {
+	}
+}
+
+func TestBearerPollStatsUnsupported(t *testing.T) {
+	b := &Bearer{
+		c: &Client{get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff("ReloadStatsSupported", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant(false), nil
+		}},
+	}
+
+	_, err := b.PollStats(context.Background(), time.Second)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, but got: %v", err)
+	}
+}
+
+func Test_statsDelta(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name       string
+		prev, curr *BearerStats
+		rx         uint64
+		reset      bool
+	}{
+		{
+			name: "increase",
+			prev: &BearerStats{RXBytes: 10, StartDate: start},
+			curr: &BearerStats{RXBytes: 15, StartDate: start},
+			rx:   5,
+		},
+		{
+			name:  "counters reset",
+			prev:  &BearerStats{RXBytes: 10},
+			curr:  &BearerStats{RXBytes: 5},
+			rx:    5,
+			reset: true,
+		},
+		{
+			name:  "start date changed",
+			prev:  &BearerStats{RXBytes: 10, StartDate: start},
+			curr:  &BearerStats{RXBytes: 15, StartDate: start.Add(time.Minute)},
+			rx:    15,
+			reset: true,
+		},
+		{
+			name: "start date reported",
+			prev: &BearerStats{RXBytes: 10},
+			curr: &BearerStats{RXBytes: 15, StartDate: start},
+			rx:   5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := statsDelta(tt.prev, tt.curr)
+
+			if diff := cmp.Diff(tt.rx, d.RXBytes); diff != "" {
+				t.Fatalf("unexpected RX bytes (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.reset, d.Reset); diff != "" {
+				t.Fatalf("unexpected reset (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,446 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A Call is a voice call handled by a Modem.
+type Call struct {
+	Index       int
+	AudioFormat *CallAudioFormat
+	AudioPort   string
+	Direction   CallDirection
+	Multiparty  bool
+	Number      string
+	State       CallState
+	StateReason CallStateReason
+
+	c *Client
+}
+
+// CallAudioFormat describes the format of the audio carried on a Call's
+// AudioPort, such as "pcm" encoding with "s16le" resolution at 8000Hz.
+type CallAudioFormat struct {
+	Encoding   string
+	Resolution string
+	Rate       int
+}
+
+// A CallState is the state of a Call.
+type CallState int
+
+// Possible CallState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMCallState.
+const (
+	CallStateUnknown CallState = iota
+	CallStateDialing
+	CallStateRingingOut
+	CallStateRingingIn
+	CallStateActive
+	CallStateHeld
+	CallStateWaiting
+	CallStateTerminated
+)
+
+// A CallStateReason is the reason a Call changed to its current state.
+type CallStateReason int
+
+// Possible CallStateReason values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMCallStateReason.
+const (
+	CallStateReasonUnknown CallStateReason = iota
+	CallStateReasonOutgoingStarted
+	CallStateReasonIncomingNew
+	CallStateReasonAccepted
+	CallStateReasonTerminated
+	CallStateReasonRefusedOrBusy
+	CallStateReasonError
+	CallStateReasonAudioSetupFailed
+	CallStateReasonTransferred
+	CallStateReasonDeflected
+)
+
+// A CallDirection indicates whether a Call is incoming or outgoing.
+type CallDirection int
+
+// Possible CallDirection values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMCallDirection.
+const (
+	CallDirectionUnknown CallDirection = iota
+	CallDirectionIncoming
+	CallDirectionOutgoing
+)
+
+// Voice contains the voice calling status of a Modem.
+type Voice struct {
+	// EmergencyOnly reports whether the Modem may only place emergency
+	// calls, such as when no SIM is present or the SIM is locked.
+	EmergencyOnly bool
+}
+
+// A CallStateChange is a transition of a Call from one CallState to another.
+type CallStateChange struct {
+	Old, New CallState
+	Reason   CallStateReason
+}
+
+// Calls lists the voice Calls which are currently handled by the Modem.
+func (m *Modem) Calls(ctx context.Context) ([]*Call, error) {
+	var ops []dbus.ObjectPath
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Voice", "ListCalls"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&ops,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	cs := make([]*Call, 0, len(ops))
+	for _, op := range ops {
+		c, err := m.c.voiceCall(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+
+		cs = append(cs, c)
+	}
+
+	return cs, nil
+}
+
+// Voice fetches the voice calling status of the Modem.
+func (m *Modem) Voice(ctx context.Context) (*Voice, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Voice"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var v Voice
+	for k, vv := range ps {
+		vp := newValueParser(vv)
+		switch k {
+		case "EmergencyOnly":
+			v.EmergencyOnly = vp.Bool()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &v, nil
+}
+
+// IsEmergencyNumber reports whether number is an emergency number. The numbers
+// 112 and 911 are always treated as emergency numbers, as required by 3GPP TS
+// 22.101. Additional numbers for the current network, such as those reported
+// by SIM.EmergencyNumbers, may be passed in emergencyNumbers. Spaces and
+// dashes in number are ignored.
+func IsEmergencyNumber(number string, emergencyNumbers []string) bool {
+	number = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+
+		return r
+	}, number)
+
+	switch number {
+	case "":
+		return false
+	case "112", "911":
+		return true
+	}
+
+	for _, n := range emergencyNumbers {
+		if n == number {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WatchCalls watches for Calls added to the Modem, such as incoming calls
+// from the network or outgoing calls created by another client. Each Call is
+// delivered on the returned channel, which is closed when the context is
+// canceled. Use Call.IsIncoming to determine whether a Call should be answered.
+func (m *Modem) WatchCalls(ctx context.Context) (<-chan *Call, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Voice"),
+		"CallAdded",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (*Call, error) {
+		var op dbus.ObjectPath
+		if err := dbus.Store(s.Body, &op); err != nil {
+			return nil, fmt.Errorf("error parsing added call: %v", err)
+		}
+
+		// The call may be hung up and removed before it can be fetched, in
+		// which case it is skipped.
+		return m.c.voiceCall(ctx, op)
+	}), nil
+}
+
+// HangupAll hangs up all of the Modem's ongoing Calls.
+func (m *Modem) HangupAll(ctx context.Context) error {
+	return m.voiceMethod(ctx, "HangupAll")
+}
+
+// HoldAndAccept places all active Calls on hold and accepts the next waiting
+// or held Call.
+func (m *Modem) HoldAndAccept(ctx context.Context) error {
+	return m.voiceMethod(ctx, "HoldAndAccept")
+}
+
+// HangupAndAccept hangs up all active Calls and accepts the next waiting or
+// held Call.
+func (m *Modem) HangupAndAccept(ctx context.Context) error {
+	return m.voiceMethod(ctx, "HangupAndAccept")
+}
+
+// Transfer joins the Modem's active and held Calls and then disconnects the
+// Modem from both, performing an attended transfer.
+func (m *Modem) Transfer(ctx context.Context) error {
+	return m.voiceMethod(ctx, "Transfer")
+}
+
+// voiceMethod calls a Voice method on the Modem which takes no arguments.
+func (m *Modem) voiceMethod(ctx context.Context, method string) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Voice", method),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// IsIncoming reports whether the Call was initiated by the network rather
+// than by the Modem.
+func (c *Call) IsIncoming() bool { return c.Direction == CallDirectionIncoming }
+
+// Accept accepts an incoming Call.
+func (c *Call) Accept(ctx context.Context) error {
+	return c.method(ctx, "Accept")
+}
+
+// Hangup hangs up the Call.
+func (c *Call) Hangup(ctx context.Context) error {
+	return c.method(ctx, "Hangup")
+}
+
+// JoinMultiparty joins the Call to an ongoing multiparty conference with the
+// Modem's other active Calls.
+func (c *Call) JoinMultiparty(ctx context.Context) error {
+	return c.method(ctx, "JoinMultiparty")
+}
+
+// LeaveMultiparty removes the Call from a multiparty conference, placing the
+// remaining Calls in the conference on hold.
+func (c *Call) LeaveMultiparty(ctx context.Context) error {
+	return c.method(ctx, "LeaveMultiparty")
+}
+
+// SendDtmf sends DTMF tones on an active Call. digits may contain the
+// characters 0-9, A-D, '*', and '#'.
+func (c *Call) SendDtmf(ctx context.Context, digits string) error {
+	return c.method(ctx, "SendDtmf", digits)
+}
+
+// WatchState watches for changes to the Call's state. Each change is delivered
+// on the returned channel, which is closed when the context is canceled.
+func (c *Call) WatchState(ctx context.Context) (<-chan CallStateChange, error) {
+	sigs, err := c.c.watch(
+		ctx,
+		objectPath("Call", strconv.Itoa(c.Index)),
+		interfacePath("Call"),
+		"StateChanged",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, parseCallStateChange), nil
+}
+
+// WatchDtmf watches for DTMF tones received on the Call. Each tone is
+// delivered on the returned channel as a string, which is closed when the
+// context is canceled.
+func (c *Call) WatchDtmf(ctx context.Context) (<-chan string, error) {
+	sigs, err := c.c.watch(
+		ctx,
+		objectPath("Call", strconv.Itoa(c.Index)),
+		interfacePath("Call"),
+		"DtmfReceived",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (string, error) {
+		var dtmf string
+		if err := dbus.Store(s.Body, &dtmf); err != nil {
+			return "", fmt.Errorf("error parsing received DTMF: %v", err)
+		}
+
+		return dtmf, nil
+	}), nil
+}
+
+// Property fetches a raw D-Bus property by name from the input D-Bus interface
+// on the Call's object, such as "org.freedesktop.ModemManager1.Call". It can
+// be used to access properties which are not yet exposed by this package.
+func (c *Call) Property(ctx context.Context, iface, name string) (dbus.Variant, error) {
+	return c.c.get(ctx, objectPath("Call", strconv.Itoa(c.Index)), iface, name)
+}
+
+// AllProperties fetches all raw D-Bus properties from the input D-Bus interface
+// on the Call's object. It can be used to access properties which are not yet
+// exposed by this package.
+func (c *Call) AllProperties(ctx context.Context, iface string) (map[string]dbus.Variant, error) {
+	return c.c.getAll(ctx, objectPath("Call", strconv.Itoa(c.Index)), iface)
+}
+
+// method calls a Call method with optional arguments.
+func (c *Call) method(ctx context.Context, method string, args ...interface{}) error {
+	err := c.c.call(
+		ctx,
+		interfacePath("Call", method),
+		objectPath("Call", strconv.Itoa(c.Index)),
+		nil,
+		args...,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// parseCallStateChange parses a CallStateChange from a D-Bus StateChanged
+// signal.
+func parseCallStateChange(s *dbus.Signal) (CallStateChange, error) {
+	var (
+		from, to int32
+		reason   uint32
+	)
+
+	if err := dbus.Store(s.Body, &from, &to, &reason); err != nil {
+		return CallStateChange{}, fmt.Errorf("error parsing call state change: %v", err)
+	}
+
+	return CallStateChange{
+		Old:    CallState(from),
+		New:    CallState(to),
+		Reason: CallStateReason(reason),
+	}, nil
+}
+
+// voiceCall fetches a Call by its object path.
+func (c *Client) voiceCall(ctx context.Context, op dbus.ObjectPath) (*Call, error) {
+	ps, err := c.getAll(ctx, op, interfacePath("Call"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Note the Call's index in the struct by fetching that index from the last
+	// element of the D-Bus object path.
+	idx, err := strconv.Atoi(path.Base(string(op)))
+	if err != nil {
+		return nil, err
+	}
+
+	vc := &Call{
+		Index: idx,
+		c:     c,
+	}
+
+	if err := vc.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return vc, nil
+}
+
+// parse parses a properties map into the Call's fields.
+func (c *Call) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "AudioFormat":
+			f, err := parseCallAudioFormat(vp.Properties())
+			if err != nil {
+				return fmt.Errorf("error parsing audio format: %v", err)
+			}
+			c.AudioFormat = f
+		case "AudioPort":
+			c.AudioPort = vp.String()
+		case "Direction":
+			c.Direction = CallDirection(vp.Int())
+		case "Multiparty":
+			c.Multiparty = vp.Bool()
+		case "Number":
+			c.Number = vp.String()
+		case "State":
+			c.State = CallState(vp.Int())
+		case "StateReason":
+			c.StateReason = CallStateReason(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
+
+// parseCallAudioFormat parses a properties map into a CallAudioFormat. An
+// empty map, reported when the Call has no audio port, results in nil.
+func parseCallAudioFormat(ps map[string]dbus.Variant) (*CallAudioFormat, error) {
+	if len(ps) == 0 {
+		return nil, nil
+	}
+
+	var f CallAudioFormat
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "encoding":
+			f.Encoding = vp.String()
+		case "resolution":
+			f.Resolution = vp.String()
+		case "rate":
+			f.Rate = vp.Int()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &f, nil
+}
@@ -0,0 +1,476 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemCalls(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Voice.ListCalls", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				return dbus.Store([]interface{}{[]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/Call/2",
+				}}, out)
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/2"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Call", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				return map[string]dbus.Variant{
+					"AudioFormat": dbus.MakeVariant(map[string]dbus.Variant{
+						"encoding":   dbus.MakeVariant("pcm"),
+						"resolution": dbus.MakeVariant("s16le"),
+						"rate":       dbus.MakeVariant(uint32(8000)),
+					}),
+					"AudioPort":   dbus.MakeVariant("ttyUSB4"),
+					"Direction":   dbus.MakeVariant(uint32(CallDirectionIncoming)),
+					"Multiparty":  dbus.MakeVariant(true),
+					"Number":      dbus.MakeVariant("+15555551234"),
+					"State":       dbus.MakeVariant(int32(CallStateRingingIn)),
+					"StateReason": dbus.MakeVariant(uint32(CallStateReasonIncomingNew)),
+				}, nil
+			},
+		},
+	}
+
+	cs, err := m.Calls(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list calls: %v", err)
+	}
+
+	want := []*Call{{
+		Index: 2,
+		AudioFormat: &CallAudioFormat{
+			Encoding:   "pcm",
+			Resolution: "s16le",
+			Rate:       8000,
+		},
+		AudioPort:   "ttyUSB4",
+		Direction:   CallDirectionIncoming,
+		Multiparty:  true,
+		Number:      "+15555551234",
+		State:       CallStateRingingIn,
+		StateReason: CallStateReasonIncomingNew,
+	}}
+
+	if diff := cmp.Diff(want, cs, cmpopts.IgnoreUnexported(Call{})); diff != "" {
+		t.Fatalf("unexpected Calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemVoiceMethods(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		fn     func(m *Modem, ctx context.Context) error
+	}{
+		{
+			name:   "hangup all",
+			method: "org.freedesktop.ModemManager1.Modem.Voice.HangupAll",
+			fn:     (*Modem).HangupAll,
+		},
+		{
+			name:   "hold and accept",
+			method: "org.freedesktop.ModemManager1.Modem.Voice.HoldAndAccept",
+			fn:     (*Modem).HoldAndAccept,
+		},
+		{
+			name:   "hangup and accept",
+			method: "org.freedesktop.ModemManager1.Modem.Voice.HangupAndAccept",
+			fn:     (*Modem).HangupAndAccept,
+		},
+		{
+			name:   "transfer",
+			method: "org.freedesktop.ModemManager1.Modem.Voice.Transfer",
+			fn:     (*Modem).Transfer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+					if diff := cmp.Diff(tt.method, method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if len(args) > 0 {
+						t.Fatalf("unexpected arguments: %v", args)
+					}
+
+					return dbus.Error{Name: unauthorizedError}
+				}},
+			}
+
+			if err := tt.fn(m, context.Background()); !errors.Is(err, os.ErrPermission) {
+				t.Fatalf("expected permission error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCallMethods(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		args   []interface{}
+		fn     func(c *Call, ctx context.Context) error
+	}{
+		{
+			name:   "accept",
+			method: "org.freedesktop.ModemManager1.Call.Accept",
+			fn:     (*Call).Accept,
+		},
+		{
+			name:   "hangup",
+			method: "org.freedesktop.ModemManager1.Call.Hangup",
+			fn:     (*Call).Hangup,
+		},
+		{
+			name:   "join multiparty",
+			method: "org.freedesktop.ModemManager1.Call.JoinMultiparty",
+			fn:     (*Call).JoinMultiparty,
+		},
+		{
+			name:   "leave multiparty",
+			method: "org.freedesktop.ModemManager1.Call.LeaveMultiparty",
+			fn:     (*Call).LeaveMultiparty,
+		},
+		{
+			name:   "send DTMF",
+			method: "org.freedesktop.ModemManager1.Call.SendDtmf",
+			args:   []interface{}{"1234#"},
+			fn: func(c *Call, ctx context.Context) error {
+				return c.SendDtmf(ctx, "1234#")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Call{
+				Index: 1,
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+					if diff := cmp.Diff(tt.method, method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/1"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(tt.args, args, cmpopts.EquateEmpty()); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Error{Name: unauthorizedError}
+				}},
+			}
+
+			if err := tt.fn(c, context.Background()); !errors.Is(err, os.ErrPermission) {
+				t.Fatalf("expected permission error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestCallWatchState(t *testing.T) {
+	c := &Call{
+		Index: 1,
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Call", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("StateChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal which should be skipped, followed by
+			// an incoming call being answered and hung up.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				int32(CallStateRingingIn),
+				int32(CallStateActive),
+				uint32(CallStateReasonAccepted),
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				int32(CallStateActive),
+				int32(CallStateTerminated),
+				uint32(CallStateReasonTerminated),
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := c.WatchState(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch call state: %v", err)
+	}
+
+	var got []CallStateChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []CallStateChange{
+		{
+			Old:    CallStateRingingIn,
+			New:    CallStateActive,
+			Reason: CallStateReasonAccepted,
+		},
+		{
+			Old:    CallStateActive,
+			New:    CallStateTerminated,
+			Reason: CallStateReasonTerminated,
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected call state changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestCallWatchDtmf(t *testing.T) {
+	c := &Call{
+		Index: 1,
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Call", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("DtmfReceived", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal which should be skipped, followed by
+			// two tones.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{"1", "2"}}
+			sigs <- &dbus.Signal{Body: []interface{}{"1"}}
+			sigs <- &dbus.Signal{Body: []interface{}{"#"}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	tones, err := c.WatchDtmf(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch DTMF: %v", err)
+	}
+
+	var got []string
+	for tone := range tones {
+		got = append(got, tone)
+	}
+
+	if diff := cmp.Diff([]string{"1", "#"}, got); diff != "" {
+		t.Fatalf("unexpected DTMF tones (-want +got):\n%s", diff)
+	}
+}
+
+func TestCallParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		ps   map[string]dbus.Variant
+	}{
+		{
+			name: "audio format type",
+			ps: map[string]dbus.Variant{
+				"AudioFormat": dbus.MakeVariant("foo"),
+			},
+		},
+		{
+			name: "audio format rate",
+			ps: map[string]dbus.Variant{
+				"AudioFormat": dbus.MakeVariant(map[string]dbus.Variant{
+					"rate": dbus.MakeVariant("foo"),
+				}),
+			},
+		},
+		{
+			name: "state",
+			ps: map[string]dbus.Variant{
+				"State": dbus.MakeVariant("foo"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c Call
+			if err := c.parse(tt.ps); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
+
+func TestModemWatchCalls(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Voice", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("CallAdded", member); diff != "" {
+					t.Fatalf("unexpected member (-want +got):\n%s", diff)
+				}
+
+				// Deliver a malformed signal and a call which no longer
+				// exists, both of which should be skipped, followed by an
+				// incoming and an outgoing call.
+				sigs := make(chan *dbus.Signal, 4)
+				sigs <- &dbus.Signal{Body: []interface{}{1}}
+				sigs <- &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/9")}}
+				sigs <- &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/0")}}
+				sigs <- &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Call/1")}}
+				close(sigs)
+
+				return sigs, nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				switch op {
+				case "/org/freedesktop/ModemManager1/Call/0":
+					return map[string]dbus.Variant{
+						"Direction": dbus.MakeVariant(uint32(CallDirectionIncoming)),
+						"Number":    dbus.MakeVariant("+15555551234"),
+					}, nil
+				case "/org/freedesktop/ModemManager1/Call/1":
+					return map[string]dbus.Variant{
+						"Direction": dbus.MakeVariant(uint32(CallDirectionOutgoing)),
+						"Number":    dbus.MakeVariant("+15555554321"),
+					}, nil
+				default:
+					return nil, dbus.Error{Name: unknownMethodError}
+				}
+			},
+		},
+	}
+
+	calls, err := m.WatchCalls(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch calls: %v", err)
+	}
+
+	var got []*Call
+	for c := range calls {
+		got = append(got, c)
+	}
+
+	want := []*Call{
+		{
+			Index:     0,
+			Direction: CallDirectionIncoming,
+			Number:    "+15555551234",
+		},
+		{
+			Index:     1,
+			Direction: CallDirectionOutgoing,
+			Number:    "+15555554321",
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(Call{})); diff != "" {
+		t.Fatalf("unexpected Calls (-want +got):\n%s", diff)
+	}
+
+	if !got[0].IsIncoming() || got[1].IsIncoming() {
+		t.Fatal("unexpected call directions")
+	}
+}
+
+func TestModemVoice(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Voice", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"Calls":         dbus.MakeVariant([]dbus.ObjectPath{}),
+				"EmergencyOnly": dbus.MakeVariant(true),
+			}, nil
+		}},
+	}
+
+	v, err := m.Voice(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get voice status: %v", err)
+	}
+
+	if diff := cmp.Diff(&Voice{EmergencyOnly: true}, v); diff != "" {
+		t.Fatalf("unexpected Voice (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsEmergencyNumber(t *testing.T) {
+	network := []string{"999", "000"}
+
+	tests := []struct {
+		number string
+		ok     bool
+	}{
+		{number: ""},
+		{number: "112", ok: true},
+		{number: "911", ok: true},
+		{number: "9-1-1", ok: true},
+		{number: "999", ok: true},
+		{number: "000", ok: true},
+		{number: "110"},
+		{number: "+15555551234"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			if diff := cmp.Diff(tt.ok, IsEmergencyNumber(tt.number, network)); diff != "" {
+				t.Fatalf("unexpected emergency number result (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// CDMAActivationProperties are the carrier provisioning values used to
+// manually activate a CDMA Modem. Zero values are unset.
+type CDMAActivationProperties struct {
+	MDN      string
+	MIN      string
+	MNAAAKey string
+	MNHAKey  string
+	PRL      []byte
+	SID      uint16
+	SPC      string
+}
+
+// A CDMAActivationState is the activation state of a CDMA modem.
+type CDMAActivationState int
+
+// Possible CDMAActivationState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemCdmaActivationState.
+const (
+	CDMAActivationStateUnknown CDMAActivationState = iota
+	CDMAActivationStateNotActivated
+	CDMAActivationStateActivating
+	CDMAActivationStatePartiallyActivated
+	CDMAActivationStateActivated
+)
+
+// A CDMAActivationError is an error which occurred during CDMA activation.
+type CDMAActivationError int
+
+// Possible CDMAActivationError values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Errors.html#MMCdmaActivationError.
+const (
+	CDMAActivationErrorNone CDMAActivationError = iota
+	CDMAActivationErrorUnknown
+	CDMAActivationErrorRoaming
+	CDMAActivationErrorWrongRadioInterface
+	CDMAActivationErrorCouldNotConnect
+	CDMAActivationErrorSecurityAuthenticationFailed
+	CDMAActivationErrorProvisioningFailed
+	CDMAActivationErrorNoSignal
+	CDMAActivationErrorTimedOut
+	CDMAActivationErrorStartFailed
+)
+
+// A CDMAActivationChange is a change in a CDMA modem's activation state.
+// StatusChanges contains any provisioning values, such as "mdn" or "min",
+// which changed during activation.
+type CDMAActivationChange struct {
+	State         CDMAActivationState
+	Error         CDMAActivationError
+	StatusChanges map[string]dbus.Variant
+}
+
+// ActivateCDMA performs over-the-air activation of a CDMA Modem using the
+// carrier's activation code.
+//
+// Activation may take a long time. If the operation times out, an error
+// compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (m *Modem) ActivateCDMA(ctx context.Context, carrierCode string) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "ModemCdma", "Activate"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		carrierCode,
+	)
+	if err != nil {
+		return toTimeout(toPermission(err))
+	}
+
+	return nil
+}
+
+// ActivateCDMAManual manually activates a CDMA Modem using carrier
+// provisioning values, rather than over-the-air activation.
+//
+// Activation may take a long time. If the operation times out, an error
+// compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (m *Modem) ActivateCDMAManual(ctx context.Context, p CDMAActivationProperties) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "ModemCdma", "ActivateManual"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		p.properties(),
+	)
+	if err != nil {
+		return toTimeout(toPermission(err))
+	}
+
+	return nil
+}
+
+// WatchCDMAActivation watches for changes to the Modem's CDMA activation
+// state. Each change is delivered on the returned channel, which is closed
+// when the context is canceled.
+func (m *Modem) WatchCDMAActivation(ctx context.Context) (<-chan CDMAActivationChange, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "ModemCdma"),
+		"ActivationStateChanged",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, parseCDMAActivationChange), nil
+}
+
+// parseCDMAActivationChange parses a CDMAActivationChange from a D-Bus
+// ActivationStateChanged signal.
+func parseCDMAActivationChange(s *dbus.Signal) (CDMAActivationChange, error) {
+	var (
+		state, aerr uint32
+		changes     map[string]dbus.Variant
+	)
+
+	if err := dbus.Store(s.Body, &state, &aerr, &changes); err != nil {
+		return CDMAActivationChange{}, fmt.Errorf("error parsing activation state change: %v", err)
+	}
+
+	return CDMAActivationChange{
+		State:         CDMAActivationState(state),
+		Error:         CDMAActivationError(aerr),
+		StatusChanges: changes,
+	}, nil
+}
+
+// properties packs CDMAActivationProperties into a properties map, omitting
+// any unset values.
+func (p CDMAActivationProperties) properties() map[string]dbus.Variant {
+	ps := make(map[string]dbus.Variant)
+	if p.MDN != "" {
+		ps["mdn"] = dbus.MakeVariant(p.MDN)
+	}
+	if p.MIN != "" {
+		ps["min"] = dbus.MakeVariant(p.MIN)
+	}
+	if p.MNAAAKey != "" {
+		ps["mn-aaa-key"] = dbus.MakeVariant(p.MNAAAKey)
+	}
+	if p.MNHAKey != "" {
+		ps["mn-ha-key"] = dbus.MakeVariant(p.MNHAKey)
+	}
+	if len(p.PRL) > 0 {
+		ps["prl"] = dbus.MakeVariant(p.PRL)
+	}
+	if p.SID != 0 {
+		ps["sid"] = dbus.MakeVariant(p.SID)
+	}
+	if p.SPC != "" {
+		ps["spc"] = dbus.MakeVariant(p.SPC)
+	}
+
+	return ps
+}
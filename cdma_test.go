@@ -0,0 +1,134 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemActivateCDMA(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.ModemCdma.Activate", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"*22899"}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: noReplyError}
+		}},
+	}
+
+	err := m.ActivateCDMA(context.Background(), "*22899")
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, but got: %v", err)
+	}
+}
+
+func TestModemActivateCDMAManual(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.ModemCdma.ActivateManual", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			// Only the set properties should be sent.
+			want := []interface{}{map[string]dbus.Variant{
+				"mdn": dbus.MakeVariant("5555551234"),
+				"min": dbus.MakeVariant("5555551234"),
+				"prl": dbus.MakeVariant([]byte{0x01, 0x02}),
+				"sid": dbus.MakeVariant(uint16(4137)),
+				"spc": dbus.MakeVariant("000000"),
+			}}
+
+			if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	err := m.ActivateCDMAManual(context.Background(), CDMAActivationProperties{
+		MDN: "5555551234",
+		MIN: "5555551234",
+		PRL: []byte{0x01, 0x02},
+		SID: 4137,
+		SPC: "000000",
+	})
+	if err != nil {
+		t.Fatalf("failed to activate: %v", err)
+	}
+}
+
+func TestModemWatchCDMAActivation(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.ModemCdma", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("ActivationStateChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal which should be skipped, followed by
+			// an activation failure and success.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				uint32(CDMAActivationStateNotActivated),
+				uint32(CDMAActivationErrorNoSignal),
+				map[string]dbus.Variant{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				uint32(CDMAActivationStateActivated),
+				uint32(CDMAActivationErrorNone),
+				map[string]dbus.Variant{"mdn": dbus.MakeVariant("5555551234")},
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := m.WatchCDMAActivation(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch activation: %v", err)
+	}
+
+	var got []CDMAActivationChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []CDMAActivationChange{
+		{
+			State:         CDMAActivationStateNotActivated,
+			Error:         CDMAActivationErrorNoSignal,
+			StatusChanges: map[string]dbus.Variant{},
+		},
+		{
+			State:         CDMAActivationStateActivated,
+			StatusChanges: map[string]dbus.Variant{"mdn": dbus.MakeVariant("5555551234")},
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmp.Comparer(variantEqual)); diff != "" {
+		t.Fatalf("unexpected activation changes (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,246 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A CellType is the radio access technology of a cell.
+type CellType int
+
+// Possible CellType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMCellType.
+const (
+	CellTypeUnknown CellType = iota
+	CellTypeCDMA
+	CellTypeGSM
+	CellTypeUMTS
+	CellTypeTDSCDMA
+	CellTypeLTE
+	CellTypeNR5G
+)
+
+// CellInfo contains information about a serving or neighboring cell. Only the
+// technology-specific field which corresponds to Type is non-nil.
+type CellInfo struct {
+	Type    CellType
+	Serving bool
+	GSM     *GSMCell
+	UMTS    *UMTSCell
+	LTE     *LTECell
+	NR5G    *NR5GCell
+}
+
+// GSMCell contains information about a GSM cell.
+type GSMCell struct {
+	OperatorID    string
+	LAC           int
+	CellID        uint64
+	ARFCN         int
+	BaseStationID int
+	RXLevel       int
+	TimingAdvance int
+}
+
+// UMTSCell contains information about a UMTS cell.
+type UMTSCell struct {
+	OperatorID string
+	LAC        int
+	CellID     uint64
+	UARFCN     int
+	PSC        int
+	RSCP, ECIO float64
+	PathLoss   int
+}
+
+// LTECell contains information about an LTE cell.
+type LTECell struct {
+	OperatorID     string
+	TAC            int
+	CellID         uint64
+	PhysicalCellID int
+	EARFCN         int
+	RSRP, RSRQ     float64
+	TimingAdvance  int
+}
+
+// NR5GCell contains information about a 5G NR cell.
+type NR5GCell struct {
+	OperatorID       string
+	TAC              int
+	CellID           uint64
+	PhysicalCellID   int
+	NRARFCN          int
+	RSRP, RSRQ, SINR float64
+	TimingAdvance    int
+}
+
+// GetCellInfo fetches information about the Modem's serving and neighboring
+// cells. This method requires ModemManager 1.20 or newer.
+func (m *Modem) GetCellInfo(ctx context.Context) ([]*CellInfo, error) {
+	var out []map[string]dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "GetCellInfo"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&out,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	cs := make([]*CellInfo, 0, len(out))
+	for _, ps := range out {
+		c, err := parseCellInfo(ps)
+		if err != nil {
+			return nil, err
+		}
+
+		cs = append(cs, c)
+	}
+
+	return cs, nil
+}
+
+// parseCellInfo parses CellInfo from a properties map.
+func parseCellInfo(ps map[string]dbus.Variant) (*CellInfo, error) {
+	// The cell type determines how the remaining properties are parsed, so it
+	// must be known before iterating over the map.
+	v, ok := ps["cell-type"]
+	if !ok {
+		return nil, fmt.Errorf("cell info is missing cell type")
+	}
+
+	vp := newValueParser(v)
+	c := &CellInfo{Type: CellType(vp.Int())}
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("error parsing %q: %v", "cell-type", err)
+	}
+
+	switch c.Type {
+	case CellTypeGSM:
+		c.GSM = &GSMCell{}
+	case CellTypeUMTS:
+		c.UMTS = &UMTSCell{}
+	case CellTypeLTE:
+		c.LTE = &LTECell{}
+	case CellTypeNR5G:
+		c.NR5G = &NR5GCell{}
+	}
+
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "serving":
+			c.Serving = vp.Bool()
+		default:
+			switch {
+			case c.GSM != nil:
+				parseGSMCell(k, vp, c.GSM)
+			case c.UMTS != nil:
+				parseUMTSCell(k, vp, c.UMTS)
+			case c.LTE != nil:
+				parseLTECell(k, vp, c.LTE)
+			case c.NR5G != nil:
+				parseNR5GCell(k, vp, c.NR5G)
+			}
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing cell info key %q: %v", k, err)
+		}
+	}
+
+	return c, nil
+}
+
+// parseGSMCell parses a single GSM cell property from vp into c.
+func parseGSMCell(k string, vp *valueParser, c *GSMCell) {
+	switch k {
+	case "operator-id":
+		c.OperatorID = vp.String()
+	case "lac":
+		c.LAC = int(vp.Hex())
+	case "ci":
+		c.CellID = vp.Hex()
+	case "arfcn":
+		c.ARFCN = vp.Int()
+	case "base-station-id":
+		c.BaseStationID = int(vp.Hex())
+	case "rx-level":
+		c.RXLevel = vp.Int()
+	case "timing-advance":
+		c.TimingAdvance = vp.Int()
+	}
+}
+
+// parseUMTSCell parses a single UMTS cell property from vp into c.
+func parseUMTSCell(k string, vp *valueParser, c *UMTSCell) {
+	switch k {
+	case "operator-id":
+		c.OperatorID = vp.String()
+	case "lac":
+		c.LAC = int(vp.Hex())
+	case "ci":
+		c.CellID = vp.Hex()
+	case "uarfcn":
+		c.UARFCN = vp.Int()
+	case "psc":
+		c.PSC = vp.Int()
+	case "rscp":
+		c.RSCP = vp.Float64()
+	case "ecio":
+		c.ECIO = vp.Float64()
+	case "path-loss":
+		c.PathLoss = vp.Int()
+	}
+}
+
+// parseLTECell parses a single LTE cell property from vp into c.
+func parseLTECell(k string, vp *valueParser, c *LTECell) {
+	switch k {
+	case "operator-id":
+		c.OperatorID = vp.String()
+	case "tac":
+		c.TAC = int(vp.Hex())
+	case "ci":
+		c.CellID = vp.Hex()
+	case "physical-ci":
+		c.PhysicalCellID = int(vp.Hex())
+	case "earfcn":
+		c.EARFCN = vp.Int()
+	case "rsrp":
+		c.RSRP = vp.Float64()
+	case "rsrq":
+		c.RSRQ = vp.Float64()
+	case "timing-advance":
+		c.TimingAdvance = vp.Int()
+	}
+}
+
+// parseNR5GCell parses a single 5G NR cell property from vp into c.
+func parseNR5GCell(k string, vp *valueParser, c *NR5GCell) {
+	switch k {
+	case "operator-id":
+		c.OperatorID = vp.String()
+	case "tac":
+		c.TAC = int(vp.Hex())
+	case "ci":
+		c.CellID = vp.Hex()
+	case "physical-ci":
+		c.PhysicalCellID = int(vp.Hex())
+	case "nrarfcn":
+		c.NRARFCN = vp.Int()
+	case "rsrp":
+		c.RSRP = vp.Float64()
+	case "rsrq":
+		c.RSRQ = vp.Float64()
+	case "sinr":
+		c.SINR = vp.Float64()
+	case "timing-advance":
+		c.TimingAdvance = vp.Int()
+	}
+}
@@ -0,0 +1,243 @@
+package modemmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemGetCellInfo(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells []map[string]dbus.Variant
+		want  []*CellInfo
+		ok    bool
+	}{
+		{
+			name: "GSM",
+			cells: []map[string]dbus.Variant{{
+				"cell-type":       dbus.MakeVariant(uint32(CellTypeGSM)),
+				"serving":         dbus.MakeVariant(true),
+				"operator-id":     dbus.MakeVariant("310260"),
+				"lac":             dbus.MakeVariant("1A2B"),
+				"ci":              dbus.MakeVariant("00C0FFEE"),
+				"arfcn":           dbus.MakeVariant(uint32(128)),
+				"base-station-id": dbus.MakeVariant("3F"),
+				"rx-level":        dbus.MakeVariant(uint32(40)),
+				"timing-advance":  dbus.MakeVariant(uint32(2)),
+			}},
+			want: []*CellInfo{{
+				Type:    CellTypeGSM,
+				Serving: true,
+				GSM: &GSMCell{
+					OperatorID:    "310260",
+					LAC:           0x1a2b,
+					CellID:        0xc0ffee,
+					ARFCN:         128,
+					BaseStationID: 0x3f,
+					RXLevel:       40,
+					TimingAdvance: 2,
+				},
+			}},
+			ok: true,
+		},
+		{
+			name: "UMTS",
+			cells: []map[string]dbus.Variant{{
+				"cell-type":   dbus.MakeVariant(uint32(CellTypeUMTS)),
+				"serving":     dbus.MakeVariant(false),
+				"operator-id": dbus.MakeVariant("310260"),
+				"lac":         dbus.MakeVariant("ff"),
+				"ci":          dbus.MakeVariant("0a1b2c3"),
+				"uarfcn":      dbus.MakeVariant(uint32(4385)),
+				"psc":         dbus.MakeVariant(uint32(300)),
+				"rscp":        dbus.MakeVariant(float64(-95)),
+				"ecio":        dbus.MakeVariant(float64(-7.5)),
+				"path-loss":   dbus.MakeVariant(uint32(120)),
+			}},
+			want: []*CellInfo{{
+				Type: CellTypeUMTS,
+				UMTS: &UMTSCell{
+					OperatorID: "310260",
+					LAC:        0xff,
+					CellID:     0xa1b2c3,
+					UARFCN:     4385,
+					PSC:        300,
+					RSCP:       -95,
+					ECIO:       -7.5,
+					PathLoss:   120,
+				},
+			}},
+			ok: true,
+		},
+		{
+			name: "LTE serving and neighbor",
+			cells: []map[string]dbus.Variant{
+				{
+					"cell-type":      dbus.MakeVariant(uint32(CellTypeLTE)),
+					"serving":        dbus.MakeVariant(true),
+					"operator-id":    dbus.MakeVariant("310260"),
+					"tac":            dbus.MakeVariant("7D01"),
+					"ci":             dbus.MakeVariant("1A2D60B"),
+					"physical-ci":    dbus.MakeVariant("1F"),
+					"earfcn":         dbus.MakeVariant(uint32(5110)),
+					"rsrp":           dbus.MakeVariant(float64(-98)),
+					"rsrq":           dbus.MakeVariant(float64(-10.5)),
+					"timing-advance": dbus.MakeVariant(uint32(7)),
+				},
+				{
+					"cell-type":   dbus.MakeVariant(uint32(CellTypeLTE)),
+					"serving":     dbus.MakeVariant(false),
+					"physical-ci": dbus.MakeVariant("20"),
+					"earfcn":      dbus.MakeVariant(uint32(5110)),
+					"rsrp":        dbus.MakeVariant(float64(-110)),
+				},
+			},
+			want: []*CellInfo{
+				{
+					Type:    CellTypeLTE,
+					Serving: true,
+					LTE: &LTECell{
+						OperatorID:     "310260",
+						TAC:            0x7d01,
+						CellID:         0x1a2d60b,
+						PhysicalCellID: 0x1f,
+						EARFCN:         5110,
+						RSRP:           -98,
+						RSRQ:           -10.5,
+						TimingAdvance:  7,
+					},
+				},
+				{
+					Type: CellTypeLTE,
+					LTE: &LTECell{
+						PhysicalCellID: 0x20,
+						EARFCN:         5110,
+						RSRP:           -110,
+					},
+				},
+			},
+			ok: true,
+		},
+		{
+			name: "NR5G",
+			cells: []map[string]dbus.Variant{{
+				"cell-type":      dbus.MakeVariant(uint32(CellTypeNR5G)),
+				"serving":        dbus.MakeVariant(true),
+				"operator-id":    dbus.MakeVariant("310260"),
+				"tac":            dbus.MakeVariant("00A1B2"),
+				"ci":             dbus.MakeVariant("F00000001"),
+				"physical-ci":    dbus.MakeVariant("3E8"),
+				"nrarfcn":        dbus.MakeVariant(uint32(520110)),
+				"rsrp":           dbus.MakeVariant(float64(-85)),
+				"rsrq":           dbus.MakeVariant(float64(-11)),
+				"sinr":           dbus.MakeVariant(float64(18.5)),
+				"timing-advance": dbus.MakeVariant(uint32(3)),
+			}},
+			want: []*CellInfo{{
+				Type:    CellTypeNR5G,
+				Serving: true,
+				NR5G: &NR5GCell{
+					OperatorID:     "310260",
+					TAC:            0xa1b2,
+					CellID:         0xf00000001,
+					PhysicalCellID: 0x3e8,
+					NRARFCN:        520110,
+					RSRP:           -85,
+					RSRQ:           -11,
+					SINR:           18.5,
+					TimingAdvance:  3,
+				},
+			}},
+			ok: true,
+		},
+		{
+			name: "unknown type",
+			cells: []map[string]dbus.Variant{{
+				"cell-type": dbus.MakeVariant(uint32(CellTypeCDMA)),
+				"serving":   dbus.MakeVariant(true),
+				"sid":       dbus.MakeVariant("1"),
+			}},
+			want: []*CellInfo{{
+				Type:    CellTypeCDMA,
+				Serving: true,
+			}},
+			ok: true,
+		},
+		{
+			name: "missing type",
+			cells: []map[string]dbus.Variant{{
+				"serving": dbus.MakeVariant(true),
+			}},
+		},
+		{
+			name: "bad serving",
+			cells: []map[string]dbus.Variant{{
+				"cell-type": dbus.MakeVariant(uint32(CellTypeLTE)),
+				"serving":   dbus.MakeVariant(uint32(1)),
+			}},
+		},
+		{
+			name: "bad hex type",
+			cells: []map[string]dbus.Variant{{
+				"cell-type": dbus.MakeVariant(uint32(CellTypeLTE)),
+				"tac":       dbus.MakeVariant(uint32(1)),
+			}},
+		},
+		{
+			name: "bad hex value",
+			cells: []map[string]dbus.Variant{{
+				"cell-type": dbus.MakeVariant(uint32(CellTypeGSM)),
+				"ci":        dbus.MakeVariant("xyz"),
+			}},
+		},
+		{
+			name: "bad float",
+			cells: []map[string]dbus.Variant{{
+				"cell-type": dbus.MakeVariant(uint32(CellTypeNR5G)),
+				"rsrp":      dbus.MakeVariant("-85"),
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				// Verify all of the expected inputs before returning canned cells.
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+					if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.GetCellInfo", method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(0, len(args)); diff != "" {
+						t.Fatalf("unexpected number of arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Store([]interface{}{tt.cells}, out)
+				}},
+			}
+
+			cells, err := m.GetCellInfo(context.Background())
+			if tt.ok && err != nil {
+				t.Fatalf("failed to get cell info: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if err != nil {
+				t.Logf("err: %v", err)
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, cells); diff != "" {
+				t.Fatalf("unexpected cell info (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,196 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A CellBroadcast is a Cell Broadcast Service (CBS) message received by a
+// Modem, such as a public warning system alert.
+type CellBroadcast struct {
+	Index       int
+	Channel     int
+	MessageCode int
+	State       CellBroadcastState
+	Text        string
+	Update      int
+
+	c *Client
+}
+
+// A CellBroadcastState is the state of a CellBroadcast.
+type CellBroadcastState int
+
+// Possible CellBroadcastState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMCbmState.
+const (
+	CellBroadcastStateUnknown CellBroadcastState = iota
+	CellBroadcastStateReceiving
+	CellBroadcastStateReceived
+)
+
+// A ChannelRange is an inclusive range of cell broadcast channels.
+type ChannelRange struct {
+	Start, End int
+}
+
+// CellBroadcasts lists the CellBroadcast messages received by the Modem. This
+// method requires ModemManager 1.24 or newer.
+func (m *Modem) CellBroadcasts(ctx context.Context) ([]*CellBroadcast, error) {
+	var ops []dbus.ObjectPath
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "CellBroadcast", "List"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&ops,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	cbs := make([]*CellBroadcast, 0, len(ops))
+	for _, op := range ops {
+		cb, err := m.c.cellBroadcast(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+
+		cbs = append(cbs, cb)
+	}
+
+	return cbs, nil
+}
+
+// WatchCellBroadcasts watches for CellBroadcast messages received by the
+// Modem. Each message is delivered on the returned channel, which is closed
+// when the context is canceled. This method requires ModemManager 1.24 or
+// newer.
+func (m *Modem) WatchCellBroadcasts(ctx context.Context) (<-chan *CellBroadcast, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "CellBroadcast"),
+		"Added",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (*CellBroadcast, error) {
+		var op dbus.ObjectPath
+		if err := dbus.Store(s.Body, &op); err != nil {
+			return nil, fmt.Errorf("error parsing added cell broadcast: %v", err)
+		}
+
+		// The message may be deleted before it can be fetched, in which case
+		// it is skipped.
+		return m.c.cellBroadcast(ctx, op)
+	}), nil
+}
+
+// CellBroadcastChannels fetches the cell broadcast channels the Modem
+// receives messages on. This method requires ModemManager 1.24 or newer.
+func (m *Modem) CellBroadcastChannels(ctx context.Context) ([]ChannelRange, error) {
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "CellBroadcast"),
+		"Channels",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	crs := vp.ChannelRanges()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse cell broadcast channels: %v", err)
+	}
+
+	return crs, nil
+}
+
+// SetCellBroadcastChannels sets the cell broadcast channels the Modem receives
+// messages on. This method requires ModemManager 1.24 or newer.
+func (m *Modem) SetCellBroadcastChannels(ctx context.Context, crs []ChannelRange) error {
+	// Each range is packed as a D-Bus (uu) struct.
+	type channelRange struct {
+		Start, End uint32
+	}
+
+	args := make([]channelRange, 0, len(crs))
+	for _, cr := range crs {
+		args = append(args, channelRange{
+			Start: uint32(cr.Start),
+			End:   uint32(cr.End),
+		})
+	}
+
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "CellBroadcast", "SetChannels"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		args,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// cellBroadcast fetches a CellBroadcast by its object path.
+func (c *Client) cellBroadcast(ctx context.Context, op dbus.ObjectPath) (*CellBroadcast, error) {
+	ps, err := c.getAll(ctx, op, interfacePath("Cbm"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Note the CellBroadcast's index in the struct by fetching that index from
+	// the last element of the D-Bus object path.
+	idx, err := strconv.Atoi(path.Base(string(op)))
+	if err != nil {
+		return nil, err
+	}
+
+	cb := &CellBroadcast{
+		Index: idx,
+		c:     c,
+	}
+
+	if err := cb.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return cb, nil
+}
+
+// parse parses a properties map into the CellBroadcast's fields.
+func (cb *CellBroadcast) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "Channel":
+			cb.Channel = vp.Int()
+		case "MessageCode":
+			cb.MessageCode = vp.Int()
+		case "State":
+			cb.State = CellBroadcastState(vp.Int())
+		case "Text":
+			cb.Text = vp.String()
+		case "Update":
+			cb.Update = vp.Int()
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package modemmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemCellBroadcasts(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.CellBroadcast.List", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				return dbus.Store([]interface{}{[]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/Cbm/1",
+				}}, out)
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Cbm/1"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Cbm", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				return map[string]dbus.Variant{
+					"Channel":     dbus.MakeVariant(uint32(4370)),
+					"MessageCode": dbus.MakeVariant(uint32(10)),
+					"State":       dbus.MakeVariant(uint32(CellBroadcastStateReceived)),
+					"Text":        dbus.MakeVariant("Presidential alert"),
+					"Update":      dbus.MakeVariant(uint32(1)),
+				}, nil
+			},
+		},
+	}
+
+	cbs, err := m.CellBroadcasts(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list cell broadcasts: %v", err)
+	}
+
+	want := []*CellBroadcast{{
+		Index:       1,
+		Channel:     4370,
+		MessageCode: 10,
+		State:       CellBroadcastStateReceived,
+		Text:        "Presidential alert",
+		Update:      1,
+	}}
+
+	if diff := cmp.Diff(want, cbs, cmpopts.IgnoreUnexported(CellBroadcast{})); diff != "" {
+		t.Fatalf("unexpected CellBroadcasts (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemCellBroadcastChannels(t *testing.T) {
+	m := &Modem{
+		c: &Client{get: func(_ context.Context, _ dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.CellBroadcast", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("Channels", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant([][]interface{}{
+				{uint32(4370), uint32(4383)},
+				{uint32(4400), uint32(4400)},
+			}), nil
+		}},
+	}
+
+	crs, err := m.CellBroadcastChannels(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get channels: %v", err)
+	}
+
+	want := []ChannelRange{
+		{Start: 4370, End: 4383},
+		{Start: 4400, End: 4400},
+	}
+
+	if diff := cmp.Diff(want, crs); diff != "" {
+		t.Fatalf("unexpected ChannelRanges (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetCellBroadcastChannels(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.CellBroadcast.SetChannels", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("a(uu)", dbus.SignatureOf(args...).String()); diff != "" {
+				t.Fatalf("unexpected arguments signature (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	err := m.SetCellBroadcastChannels(context.Background(), []ChannelRange{
+		{Start: 4370, End: 4383},
+	})
+	if err != nil {
+		t.Fatalf("failed to set channels: %v", err)
+	}
+}
+
+func TestModemWatchCellBroadcasts(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.CellBroadcast", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("Added", member); diff != "" {
+					t.Fatalf("unexpected member (-want +got):\n%s", diff)
+				}
+
+				// Deliver a malformed signal and a message which no longer
+				// exists, both of which should be skipped, followed by a
+				// valid message.
+				sigs := make(chan *dbus.Signal, 3)
+				sigs <- &dbus.Signal{Body: []interface{}{1}}
+				sigs <- &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Cbm/9")}}
+				sigs <- &dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Cbm/0")}}
+				close(sigs)
+
+				return sigs, nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				if op != "/org/freedesktop/ModemManager1/Cbm/0" {
+					return nil, dbus.Error{Name: unknownMethodError}
+				}
+
+				return map[string]dbus.Variant{
+					"Channel": dbus.MakeVariant(uint32(4370)),
+					"Text":    dbus.MakeVariant("Extreme alert"),
+				}, nil
+			},
+		},
+	}
+
+	cbs, err := m.WatchCellBroadcasts(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch cell broadcasts: %v", err)
+	}
+
+	var got []*CellBroadcast
+	for cb := range cbs {
+		got = append(got, cb)
+	}
+
+	want := []*CellBroadcast{{
+		Index:   0,
+		Channel: 4370,
+		Text:    "Extreme alert",
+	}}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(CellBroadcast{})); diff != "" {
+		t.Fatalf("unexpected CellBroadcasts (-want +got):\n%s", diff)
+	}
+}
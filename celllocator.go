@@ -0,0 +1,63 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+)
+
+// A CellLocator resolves the coordinates of a cell identified by a
+// Location3GPP, such as by querying OpenCelliD or an offline cell database.
+type CellLocator interface {
+	LocateCell(ctx context.Context, cell Location3GPP) (Coordinates, error)
+}
+
+// A CellLocatorFunc adapts a function to a CellLocator.
+type CellLocatorFunc func(ctx context.Context, cell Location3GPP) (Coordinates, error)
+
+// LocateCell implements CellLocator.
+func (fn CellLocatorFunc) LocateCell(ctx context.Context, cell Location3GPP) (Coordinates, error) {
+	return fn(ctx, cell)
+}
+
+// Coordinates are a latitude and longitude in degrees.
+type Coordinates struct {
+	Latitude, Longitude float64
+
+	// Source is the LocationSource which produced the Coordinates. Cell-based
+	// Coordinates, which are only approximate, use LocationSource3GPPLACCI.
+	Source LocationSource
+}
+
+// ApproximateCoordinates determines the Coordinates of a Location. GPS
+// coordinates are preferred when available. Otherwise, the serving cell is
+// resolved using cl, if cl is not nil.
+//
+// If no coordinates can be determined, an error compatible with
+// 'errors.Is(err, os.ErrNotExist)' is returned.
+func ApproximateCoordinates(ctx context.Context, l *Location, cl CellLocator) (Coordinates, error) {
+	if l == nil {
+		return Coordinates{}, fmt.Errorf("no location: %w", os.ErrNotExist)
+	}
+
+	// A GPS location without a fix time has not yet acquired any satellites.
+	if g := l.GPS; g != nil && !g.Time.IsZero() {
+		return Coordinates{
+			Latitude:  g.Latitude,
+			Longitude: g.Longitude,
+			Source:    LocationSourceGPSRaw,
+		}, nil
+	}
+
+	if l.ThreeGPP == nil || cl == nil {
+		return Coordinates{}, fmt.Errorf("no GPS or cell location: %w", os.ErrNotExist)
+	}
+
+	c, err := cl.LocateCell(ctx, *l.ThreeGPP)
+	if err != nil {
+		return Coordinates{}, err
+	}
+
+	c.Source = LocationSource3GPPLACCI
+	return c, nil
+}
@@ -0,0 +1,107 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestApproximateCoordinates(t *testing.T) {
+	var (
+		cell = &Location3GPP{
+			MCC: "310",
+			MNC: "410",
+			TAC: 0x1a2b,
+			CID: 0x0a2b3c4d,
+		}
+
+		errLookup = errors.New("lookup failed")
+	)
+
+	cl := CellLocatorFunc(func(_ context.Context, c Location3GPP) (Coordinates, error) {
+		if diff := cmp.Diff(*cell, c); diff != "" {
+			t.Fatalf("unexpected cell (-want +got):\n%s", diff)
+		}
+
+		return Coordinates{Latitude: 38.9, Longitude: -77.0}, nil
+	})
+
+	tests := []struct {
+		name string
+		l    *Location
+		cl   CellLocator
+		c    Coordinates
+		err  error
+	}{
+		{
+			name: "nil",
+			err:  os.ErrNotExist,
+		},
+		{
+			name: "GPS",
+			l: &Location{
+				GPS: &GPSLocation{
+					Time:      time.Unix(1, 0),
+					Latitude:  38.8977,
+					Longitude: -77.0365,
+				},
+				ThreeGPP: cell,
+			},
+			cl: cl,
+			c: Coordinates{
+				Latitude:  38.8977,
+				Longitude: -77.0365,
+				Source:    LocationSourceGPSRaw,
+			},
+		},
+		{
+			name: "GPS no fix",
+			l: &Location{
+				GPS:      &GPSLocation{},
+				ThreeGPP: cell,
+			},
+			cl: cl,
+			c: Coordinates{
+				Latitude:  38.9,
+				Longitude: -77.0,
+				Source:    LocationSource3GPPLACCI,
+			},
+		},
+		{
+			name: "no locator",
+			l:    &Location{ThreeGPP: cell},
+			err:  os.ErrNotExist,
+		},
+		{
+			name: "no cell",
+			l:    &Location{},
+			cl:   cl,
+			err:  os.ErrNotExist,
+		},
+		{
+			name: "lookup failed",
+			l:    &Location{ThreeGPP: &Location3GPP{MCC: "310", MNC: "410", CID: 0x0a2b3c4d}},
+			cl: CellLocatorFunc(func(_ context.Context, _ Location3GPP) (Coordinates, error) {
+				return Coordinates{}, errLookup
+			}),
+			err: errLookup,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ApproximateCoordinates(context.Background(), tt.l, tt.cl)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.c, c); diff != "" {
+				t.Fatalf("unexpected Coordinates (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,25 +21,56 @@ const (
 	methodGet    = "org.freedesktop.DBus.Properties.Get"
 	methodGetAll = "org.freedesktop.DBus.Properties.GetAll"
 
+	// Well-known signal names.
+	interfaceProperties     = "org.freedesktop.DBus.Properties"
+	signalPropertiesChanged = "PropertiesChanged"
+	interfaceObjectManager  = "org.freedesktop.DBus.ObjectManager"
+	signalInterfacesRemoved = "InterfacesRemoved"
+
 	// Well-known error names which map to Go error types.
 	//
 	// os.ErrNotExist
 	unknownMethodError  = "org.freedesktop.DBus.Error.UnknownMethod"
 	serviceUnknownError = "org.freedesktop.DBus.Error.ServiceUnknown"
+	notFoundError       = "org.freedesktop.ModemManager1.Error.Core.NotFound"
 	// os.ErrPermission
 	unauthorizedError = "org.freedesktop.ModemManager1.Error.Core.Unauthorized"
+	// os.ErrDeadlineExceeded
+	noReplyError        = "org.freedesktop.DBus.Error.NoReply"
+	networkTimeoutError = "org.freedesktop.ModemManager1.Error.MobileEquipment.NetworkTimeout"
+	// ErrIncorrectPassword
+	incorrectPasswordError = "org.freedesktop.ModemManager1.Error.MobileEquipment.IncorrectPassword"
+	// ErrPUKRequired
+	simPUKError = "org.freedesktop.ModemManager1.Error.MobileEquipment.SimPuk"
 )
 
 // A Client allows control of ModemManager.
 type Client struct {
 	Version string
 
+	// Lenient, if set, skips Modem and Bearer properties which cannot be
+	// parsed rather than returning an error. Each skipped property is left as
+	// its zero value and reported in the object's Warnings.
+	Lenient bool
+
+	// MapError, if set, is called with an *Error describing each D-Bus error
+	// returned to the Client. If MapError returns a non-nil error, it is used
+	// as the *Error's Err in place of this package's default category, so
+	// that comparisons such as 'errors.Is(err, myErr)' work as expected.
+	MapError func(e *Error) error
+
+	// OperatorResolver, if set, resolves the operator names reported with
+	// numeric operator codes, such as Modem3GPP.OperatorName. If it does not
+	// know of an operator, the name reported by ModemManager is used.
+	OperatorResolver OperatorResolver
+
 	// Functions which normally manipulate D-Bus but are also swappable for
 	// tests.
 	close  func() error
 	call   callFunc
 	get    getFunc
 	getAll getAllFunc
+	watch  watchFunc
 }
 
 // Dial dials a D-Bus connection to ModemManager and returns a Client. If the
@@ -51,14 +82,16 @@ func Dial(ctx context.Context) (*Client, error) {
 		return nil, err
 	}
 
-	return initClient(ctx, &Client{
-		// Wrap the *dbus.Conn completely to abstract away all of the low-level
-		// D-Bus logic for ease of unit testing.
-		close:  conn.Close,
-		call:   makeCall(conn),
-		get:    makeGet(conn),
-		getAll: makeGetAll(conn),
-	})
+	// Wrap the *dbus.Conn completely to abstract away all of the low-level
+	// D-Bus logic for ease of unit testing. Errors are mapped using the
+	// Client's MapError at the time of each call.
+	c := &Client{close: conn.Close}
+	c.call = makeCall(conn, c.mapError)
+	c.get = makeGet(conn, c.mapError)
+	c.getAll = makeGetAll(conn, c.mapError)
+	c.watch = makeWatch(conn, c.mapError)
+
+	return initClient(ctx, c)
 }
 
 // initClient verifies a Client can speak with ModemManager.
@@ -131,6 +164,63 @@ func (c *Client) ForEachModem(ctx context.Context, fn func(ctx context.Context,
 	}
 }
 
+// watchProperties watches for changes to an object's properties on the input
+// D-Bus interface. Each map of changed properties is delivered on the returned
+// channel, which is closed when the context is canceled.
+func (c *Client) watchProperties(ctx context.Context, op dbus.ObjectPath, iface string) (<-chan map[string]dbus.Variant, error) {
+	sigs, err := c.watch(ctx, op, interfaceProperties, signalPropertiesChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (map[string]dbus.Variant, error) {
+		// The changed properties signal is emitted for all of an object's
+		// interfaces, so only consider the one requested by the caller.
+		var (
+			name        string
+			changed     map[string]dbus.Variant
+			invalidated []string
+		)
+
+		if err := dbus.Store(s.Body, &name, &changed, &invalidated); err != nil {
+			return nil, fmt.Errorf("error parsing changed properties: %v", err)
+		}
+		if name != iface {
+			return nil, fmt.Errorf("unexpected properties interface: %q", name)
+		}
+
+		return changed, nil
+	}), nil
+}
+
+// watchRemoved returns a channel which is closed when the object op is removed
+// from ModemManager, or never if the context is canceled first.
+func (c *Client) watchRemoved(ctx context.Context, op dbus.ObjectPath) (<-chan struct{}, error) {
+	sigs, err := c.watch(ctx, baseObject, interfaceObjectManager, signalInterfacesRemoved)
+	if err != nil {
+		return nil, err
+	}
+
+	removed := make(chan struct{})
+	go func() {
+		for s := range sigs {
+			var (
+				rop    dbus.ObjectPath
+				ifaces []string
+			)
+
+			if err := dbus.Store(s.Body, &rop, &ifaces); err != nil || rop != op {
+				continue
+			}
+
+			close(removed)
+			return
+		}
+	}()
+
+	return removed, nil
+}
+
 // toNotExist converts a D-Bus error with the input name to a wrapped error
 // containing os.ErrNotExist. If the error is not a dbus.Error or does not have
 // a matching name, it returns the input error.
@@ -157,6 +247,19 @@ func toPermission(err error) error {
 	return fmt.Errorf("permission denied: %v: %w", err, os.ErrPermission)
 }
 
+// toTimeout converts a D-Bus timeout error to a wrapped error containing
+// os.ErrDeadlineExceeded. If the error is not a dbus.Error or does not have a
+// matching name, it returns the input error.
+func toTimeout(err error) error {
+	var derr dbus.Error
+	if !errors.As(err, &derr) || (derr.Name != noReplyError && derr.Name != networkTimeoutError) {
+		return err
+	}
+
+	// Also return the input error which may have wrapped the dbus.Error.
+	return fmt.Errorf("timed out: %v: %w", err, os.ErrDeadlineExceeded)
+}
+
 // objectPath prepends its arguments with the base object path for ModemManager.
 func objectPath(ss ...string) dbus.ObjectPath {
 	p := dbus.ObjectPath(path.Join(
@@ -180,7 +283,8 @@ func interfacePath(ss ...string) string {
 }
 
 // A callFunc is a function which calls a D-Bus method on an object and
-// optionally stores its output in the pointer provided to out.
+// optionally stores its output in the pointer provided to out. Methods with
+// multiple outputs pass a []interface{} of pointers to out.
 type callFunc func(ctx context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error
 
 // A getFunc is a function which fetches a D-Bus property from an object.
@@ -189,31 +293,91 @@ type getFunc func(ctx context.Context, op dbus.ObjectPath, iface, prop string) (
 // A getAllFunc is a function which fetches all of an object's D-Bus properties.
 type getAllFunc func(ctx context.Context, op dbus.ObjectPath, iface string) (map[string]dbus.Variant, error)
 
+// A watchFunc is a function which subscribes to a D-Bus signal emitted by an
+// object. Signals are delivered on the returned channel until the context is
+// canceled, at which point the channel is closed.
+type watchFunc func(ctx context.Context, op dbus.ObjectPath, iface, member string) (<-chan *dbus.Signal, error)
+
+// mapError applies the Client's MapError function, if set.
+func (c *Client) mapError(e *Error) error {
+	if c.MapError == nil {
+		return nil
+	}
+
+	return c.MapError(e)
+}
+
+// An objectCallFunc is a function which calls the D-Bus method on the object
+// described by info. Errors are converted to *Error containing info.
+type objectCallFunc func(ctx context.Context, info Error, out interface{}, args ...interface{}) error
+
+// makeObjectCall produces an objectCallFunc which converts errors to *Error
+// using mapError.
+func makeObjectCall(c *dbus.Conn, mapError func(e *Error) error) objectCallFunc {
+	return func(ctx context.Context, info Error, out interface{}, args ...interface{}) error {
+		call := c.Object(service, info.Path).CallWithContext(ctx, info.Method, 0, args...)
+
+		// Store the results of the call only when out is not nil. Methods
+		// which return multiple values pass a slice of pointers for each.
+		err := call.Err
+		if err == nil {
+			switch out := out.(type) {
+			case nil:
+			case []interface{}:
+				err = call.Store(out...)
+			default:
+				err = call.Store(out)
+			}
+		}
+		if err != nil {
+			return toError(err, info, mapError)
+		}
+
+		return nil
+	}
+}
+
 // makeCall produces a callFunc which call's a D-Bus method on an object.
-func makeCall(c *dbus.Conn) callFunc {
+// Errors are converted to *Error using mapError.
+func makeCall(c *dbus.Conn, mapError func(e *Error) error) callFunc {
+	// Adapt a callFunc using the more generic objectCallFunc.
+	call := makeObjectCall(c, mapError)
 	return func(ctx context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
-		call := c.Object(service, op).CallWithContext(ctx, method, 0, args...)
-		if call.Err != nil {
-			return fmt.Errorf("failed to call %q: %w", method, call.Err)
+		// The method's interface is its name without the final element.
+		iface := method
+		if i := strings.LastIndex(method, "."); i != -1 {
+			iface = method[:i]
+		}
+
+		info := Error{
+			Path:      op,
+			Interface: iface,
+			Method:    method,
 		}
 
-		// Store the results of the call only when out is not nil.
-		if out == nil {
-			return nil
+		if err := call(ctx, info, out, args...); err != nil {
+			return fmt.Errorf("failed to call %q: %w", method, err)
 		}
 
-		return call.Store(out)
+		return nil
 	}
 }
 
 // makeGet produces a getFunc which can fetch an object's property from a D-Bus
 // interface.
-func makeGet(c *dbus.Conn) getFunc {
-	// Adapt a getFunc using the more generic callFunc.
-	call := makeCall(c)
+func makeGet(c *dbus.Conn, mapError func(e *Error) error) getFunc {
+	// Adapt a getFunc using the more generic objectCallFunc.
+	call := makeObjectCall(c, mapError)
 	return func(ctx context.Context, op dbus.ObjectPath, iface, prop string) (dbus.Variant, error) {
+		info := Error{
+			Path:      op,
+			Interface: iface,
+			Method:    methodGet,
+			Property:  prop,
+		}
+
 		var out dbus.Variant
-		if err := call(ctx, methodGet, op, &out, iface, prop); err != nil {
+		if err := call(ctx, info, &out, iface, prop); err != nil {
 			return dbus.Variant{}, fmt.Errorf("failed to get property %q for %q: %w",
 				prop, iface, err)
 		}
@@ -224,12 +388,18 @@ func makeGet(c *dbus.Conn) getFunc {
 
 // makeGetAll produces a getAllFunc which fetches all of an object's properties
 // from a D-Bus interface.
-func makeGetAll(c *dbus.Conn) getAllFunc {
-	// Adapt a getAllFunc using the more generic callFunc.
-	call := makeCall(c)
+func makeGetAll(c *dbus.Conn, mapError func(e *Error) error) getAllFunc {
+	// Adapt a getAllFunc using the more generic objectCallFunc.
+	call := makeObjectCall(c, mapError)
 	return func(ctx context.Context, op dbus.ObjectPath, iface string) (map[string]dbus.Variant, error) {
+		info := Error{
+			Path:      op,
+			Interface: iface,
+			Method:    methodGetAll,
+		}
+
 		var out map[string]dbus.Variant
-		if err := call(ctx, methodGetAll, op, &out, iface); err != nil {
+		if err := call(ctx, info, &out, iface); err != nil {
 			return nil, fmt.Errorf("failed to get all properties for %q: %w",
 				iface, err)
 		}
@@ -238,6 +408,95 @@ func makeGetAll(c *dbus.Conn) getAllFunc {
 	}
 }
 
+// makeWatch produces a watchFunc which subscribes to an object's D-Bus signals.
+func makeWatch(c *dbus.Conn, mapError func(e *Error) error) watchFunc {
+	return func(ctx context.Context, op dbus.ObjectPath, iface, member string) (<-chan *dbus.Signal, error) {
+		opts := []dbus.MatchOption{
+			dbus.WithMatchObjectPath(op),
+			dbus.WithMatchInterface(iface),
+			dbus.WithMatchMember(member),
+		}
+
+		if err := c.AddMatchSignalContext(ctx, opts...); err != nil {
+			info := Error{
+				Path:      op,
+				Interface: iface,
+				Method:    "org.freedesktop.DBus.AddMatch",
+			}
+
+			return nil, fmt.Errorf("failed to watch signal %q for %q: %w",
+				member, iface, toError(err, info, mapError))
+		}
+
+		// The connection delivers every signal to every registered channel, so
+		// only forward the signals which match this subscription.
+		in := make(chan *dbus.Signal, 16)
+		c.Signal(in)
+
+		out := make(chan *dbus.Signal)
+		go func() {
+			defer func() {
+				c.RemoveSignal(in)
+				// ctx is already canceled at this point so it can't be used to
+				// remove the match rule.
+				_ = c.RemoveMatchSignal(opts...)
+				close(out)
+			}()
+
+			name := iface + "." + member
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case s, ok := <-in:
+					if !ok {
+						// Connection closed.
+						return
+					}
+
+					if s.Path != op || s.Name != name {
+						continue
+					}
+
+					select {
+					case out <- s:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+
+		return out, nil
+	}
+}
+
+// forward converts each value received on in, such as a D-Bus signal, to
+// another value using fn and delivers it on the returned channel. The returned
+// channel is closed when in is closed or the context is canceled. Values for
+// which fn returns an error, such as malformed or irrelevant signals, are
+// skipped.
+func forward[T, U any](ctx context.Context, in <-chan T, fn func(v T) (U, error)) <-chan U {
+	out := make(chan U)
+	go func() {
+		defer close(out)
+		for v := range in {
+			u, err := fn(v)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case out <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
 func panicf(format string, a ...interface{}) {
 	panic(fmt.Sprintf(format, a...))
 }
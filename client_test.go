@@ -111,6 +111,34 @@ func TestClientModemBadType(t *testing.T) {
 	t.Logf("err: %v", err)
 }
 
+func TestClientModemLenient(t *testing.T) {
+	c := &Client{
+		Lenient: true,
+		getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+			// Device must be a string, not an integer.
+			return map[string]dbus.Variant{
+				"Device": dbus.MakeVariant(1),
+				"Model":  dbus.MakeVariant("foo"),
+			}, nil
+		},
+	}
+
+	m, err := c.Modem(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("failed to get modem: %v", err)
+	}
+
+	if diff := cmp.Diff("foo", m.Model); diff != "" {
+		t.Fatalf("unexpected model (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(1, len(m.Warnings)); diff != "" {
+		t.Fatalf("unexpected number of warnings (-want +got):\n%s", diff)
+	}
+
+	t.Logf("warning: %v", m.Warnings[0])
+}
+
 func TestClientModemOK(t *testing.T) {
 	c := &Client{
 		// Verify all of the expected inputs before returning canned properties.
@@ -125,6 +153,7 @@ func TestClientModemOK(t *testing.T) {
 
 			// Test data copied from mdlayher's modem with some tweaks.
 			return map[string]dbus.Variant{
+				"AccessTechnologies": dbus.MakeVariant(uint32(AccessTechnologyLTE)),
 				"Bearers": dbus.MakeVariant([]dbus.ObjectPath{
 					"/org/freedesktop/ModemManager1/Bearer/0",
 				}),
@@ -155,10 +184,21 @@ func TestClientModemOK(t *testing.T) {
 						uint32(PortTypeNet),
 					},
 				}),
-				"PowerState":  dbus.MakeVariant(uint32(PowerStateOn)),
-				"PrimaryPort": dbus.MakeVariant("cdc-wdm0"),
-				"Revision":    dbus.MakeVariant("SWI9X30C_02.33.03.00"),
-				"State":       dbus.MakeVariant(int32(StateConnected)),
+				"PowerState":     dbus.MakeVariant(uint32(PowerStateOn)),
+				"PrimaryPort":    dbus.MakeVariant("cdc-wdm0"),
+				"PrimarySimSlot": dbus.MakeVariant(uint32(1)),
+				"Revision":       dbus.MakeVariant("SWI9X30C_02.33.03.00"),
+				"Sim":            dbus.MakeVariant(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0")),
+				"SimSlots": dbus.MakeVariant([]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/SIM/0",
+					"/",
+				}),
+				"State":          dbus.MakeVariant(int32(StateConnected)),
+				"UnlockRequired": dbus.MakeVariant(uint32(LockNone)),
+				"UnlockRetries": dbus.MakeVariant(map[uint32]uint32{
+					uint32(LockSIMPIN): 3,
+					uint32(LockSIMPUK): 10,
+				}),
 			}, nil
 		},
 	}
@@ -169,6 +209,7 @@ func TestClientModemOK(t *testing.T) {
 	}
 
 	want := &Modem{
+		AccessTechnologies:  AccessTechnologyLTE,
 		Device:              "/sys/devices/pci0000:00/0000:00:13.0/usb1/1-1/1-1.3",
 		DeviceIdentifier:    "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
 		EquipmentIdentifier: "123456789012345",
@@ -194,12 +235,23 @@ func TestClientModemOK(t *testing.T) {
 				Type: PortTypeNet,
 			},
 		},
-		PowerState:  PowerStateOn,
-		PrimaryPort: "cdc-wdm0",
-		Revision:    "SWI9X30C_02.33.03.00",
-		State:       StateConnected,
+		PowerState:     PowerStateOn,
+		PrimaryPort:    "cdc-wdm0",
+		PrimarySIMSlot: 1,
+		Revision:       "SWI9X30C_02.33.03.00",
+		State:          StateConnected,
+		UnlockRequired: LockNone,
+		UnlockRetries: map[Lock]int{
+			LockSIMPIN: 3,
+			LockSIMPUK: 10,
+		},
 
 		bearers: []dbus.ObjectPath{"/org/freedesktop/ModemManager1/Bearer/0"},
+		sim:     "/org/freedesktop/ModemManager1/SIM/0",
+		simSlots: []dbus.ObjectPath{
+			"/org/freedesktop/ModemManager1/SIM/0",
+			"/",
+		},
 	}
 
 	// Ignore the internal Client but allow comparison of other fields such as
@@ -334,3 +386,8 @@ func TestIntegrationClient(t *testing.T) {
 		t.Fatalf("failed to iterate modems: %v", err)
 	}
 }
+
+// variantEqual is a cmp.Comparer for dbus.Variant values.
+func variantEqual(x, y dbus.Variant) bool {
+	return x.Signature() == y.Signature() && cmp.Equal(x.Value(), y.Value())
+}
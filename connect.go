@@ -0,0 +1,183 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// A ConnectConfig configures how ConnectLTE brings up a Modem's data
+// connection.
+type ConnectConfig struct {
+	// PIN unlocks the Modem's SIM if it requires a PIN.
+	PIN string
+
+	// Bearer configures the data connection, such as its APN.
+	Bearer BearerProperties
+
+	// RefuseRoaming, if set, refuses to connect while the Modem is registered
+	// with a roaming network, to avoid unexpected data charges. Unless
+	// Bearer.AllowRoaming is set explicitly, the Bearer is also configured
+	// to disallow roaming so that ModemManager will not connect it later
+	// while roaming.
+	RefuseRoaming bool
+}
+
+// ErrRoaming indicates that a Modem was not connected because it is registered
+// with a roaming network and roaming was refused.
+var ErrRoaming = errors.New("modem is roaming")
+
+// A ConnectStep is a step performed by ConnectLTE.
+type ConnectStep int
+
+// Possible ConnectStep values.
+const (
+	ConnectStepUnknown ConnectStep = iota
+	ConnectStepUnlock
+	ConnectStepEnable
+	ConnectStepRegister
+	ConnectStepConnect
+	ConnectStepWaitConnected
+)
+
+// A ConnectError is returned when ConnectLTE fails, and describes the step
+// which failed.
+type ConnectError struct {
+	// Step is the step which failed.
+	Step ConnectStep
+
+	// State is the Modem's last observed State, or StateUnknown if the State
+	// was not observed before the failure.
+	State State
+
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.
+func (e *ConnectError) Error() string {
+	return fmt.Sprintf("failed to connect modem at step %s (state %s): %v", e.Step, e.State, e.Err)
+}
+
+// Unwrap implements errors unwrapping, so that comparisons such as
+// 'errors.Is(err, ErrPUKRequired)' work as expected.
+func (e *ConnectError) Unwrap() error { return e.Err }
+
+// ConnectLTE runs the full sequence to bring up the Modem's data connection:
+// it unlocks the SIM if needed, enables the Modem, waits for the Modem to
+// register with a network, connects using the configured BearerProperties,
+// and waits for the Modem to report that it is connected. The context bounds
+// the entire sequence.
+//
+// If cfg.RefuseRoaming is set and the Modem is registered with a roaming
+// network, the Modem is not connected and the returned error wraps ErrRoaming.
+//
+// If any step fails, a *ConnectError describing that step is returned.
+func (m *Modem) ConnectLTE(ctx context.Context, cfg ConnectConfig) (*Bearer, error) {
+	if err := m.Unlock(ctx, cfg.PIN); err != nil {
+		return nil, &ConnectError{Step: ConnectStepUnlock, Err: err}
+	}
+
+	if err := m.Enable(ctx, true); err != nil {
+		return nil, &ConnectError{Step: ConnectStepEnable, Err: err}
+	}
+
+	state, err := m.waitState(ctx, func(s State) bool { return s >= StateRegistered })
+	if err != nil {
+		return nil, &ConnectError{Step: ConnectStepRegister, State: state, Err: err}
+	}
+
+	p := cfg.Bearer
+	if cfg.RefuseRoaming {
+		if err := m.checkRoaming(ctx); err != nil {
+			return nil, &ConnectError{Step: ConnectStepRegister, State: state, Err: err}
+		}
+
+		if p.AllowRoaming == nil {
+			allow := false
+			p.AllowRoaming = &allow
+		}
+	}
+
+	b, err := m.Connect(ctx, p)
+	if err != nil {
+		return nil, &ConnectError{Step: ConnectStepConnect, State: state, Err: err}
+	}
+
+	state, err = m.waitState(ctx, func(s State) bool { return s == StateConnected })
+	if err != nil {
+		return nil, &ConnectError{Step: ConnectStepWaitConnected, State: state, Err: err}
+	}
+
+	return b, nil
+}
+
+// checkRoaming returns ErrRoaming if the Modem is registered with a roaming
+// network.
+func (m *Modem) checkRoaming(ctx context.Context) error {
+	s, err := m.GetStatus(ctx)
+	if err != nil {
+		return err
+	}
+
+	if s.RegistrationState.IsRoaming() {
+		return ErrRoaming
+	}
+
+	return nil
+}
+
+// waitState waits until the Modem's State satisfies ok, and returns the last
+// observed State. If the Modem fails, an error is returned.
+func (m *Modem) waitState(ctx context.Context, ok func(s State) bool) (State, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// Begin watching before fetching the current State so no changes are
+	// missed.
+	changes, err := m.WatchState(ctx)
+	if err != nil {
+		return StateUnknown, err
+	}
+
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem"),
+		"State",
+	)
+	if err != nil {
+		return StateUnknown, err
+	}
+
+	vp := newValueParser(v)
+	state := State(vp.Int())
+	if err := vp.Err(); err != nil {
+		return StateUnknown, fmt.Errorf("failed to parse modem state: %v", err)
+	}
+
+	for {
+		if ok(state) {
+			return state, nil
+		}
+		if state == StateFailed {
+			return state, errors.New("modem failed")
+		}
+
+		select {
+		case c, open := <-changes:
+			if !open {
+				if err := ctx.Err(); err != nil {
+					return state, err
+				}
+
+				return state, errors.New("modem state watch ended")
+			}
+
+			state = c.New
+		case <-ctx.Done():
+			return state, ctx.Err()
+		}
+	}
+}
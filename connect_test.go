@@ -0,0 +1,274 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemConnectLTE(t *testing.T) {
+	var (
+		calls  []string
+		states = []State{StateEnabled, StateConnected}
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, args ...interface{}) error {
+				calls = append(calls, method)
+
+				switch method {
+				case "org.freedesktop.ModemManager1.Modem.Enable":
+					if diff := cmp.Diff([]interface{}{true}, args); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return nil
+				case "org.freedesktop.ModemManager1.Modem.Simple.Connect":
+					want := []interface{}{map[string]dbus.Variant{
+						"apn": dbus.MakeVariant("broadband"),
+					}}
+
+					if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Store(
+						[]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")},
+						out,
+					)
+				default:
+					t.Fatalf("unexpected method: %q", method)
+					return nil
+				}
+			},
+			get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+				switch prop {
+				case "UnlockRequired":
+					return dbus.MakeVariant(uint32(LockNone)), nil
+				case "State":
+					// Report the next State each time it is waited on.
+					s := states[0]
+					states = states[1:]
+					return dbus.MakeVariant(int32(s)), nil
+				default:
+					t.Fatalf("unexpected property: %q", prop)
+					return dbus.Variant{}, nil
+				}
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(true),
+				}, nil
+			},
+			watch: func(_ context.Context, _ dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff("StateChanged", member); diff != "" {
+					t.Fatalf("unexpected signal (-want +got):\n%s", diff)
+				}
+
+				// The Modem searches and then registers with a network.
+				sigs := make(chan *dbus.Signal, 2)
+				sigs <- &dbus.Signal{Body: []interface{}{
+					int32(StateEnabled), int32(StateSearching), uint32(StateChangeReasonUnknown),
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					int32(StateSearching), int32(StateRegistered), uint32(StateChangeReasonUnknown),
+				}}
+				close(sigs)
+
+				return sigs, nil
+			},
+		},
+	}
+
+	b, err := m.ConnectLTE(context.Background(), ConnectConfig{
+		Bearer: BearerProperties{APN: "broadband"},
+	})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+
+	if diff := cmp.Diff(&Bearer{Connected: true}, b, cmpopts.IgnoreUnexported(Bearer{})); diff != "" {
+		t.Fatalf("unexpected Bearer (-want +got):\n%s", diff)
+	}
+
+	wantCalls := []string{
+		"org.freedesktop.ModemManager1.Modem.Enable",
+		"org.freedesktop.ModemManager1.Modem.Simple.Connect",
+	}
+
+	if diff := cmp.Diff(wantCalls, calls); diff != "" {
+		t.Fatalf("unexpected method calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemConnectLTEErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		lock       Lock
+		enable     error
+		state      State
+		step       ConnectStep
+		permission bool
+	}{
+		{
+			name: "PUK required",
+			lock: LockSIMPUK,
+			step: ConnectStepUnlock,
+		},
+		{
+			name:       "enable permission denied",
+			lock:       LockNone,
+			enable:     dbus.Error{Name: unauthorizedError},
+			step:       ConnectStepEnable,
+			permission: true,
+		},
+		{
+			name:  "modem failed",
+			lock:  LockNone,
+			state: StateFailed,
+			step:  ConnectStepRegister,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{
+					call: func(_ context.Context, _ string, _ dbus.ObjectPath, _ interface{}, _ ...interface{}) error {
+						return tt.enable
+					},
+					get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+						if prop == "UnlockRequired" {
+							return dbus.MakeVariant(uint32(tt.lock)), nil
+						}
+
+						return dbus.MakeVariant(int32(tt.state)), nil
+					},
+					watch: func(_ context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+						sigs := make(chan *dbus.Signal)
+						close(sigs)
+						return sigs, nil
+					},
+				},
+			}
+
+			_, err := m.ConnectLTE(context.Background(), ConnectConfig{})
+
+			var cerr *ConnectError
+			if !errors.As(err, &cerr) {
+				t.Fatalf("expected *ConnectError, but got: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.step, cerr.Step); diff != "" {
+				t.Fatalf("unexpected connect step (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.permission, errors.Is(err, os.ErrPermission)); diff != "" {
+				t.Fatalf("unexpected permission error (-want +got):\n%s", diff)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
+
+func TestModemConnectLTERoaming(t *testing.T) {
+	f := false
+
+	tests := []struct {
+		name      string
+		refuse    bool
+		state     RegistrationState3GPP
+		props     map[string]dbus.Variant
+		roaming   bool
+		connected bool
+	}{
+		{
+			name:      "allowed",
+			state:     RegistrationState3GPPRoaming,
+			props:     map[string]dbus.Variant{},
+			connected: true,
+		},
+		{
+			name:   "home",
+			refuse: true,
+			state:  RegistrationState3GPPHome,
+			props: map[string]dbus.Variant{
+				"allow-roaming": dbus.MakeVariant(f),
+			},
+			connected: true,
+		},
+		{
+			name:    "refused",
+			refuse:  true,
+			state:   RegistrationState3GPPRoaming,
+			roaming: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var connected bool
+
+			m := &Modem{
+				c: &Client{
+					call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, args ...interface{}) error {
+						switch method {
+						case "org.freedesktop.ModemManager1.Modem.Simple.GetStatus":
+							return dbus.Store([]interface{}{map[string]dbus.Variant{
+								"m3gpp-registration-state": dbus.MakeVariant(uint32(tt.state)),
+							}}, out)
+						case "org.freedesktop.ModemManager1.Modem.Simple.Connect":
+							connected = true
+
+							want := []interface{}{tt.props}
+							if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+								t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+							}
+
+							return dbus.Store(
+								[]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")},
+								out,
+							)
+						}
+
+						return nil
+					},
+					get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+						if prop == "UnlockRequired" {
+							return dbus.MakeVariant(uint32(LockNone)), nil
+						}
+
+						return dbus.MakeVariant(int32(StateConnected)), nil
+					},
+					getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+						return map[string]dbus.Variant{
+							"Connected": dbus.MakeVariant(true),
+						}, nil
+					},
+					watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+						return testSignals(ctx), nil
+					},
+				},
+			}
+
+			_, err := m.ConnectLTE(context.Background(), ConnectConfig{RefuseRoaming: tt.refuse})
+			if diff := cmp.Diff(tt.roaming, errors.Is(err, ErrRoaming)); diff != "" {
+				t.Fatalf("unexpected roaming error (-want +got):\n%s", diff)
+			}
+			if !tt.roaming && err != nil {
+				t.Fatalf("failed to connect: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.connected, connected); diff != "" {
+				t.Fatalf("unexpected connection (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSIMChanged indicates that a Modem's connection was torn down because its
+// SIM was changed.
+var ErrSIMChanged = errors.New("modem SIM changed")
+
+// A ConnectionManager keeps a Modem connected according to a ConnectConfig.
+// It connects the Modem, watches for the connection to drop or the SIM to
+// change, and reconnects with exponential backoff, reporting its progress as
+// ConnectionEvents.
+type ConnectionManager struct {
+	// MinBackoff and MaxBackoff bound the delay before reconnecting after a
+	// failed connection attempt or a dropped connection. The delay doubles
+	// after each consecutive failure, and is reset once a connection stays up
+	// for at least MaxBackoff.
+	MinBackoff, MaxBackoff time.Duration
+
+	// CheckInterval is the interval at which a connected Bearer is checked
+	// to confirm that it still exists, since no change is signaled when a
+	// Modem is unplugged or reset.
+	CheckInterval time.Duration
+
+	m   *Modem
+	cfg ConnectConfig
+}
+
+// A ConnectionStatus is the status of a Modem's connection as managed by a
+// ConnectionManager.
+type ConnectionStatus int
+
+// Possible ConnectionStatus values.
+const (
+	ConnectionStatusUnknown ConnectionStatus = iota
+	ConnectionStatusConnecting
+	ConnectionStatusConnected
+	ConnectionStatusDisconnected
+	ConnectionStatusFailed
+)
+
+// A ConnectionEvent is an event which occurs when the status of a Modem's
+// connection changes.
+type ConnectionEvent struct {
+	Status ConnectionStatus
+
+	// Bearer is the connected Bearer when Status is ConnectionStatusConnected.
+	Bearer *Bearer
+
+	// Err is the reason the connection was lost or the connection attempt
+	// failed, if known.
+	Err error
+
+	// RetryIn is the delay before the next connection attempt when Status
+	// is ConnectionStatusFailed or ConnectionStatusDisconnected.
+	RetryIn time.Duration
+}
+
+// NewConnectionManager creates a ConnectionManager for the Modem with default
+// settings, which may be modified before calling Run.
+func NewConnectionManager(m *Modem, cfg ConnectConfig) *ConnectionManager {
+	return &ConnectionManager{
+		MinBackoff:    time.Second,
+		MaxBackoff:    2 * time.Minute,
+		CheckInterval: 30 * time.Second,
+
+		m:   m,
+		cfg: cfg,
+	}
+}
+
+// Run begins managing the Modem's connection, delivering each change in its
+// status on the returned channel. Management stops and the channel is closed
+// when ctx is canceled. The caller must receive from the channel until it is
+// closed, or management will stall.
+//
+// When the Modem's SIM changes, the Modem is reregistered with the network and
+// reconnected. A connection is also considered lost when the Modem is no longer
+// registered, or when its Bearer no longer exists. Connection attempts which
+// fail, including those for a Modem which no longer exists, are retried until
+// ctx is canceled.
+func (cm *ConnectionManager) Run(ctx context.Context) <-chan ConnectionEvent {
+	events := make(chan ConnectionEvent)
+	go func() {
+		defer close(events)
+		cm.run(ctx, events)
+	}()
+
+	return events
+}
+
+// run implements Run.
+func (cm *ConnectionManager) run(ctx context.Context, events chan<- ConnectionEvent) {
+	emit := func(e ConnectionEvent) bool {
+		select {
+		case events <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	// retry waits before the next connection attempt and increases the delay
+	// for the following one.
+	backoff := cm.MinBackoff
+	retry := func() bool {
+		if !sleep(ctx, backoff) {
+			return false
+		}
+
+		backoff *= 2
+		if backoff > cm.MaxBackoff {
+			backoff = cm.MaxBackoff
+		}
+
+		return true
+	}
+
+	for {
+		if !emit(ConnectionEvent{Status: ConnectionStatusConnecting}) {
+			return
+		}
+
+		m, b, err := cm.connect(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if !emit(ConnectionEvent{
+				Status:  ConnectionStatusFailed,
+				Err:     err,
+				RetryIn: backoff,
+			}) {
+				return
+			}
+
+			if !retry() {
+				return
+			}
+
+			continue
+		}
+
+		if !emit(ConnectionEvent{Status: ConnectionStatusConnected, Bearer: b}) {
+			return
+		}
+
+		start := time.Now()
+		err = cm.wait(ctx, m, b)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if errors.Is(err, ErrSIMChanged) {
+			// Tear down the connection to the previous SIM's network and
+			// reregister. Both are retried by the next connection attempt if
+			// they fail here, so their errors are not reported.
+			_ = cm.m.Disconnect(ctx, b)
+			_ = cm.m.Register(ctx, "")
+		}
+
+		// A connection which stayed up resets the backoff, while one which
+		// drops quickly is retried with increasing delays.
+		if time.Since(start) >= cm.MaxBackoff {
+			backoff = cm.MinBackoff
+		}
+
+		if !emit(ConnectionEvent{
+			Status:  ConnectionStatusDisconnected,
+			Err:     err,
+			RetryIn: backoff,
+		}) {
+			return
+		}
+
+		if !retry() {
+			return
+		}
+	}
+}
+
+// connect connects the Modem and returns the connected Bearer, along with a
+// snapshot of the Modem taken beforehand which notes the SIM and primary SIM
+// slot the Bearer is connected with.
+func (cm *ConnectionManager) connect(ctx context.Context) (*Modem, *Bearer, error) {
+	m, err := cm.m.c.Modem(ctx, cm.m.Index)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := cm.m.ConnectLTE(ctx, cm.cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return m, b, nil
+}
+
+// wait waits until the Bearer disconnects or disappears, the Modem is no
+// longer registered, or the Modem's SIM or primary SIM slot differ from those
+// in the snapshot m, and returns the reason.
+func (cm *ConnectionManager) wait(ctx context.Context, m *Modem, b *Bearer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	changes, err := b.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	// WatchSIM compares each update with the SIM and primary SIM slot in the
+	// snapshot, so any change it reports differs from those the Bearer was
+	// connected with.
+	sims, err := m.WatchSIM(ctx)
+	if err != nil {
+		return err
+	}
+
+	states, err := m.WatchState(ctx)
+	if err != nil {
+		return err
+	}
+
+	// A nil channel disables the periodic check.
+	var check <-chan time.Time
+	if cm.CheckInterval > 0 {
+		t := time.NewTicker(cm.CheckInterval)
+		defer t.Stop()
+		check = t.C
+	}
+
+	for {
+		select {
+		case c, ok := <-changes:
+			if !ok {
+				return ctx.Err()
+			}
+			if !c.Connected {
+				return errors.New("bearer disconnected")
+			}
+		case _, ok := <-sims:
+			if !ok {
+				return ctx.Err()
+			}
+
+			return ErrSIMChanged
+		case s, ok := <-states:
+			if !ok {
+				return ctx.Err()
+			}
+			if s.New < StateRegistered {
+				return fmt.Errorf("modem is no longer registered: %v", s.New)
+			}
+		case <-check:
+			if err := cm.checkBearer(ctx, b); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// checkBearer verifies that the Bearer still exists and is connected.
+func (cm *ConnectionManager) checkBearer(ctx context.Context, b *Bearer) error {
+	v, err := b.Property(ctx, interfacePath("Bearer"), "Connected")
+	if err != nil {
+		return fmt.Errorf("failed to check bearer: %w", err)
+	}
+
+	vp := newValueParser(v)
+	connected := vp.Bool()
+	if err := vp.Err(); err != nil {
+		return fmt.Errorf("error parsing bearer connected state: %v", err)
+	}
+	if !connected {
+		return errors.New("bearer disconnected")
+	}
+
+	return nil
+}
+
+// sleep sleeps for d or until ctx is canceled, and reports whether the full
+// duration elapsed.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
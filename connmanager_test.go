@@ -0,0 +1,357 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConnectionManagerRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var (
+		calls                  []string
+		enables, bearers, sims int
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				calls = append(calls, method)
+
+				switch method {
+				case "org.freedesktop.ModemManager1.Modem.Enable":
+					// The first connection attempt fails.
+					enables++
+					if enables == 1 {
+						return errors.New("enable failed")
+					}
+				case "org.freedesktop.ModemManager1.Modem.Simple.Connect":
+					return dbus.Store(
+						[]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")},
+						out,
+					)
+				}
+
+				return nil
+			},
+			get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+				if prop == "UnlockRequired" {
+					return dbus.MakeVariant(uint32(LockNone)), nil
+				}
+
+				return dbus.MakeVariant(int32(StateConnected)), nil
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(true),
+				}, nil
+			},
+			watch: func(ctx context.Context, op dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+				if member == "StateChanged" {
+					return testSignals(ctx), nil
+				}
+
+				switch op {
+				case "/org/freedesktop/ModemManager1/Bearer/0":
+					// The first connection drops.
+					bearers++
+					if bearers == 1 {
+						return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+							"org.freedesktop.ModemManager1.Bearer",
+							map[string]dbus.Variant{"Connected": dbus.MakeVariant(false)},
+							[]string{},
+						}}), nil
+					}
+				case "/org/freedesktop/ModemManager1/Modem/0":
+					// The SIM is removed during the second connection.
+					sims++
+					if sims == 2 {
+						return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+							"org.freedesktop.ModemManager1.Modem",
+							map[string]dbus.Variant{"Sim": dbus.MakeVariant(dbus.ObjectPath("/"))},
+							[]string{},
+						}}), nil
+					}
+				}
+
+				return testSignals(ctx), nil
+			},
+		},
+	}
+
+	cm := NewConnectionManager(m, ConnectConfig{})
+	cm.MinBackoff = time.Millisecond
+	cm.MaxBackoff = time.Hour
+
+	events := cm.Run(ctx)
+
+	var got []ConnectionEvent
+	for e := range events {
+		got = append(got, e)
+		if len(got) == 8 {
+			cancel()
+		}
+	}
+
+	// Only the presence of errors and Bearers is compared.
+	var (
+		errAny    = errors.New("any error")
+		bearerAny = &Bearer{}
+	)
+
+	want := []ConnectionEvent{
+		{Status: ConnectionStatusConnecting},
+		{Status: ConnectionStatusFailed, Err: errAny, RetryIn: time.Millisecond},
+		{Status: ConnectionStatusConnecting},
+		{Status: ConnectionStatusConnected, Bearer: bearerAny},
+		{Status: ConnectionStatusDisconnected, Err: errAny, RetryIn: 2 * time.Millisecond},
+		{Status: ConnectionStatusConnecting},
+		{Status: ConnectionStatusConnected, Bearer: bearerAny},
+		{Status: ConnectionStatusDisconnected, Err: errAny, RetryIn: 4 * time.Millisecond},
+	}
+
+	opts := []cmp.Option{
+		cmp.Comparer(func(x, y error) bool { return (x == nil) == (y == nil) }),
+		cmp.Comparer(func(x, y *Bearer) bool { return (x == nil) == (y == nil) }),
+	}
+
+	if len(got) < len(want) {
+		t.Fatalf("expected at least %d events, but got %d", len(want), len(got))
+	}
+	got = got[:len(want)]
+
+	if diff := cmp.Diff(want, got, opts...); diff != "" {
+		t.Fatalf("unexpected ConnectionEvents (-want +got):\n%s", diff)
+	}
+
+	if !errors.Is(got[7].Err, ErrSIMChanged) {
+		t.Fatalf("expected SIM changed error, but got: %v", got[7].Err)
+	}
+
+	// The SIM change tears down the connection and reregisters the Modem.
+	var teardown []string
+	for _, c := range calls {
+		switch c {
+		case "org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+			"org.freedesktop.ModemManager1.Modem.Modem3gpp.Register":
+			teardown = append(teardown, c)
+		}
+	}
+
+	wantTeardown := []string{
+		"org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+		"org.freedesktop.ModemManager1.Modem.Modem3gpp.Register",
+	}
+
+	if diff := cmp.Diff(wantTeardown, teardown); diff != "" {
+		t.Fatalf("unexpected teardown calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestConnectionManagerRunLost(t *testing.T) {
+	tests := []struct {
+		name             string
+		check            time.Duration
+		gone, deregister bool
+	}{
+		{
+			name:  "bearer gone",
+			check: time.Millisecond,
+			gone:  true,
+		},
+		{
+			name:       "deregistered",
+			deregister: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// The Modem is unplugged or loses its registration after the
+			// Bearer is connected, without any change to the Bearer itself.
+			var connected bool
+			m := &Modem{
+				c: &Client{
+					call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+						if method == "org.freedesktop.ModemManager1.Modem.Simple.Connect" {
+							connected = true
+							return dbus.Store(
+								[]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")},
+								out,
+							)
+						}
+
+						return nil
+					},
+					get: func(_ context.Context, op dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+						switch {
+						case prop == "UnlockRequired":
+							return dbus.MakeVariant(uint32(LockNone)), nil
+						case op == "/org/freedesktop/ModemManager1/Bearer/0" && prop == "Connected":
+							if tt.gone {
+								return dbus.Variant{}, dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}
+							}
+
+							return dbus.MakeVariant(true), nil
+						}
+
+						return dbus.MakeVariant(int32(StateConnected)), nil
+					},
+					getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+						return map[string]dbus.Variant{
+							"Connected": dbus.MakeVariant(true),
+						}, nil
+					},
+					watch: func(ctx context.Context, _ dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+						if member == "StateChanged" && connected && tt.deregister {
+							return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+								int32(StateConnected),
+								int32(StateSearching),
+								uint32(StateChangeReasonUnknown),
+							}}), nil
+						}
+
+						return testSignals(ctx), nil
+					},
+				},
+			}
+
+			cm := NewConnectionManager(m, ConnectConfig{})
+			cm.MinBackoff = time.Millisecond
+			cm.MaxBackoff = time.Hour
+			cm.CheckInterval = tt.check
+
+			var got []ConnectionStatus
+			for e := range cm.Run(ctx) {
+				got = append(got, e.Status)
+				if e.Status == ConnectionStatusDisconnected {
+					if e.Err == nil {
+						t.Fatal("expected a disconnect error, but none occurred")
+					}
+
+					cancel()
+				}
+			}
+
+			want := []ConnectionStatus{
+				ConnectionStatusConnecting,
+				ConnectionStatusConnected,
+				ConnectionStatusDisconnected,
+			}
+
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("unexpected statuses (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConnectionManagerRunSIMUnchanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const sim = dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/1")
+
+	// The Modem value is stale, but the Modem was switched to its second SIM
+	// slot before the ConnectionManager connected.
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				if method == "org.freedesktop.ModemManager1.Modem.Simple.Connect" {
+					return dbus.Store(
+						[]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")},
+						out,
+					)
+				}
+
+				return nil
+			},
+			get: func(_ context.Context, op dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+				switch {
+				case prop == "UnlockRequired":
+					return dbus.MakeVariant(uint32(LockNone)), nil
+				case op == "/org/freedesktop/ModemManager1/Bearer/0" && prop == "Connected":
+					// The Bearer eventually disappears, ending the test.
+					return dbus.Variant{}, dbus.Error{Name: "org.freedesktop.DBus.Error.UnknownObject"}
+				}
+
+				return dbus.MakeVariant(int32(StateConnected)), nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				if op == "/org/freedesktop/ModemManager1/Modem/0" {
+					return map[string]dbus.Variant{
+						"PrimarySimSlot": dbus.MakeVariant(uint32(2)),
+						"Sim":            dbus.MakeVariant(sim),
+					}, nil
+				}
+
+				return map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(true),
+				}, nil
+			},
+			watch: func(ctx context.Context, op dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+				if op == "/org/freedesktop/ModemManager1/Modem/0" && member == "PropertiesChanged" {
+					// The SIM the Bearer was connected with is reported
+					// again, which is not a change.
+					return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+						"org.freedesktop.ModemManager1.Modem",
+						map[string]dbus.Variant{
+							"PrimarySimSlot": dbus.MakeVariant(uint32(2)),
+							"Sim":            dbus.MakeVariant(sim),
+						},
+						[]string{},
+					}}), nil
+				}
+
+				return testSignals(ctx), nil
+			},
+		},
+		PrimarySIMSlot: 1,
+		sim:            "/org/freedesktop/ModemManager1/SIM/0",
+	}
+
+	cm := NewConnectionManager(m, ConnectConfig{})
+	cm.MinBackoff = time.Millisecond
+	cm.MaxBackoff = time.Hour
+	cm.CheckInterval = 50 * time.Millisecond
+
+	for e := range cm.Run(ctx) {
+		if e.Status != ConnectionStatusDisconnected {
+			continue
+		}
+
+		if errors.Is(e.Err, ErrSIMChanged) {
+			t.Fatalf("unexpected SIM change: %v", e.Err)
+		}
+		if e.Err == nil {
+			t.Fatal("expected a disconnect error, but none occurred")
+		}
+
+		cancel()
+	}
+}
+
+// testSignals returns a channel which delivers sigs and is closed when ctx is
+// canceled.
+func testSignals(ctx context.Context, sigs ...*dbus.Signal) <-chan *dbus.Signal {
+	ch := make(chan *dbus.Signal, len(sigs))
+	for _, s := range sigs {
+		ch <- s
+	}
+
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+
+	return ch
+}
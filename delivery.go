@@ -0,0 +1,256 @@
+package modemmanager
+
+import (
+	"context"
+	"strconv"
+	"sync"
+)
+
+// An SMSDeliveryState is the delivery state of a sent SMS, as reported by an
+// SMS status report.
+type SMSDeliveryState int
+
+// Possible SMSDeliveryState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsDeliveryState.
+const (
+	SMSDeliveryStateCompletedReceived                    SMSDeliveryState = 0x00
+	SMSDeliveryStateCompletedForwardedUnconfirmed        SMSDeliveryState = 0x01
+	SMSDeliveryStateCompletedReplacedBySC                SMSDeliveryState = 0x02
+	SMSDeliveryStateTemporaryErrorCongestion             SMSDeliveryState = 0x20
+	SMSDeliveryStateTemporaryErrorSMEBusy                SMSDeliveryState = 0x21
+	SMSDeliveryStateTemporaryErrorNoResponseFromSME      SMSDeliveryState = 0x22
+	SMSDeliveryStateTemporaryErrorServiceRejected        SMSDeliveryState = 0x23
+	SMSDeliveryStateTemporaryErrorQoSNotAvailable        SMSDeliveryState = 0x24
+	SMSDeliveryStateTemporaryErrorInSME                  SMSDeliveryState = 0x25
+	SMSDeliveryStateErrorRemoteProcedure                 SMSDeliveryState = 0x40
+	SMSDeliveryStateErrorIncompatibleDestination         SMSDeliveryState = 0x41
+	SMSDeliveryStateErrorConnectionRejectedBySME         SMSDeliveryState = 0x42
+	SMSDeliveryStateErrorNotObtainable                   SMSDeliveryState = 0x43
+	SMSDeliveryStateErrorQoSNotAvailable                 SMSDeliveryState = 0x44
+	SMSDeliveryStateErrorNoInterworkingAvailable         SMSDeliveryState = 0x45
+	SMSDeliveryStateErrorValidityPeriodExpired           SMSDeliveryState = 0x46
+	SMSDeliveryStateErrorDeletedByOriginatingSME         SMSDeliveryState = 0x47
+	SMSDeliveryStateErrorDeletedBySCAdministration       SMSDeliveryState = 0x48
+	SMSDeliveryStateErrorMessageDoesNotExist             SMSDeliveryState = 0x49
+	SMSDeliveryStateTemporaryFatalErrorCongestion        SMSDeliveryState = 0x60
+	SMSDeliveryStateTemporaryFatalErrorSMEBusy           SMSDeliveryState = 0x61
+	SMSDeliveryStateTemporaryFatalErrorNoResponseFromSME SMSDeliveryState = 0x62
+	SMSDeliveryStateTemporaryFatalErrorServiceRejected   SMSDeliveryState = 0x63
+	SMSDeliveryStateTemporaryFatalErrorQoSNotAvailable   SMSDeliveryState = 0x64
+	SMSDeliveryStateTemporaryFatalErrorInSME             SMSDeliveryState = 0x65
+	SMSDeliveryStateUnknown                              SMSDeliveryState = 0x100
+)
+
+// A DeliveryStatus summarizes the delivery of a sent SMS.
+type DeliveryStatus int
+
+// Possible DeliveryStatus values.
+const (
+	DeliveryStatusPending DeliveryStatus = iota
+	DeliveryStatusDelivered
+	DeliveryStatusFailed
+)
+
+// Status summarizes an SMSDeliveryState as a DeliveryStatus, following the
+// status ranges described in 3GPP TS 23.040 section 9.2.3.15. Temporary errors
+// for which the service center is still trying to deliver the SMS are
+// reported as pending.
+func (s SMSDeliveryState) Status() DeliveryStatus {
+	switch {
+	case s >= 0x00 && s <= 0x1f:
+		return DeliveryStatusDelivered
+	case s >= 0x40 && s <= 0x7f:
+		return DeliveryStatusFailed
+	default:
+		return DeliveryStatusPending
+	}
+}
+
+// A DeliveryReport is the delivery status of a sent SMS, as correlated by a
+// DeliveryTracker.
+type DeliveryReport struct {
+	SMS    *SMS
+	State  SMSDeliveryState
+	Status DeliveryStatus
+}
+
+// A DeliveryTracker correlates SMS status reports with the sent SMS messages
+// which requested them. DeliveryTracker is safe for concurrent use.
+//
+// Status reports identify a sent SMS only by its MessageReference, which is
+// assigned by the modem and wraps after 256 messages, so a DeliveryTracker
+// should only be used with messages sent by a single Modem.
+type DeliveryTracker struct {
+	mu   sync.Mutex
+	sent map[int]*trackedSMS
+}
+
+// A trackedSMS is the state of an SMS tracked by a DeliveryTracker.
+type trackedSMS struct {
+	r DeliveryReport
+
+	// done is closed when the SMS is no longer tracked.
+	done chan struct{}
+}
+
+// NewDeliveryTracker creates a DeliveryTracker.
+func NewDeliveryTracker() *DeliveryTracker {
+	return &DeliveryTracker{sent: make(map[int]*trackedSMS)}
+}
+
+// Track begins tracking the delivery of a sent SMS, using its
+// MessageReference. The SMS should be sent with DeliveryReportRequest set so
+// the network sends a status report. Tracking a new SMS with the same
+// MessageReference replaces the previously tracked SMS.
+func (t *DeliveryTracker) Track(s *SMS) {
+	t.track(s)
+}
+
+// Watch tracks a sent SMS as Track does, and also watches the SMS's
+// DeliveryState property, which ModemManager updates when it correlates a
+// status report with the SMS itself. Each updated DeliveryReport is delivered
+// on the returned channel, which is closed once the SMS is delivered, has
+// failed, or is no longer tracked, or when the context is canceled.
+//
+// Status reports passed to Report also update the SMS, as a fallback for
+// modems which only expose status reports as separate SMS messages.
+func (t *DeliveryTracker) Watch(ctx context.Context, s *SMS) (_ <-chan DeliveryReport, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	// Watch for changes before tracking the SMS so that no delivery state
+	// change is missed.
+	changes, err := s.c.watchProperties(
+		ctx,
+		objectPath("SMS", strconv.Itoa(s.Index)),
+		interfacePath("Sms"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	ts := t.track(s)
+
+	out := make(chan DeliveryReport)
+	go func() {
+		defer cancel()
+		defer close(out)
+
+		for {
+			var state SMSDeliveryState
+			select {
+			case ps, ok := <-changes:
+				if !ok {
+					return
+				}
+
+				v, ok := ps["DeliveryState"]
+				if !ok {
+					continue
+				}
+
+				vp := newValueParser(v)
+				state = SMSDeliveryState(vp.Int())
+				if vp.Err() != nil {
+					continue
+				}
+			case <-ts.done:
+				return
+			case <-ctx.Done():
+				return
+			}
+
+			r, ok := t.update(s.MessageReference, ts, state)
+			if !ok {
+				return
+			}
+
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+
+			if r.Status != DeliveryStatusPending {
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// track begins tracking s and returns its state.
+func (t *DeliveryTracker) track(s *SMS) *trackedSMS {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if ts, ok := t.sent[s.MessageReference]; ok {
+		close(ts.done)
+	}
+
+	ts := &trackedSMS{
+		r: DeliveryReport{
+			SMS:    s,
+			State:  SMSDeliveryStateUnknown,
+			Status: DeliveryStatusPending,
+		},
+		done: make(chan struct{}),
+	}
+
+	t.sent[s.MessageReference] = ts
+	return ts
+}
+
+// update updates the tracked SMS with the input MessageReference and returns
+// its DeliveryReport. If want is not nil, the SMS is only updated if it has
+// not been replaced since want was tracked. Once an SMS is delivered or has
+// failed, it is no longer tracked.
+func (t *DeliveryTracker) update(reference int, want *trackedSMS, state SMSDeliveryState) (DeliveryReport, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts, ok := t.sent[reference]
+	if !ok || (want != nil && want != ts) {
+		return DeliveryReport{}, false
+	}
+
+	ts.r.State = state
+	ts.r.Status = state.Status()
+	if ts.r.Status != DeliveryStatusPending {
+		delete(t.sent, reference)
+		close(ts.done)
+	}
+
+	return ts.r, true
+}
+
+// Report correlates a received SMS status report with a tracked SMS, and
+// returns the tracked SMS's updated DeliveryReport. If s is not a status
+// report or does not match a tracked SMS, ok is false. Once an SMS is
+// delivered or has failed, it is no longer tracked.
+func (t *DeliveryTracker) Report(s *SMS) (r DeliveryReport, ok bool) {
+	if s.PDUType != SMSPDUTypeStatusReport {
+		return DeliveryReport{}, false
+	}
+
+	return t.update(s.MessageReference, nil, s.DeliveryState)
+}
+
+// Status returns the current DeliveryReport for the tracked SMS with the
+// input MessageReference. If no such SMS is tracked, ok is false.
+func (t *DeliveryTracker) Status(reference int) (r DeliveryReport, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts, ok := t.sent[reference]
+	if !ok {
+		return DeliveryReport{}, false
+	}
+
+	return ts.r, true
+}
@@ -0,0 +1,212 @@
+package modemmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestSMSDeliveryStateStatus(t *testing.T) {
+	tests := []struct {
+		s    SMSDeliveryState
+		want DeliveryStatus
+	}{
+		{s: SMSDeliveryStateCompletedReceived, want: DeliveryStatusDelivered},
+		{s: SMSDeliveryStateCompletedReplacedBySC, want: DeliveryStatusDelivered},
+		{s: SMSDeliveryStateTemporaryErrorSMEBusy, want: DeliveryStatusPending},
+		{s: SMSDeliveryStateErrorValidityPeriodExpired, want: DeliveryStatusFailed},
+		{s: SMSDeliveryStateTemporaryFatalErrorInSME, want: DeliveryStatusFailed},
+		{s: SMSDeliveryStateUnknown, want: DeliveryStatusPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s.String(), func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.s.Status()); diff != "" {
+				t.Fatalf("unexpected DeliveryStatus (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDeliveryTracker(t *testing.T) {
+	var (
+		dt = NewDeliveryTracker()
+
+		a = &SMS{Index: 0, MessageReference: 1, Number: "+15555551234"}
+		b = &SMS{Index: 1, MessageReference: 2, Number: "+15555555678"}
+	)
+
+	dt.Track(a)
+	dt.Track(b)
+
+	// A received message is not a status report, and a status report for an
+	// untracked message is ignored.
+	if _, ok := dt.Report(&SMS{PDUType: SMSPDUTypeDeliver, MessageReference: 1}); ok {
+		t.Fatal("unexpected report for non-status report SMS")
+	}
+	if _, ok := dt.Report(&SMS{PDUType: SMSPDUTypeStatusReport, MessageReference: 3}); ok {
+		t.Fatal("unexpected report for untracked SMS")
+	}
+
+	// The first message is temporarily delayed and then delivered, and the
+	// second fails.
+	reports := []*SMS{
+		{
+			PDUType:          SMSPDUTypeStatusReport,
+			MessageReference: 1,
+			DeliveryState:    SMSDeliveryStateTemporaryErrorSMEBusy,
+		},
+		{
+			PDUType:          SMSPDUTypeStatusReport,
+			MessageReference: 2,
+			DeliveryState:    SMSDeliveryStateErrorNotObtainable,
+		},
+		{
+			PDUType:          SMSPDUTypeStatusReport,
+			MessageReference: 1,
+			DeliveryState:    SMSDeliveryStateCompletedReceived,
+		},
+	}
+
+	var got []DeliveryReport
+	for _, r := range reports {
+		dr, ok := dt.Report(r)
+		if !ok {
+			t.Fatalf("no report for message reference %d", r.MessageReference)
+		}
+
+		got = append(got, dr)
+	}
+
+	want := []DeliveryReport{
+		{
+			SMS:    a,
+			State:  SMSDeliveryStateTemporaryErrorSMEBusy,
+			Status: DeliveryStatusPending,
+		},
+		{
+			SMS:    b,
+			State:  SMSDeliveryStateErrorNotObtainable,
+			Status: DeliveryStatusFailed,
+		},
+		{
+			SMS:    a,
+			State:  SMSDeliveryStateCompletedReceived,
+			Status: DeliveryStatusDelivered,
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmp.AllowUnexported(SMS{})); diff != "" {
+		t.Fatalf("unexpected DeliveryReports (-want +got):\n%s", diff)
+	}
+
+	// Both messages are complete and no longer tracked.
+	for _, ref := range []int{1, 2} {
+		if _, ok := dt.Status(ref); ok {
+			t.Fatalf("message reference %d is still tracked", ref)
+		}
+	}
+}
+
+func TestDeliveryTrackerWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const iface = "org.freedesktop.ModemManager1.Sms"
+	c := &Client{
+		watch: func(ctx context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+			switch op {
+			case "/org/freedesktop/ModemManager1/SMS/0":
+				// An unrelated property changes, and then ModemManager
+				// correlates two status reports with the SMS.
+				return testSignals(ctx,
+					&dbus.Signal{Body: []interface{}{
+						iface,
+						map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(SMSStateSent))},
+						[]string{},
+					}},
+					&dbus.Signal{Body: []interface{}{
+						iface,
+						map[string]dbus.Variant{"DeliveryState": dbus.MakeVariant(uint32(SMSDeliveryStateTemporaryErrorSMEBusy))},
+						[]string{},
+					}},
+					&dbus.Signal{Body: []interface{}{
+						iface,
+						map[string]dbus.Variant{"DeliveryState": dbus.MakeVariant(uint32(SMSDeliveryStateCompletedReceived))},
+						[]string{},
+					}},
+				), nil
+			case "/org/freedesktop/ModemManager1/SMS/1":
+				// No property changes; a status report arrives instead.
+				return testSignals(ctx), nil
+			default:
+				t.Fatalf("unexpected object path: %q", op)
+				return nil, nil
+			}
+		},
+	}
+
+	var (
+		dt = NewDeliveryTracker()
+
+		a = &SMS{Index: 0, MessageReference: 1, c: c}
+		b = &SMS{Index: 1, MessageReference: 2, c: c}
+	)
+
+	aReports, err := dt.Watch(ctx, a)
+	if err != nil {
+		t.Fatalf("failed to watch SMS a: %v", err)
+	}
+
+	bReports, err := dt.Watch(ctx, b)
+	if err != nil {
+		t.Fatalf("failed to watch SMS b: %v", err)
+	}
+
+	// The channel is closed once the SMS is delivered, even though the
+	// properties watch remains open.
+	var got []DeliveryReport
+	for r := range aReports {
+		got = append(got, r)
+	}
+
+	want := []DeliveryReport{
+		{
+			SMS:    a,
+			State:  SMSDeliveryStateTemporaryErrorSMEBusy,
+			Status: DeliveryStatusPending,
+		},
+		{
+			SMS:    a,
+			State:  SMSDeliveryStateCompletedReceived,
+			Status: DeliveryStatusDelivered,
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(SMS{})); diff != "" {
+		t.Fatalf("unexpected DeliveryReports (-want +got):\n%s", diff)
+	}
+
+	// The status report fallback completes the second SMS, which also closes
+	// its channel.
+	if _, ok := dt.Report(&SMS{
+		PDUType:          SMSPDUTypeStatusReport,
+		MessageReference: 2,
+		DeliveryState:    SMSDeliveryStateErrorNotObtainable,
+	}); !ok {
+		t.Fatal("no report for message reference 2")
+	}
+
+	if r, ok := <-bReports; ok {
+		t.Fatalf("unexpected DeliveryReport: %+v", r)
+	}
+
+	for _, ref := range []int{1, 2} {
+		if _, ok := dt.Status(ref); ok {
+			t.Fatalf("message reference %d is still tracked", ref)
+		}
+	}
+}
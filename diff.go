@@ -0,0 +1,73 @@
+package modemmanager
+
+import "reflect"
+
+// DiffModem reports the names of the exported fields which differ between the
+// old and new Modem snapshots, in struct field order. A nil Modem is treated as
+// the zero value. If no fields differ, nil is returned.
+func DiffModem(old, new *Modem) []string { return diff(old, new) }
+
+// DiffBearer reports the names of the exported fields which differ between the
+// old and new Bearer snapshots, in struct field order. A nil Bearer is treated
+// as the zero value. If no fields differ, nil is returned.
+func DiffBearer(old, new *Bearer) []string { return diff(old, new) }
+
+// DiffSignal reports the names of the exported fields which differ between the
+// old and new Signal snapshots, in struct field order. A nil Signal is treated
+// as the zero value. If no fields differ, nil is returned.
+func DiffSignal(old, new *Signal) []string { return diff(old, new) }
+
+// diff compares the exported fields of two structs of the same type.
+func diff[T any](old, new *T) []string {
+	var zero T
+	if old == nil {
+		old = &zero
+	}
+	if new == nil {
+		new = &zero
+	}
+
+	ov, nv := reflect.ValueOf(old).Elem(), reflect.ValueOf(new).Elem()
+	t := ov.Type()
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+
+		if !equal(ov.Field(i), nv.Field(i)) {
+			fields = append(fields, t.Field(i).Name)
+		}
+	}
+
+	return fields
+}
+
+// equal compares two values of the same type. Scalar values are compared
+// directly to avoid the allocations incurred by reflect.DeepEqual.
+func equal(a, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Map, reflect.Slice:
+		// Treat nil and empty as equivalent since either indicates no data.
+		if a.Len() == 0 && b.Len() == 0 {
+			return true
+		}
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+	}
+
+	return reflect.DeepEqual(a.Interface(), b.Interface())
+}
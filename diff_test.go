@@ -0,0 +1,111 @@
+package modemmanager
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffModem(t *testing.T) {
+	old := &Modem{
+		Index:      0,
+		Model:      "MC7455",
+		Ports:      []Port{{Name: "wwan0", Type: PortTypeNet}},
+		PowerState: PowerStateOn,
+		State:      StateRegistered,
+	}
+
+	tests := []struct {
+		name     string
+		old, new *Modem
+		want     []string
+	}{
+		{
+			name: "equal",
+			old:  old,
+			new:  old,
+		},
+		{
+			name: "both nil",
+		},
+		{
+			name: "nil old",
+			new:  &Modem{Model: "MC7455"},
+			want: []string{"Model"},
+		},
+		{
+			name: "changed",
+			old:  old,
+			new: &Modem{
+				Index:      0,
+				Model:      "MC7455",
+				Ports:      []Port{{Name: "wwan1", Type: PortTypeNet}},
+				PowerState: PowerStateOn,
+				State:      StateConnected,
+			},
+			want: []string{"Ports", "State"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, DiffModem(tt.old, tt.new)); diff != "" {
+				t.Fatalf("unexpected fields (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDiffBearer(t *testing.T) {
+	old := &Bearer{
+		Connected: true,
+		IPv4Config: &IPConfig{
+			Address: &net.IPNet{IP: net.IPv4(192, 0, 2, 10), Mask: net.CIDRMask(24, 32)},
+		},
+		IPTimeout: 20 * time.Second,
+	}
+
+	new := &Bearer{
+		Connected: false,
+		IPv4Config: &IPConfig{
+			Address: &net.IPNet{IP: net.IPv4(192, 0, 2, 11), Mask: net.CIDRMask(24, 32)},
+		},
+		IPTimeout: 20 * time.Second,
+	}
+
+	want := []string{"Connected", "IPv4Config"}
+	if diff := cmp.Diff(want, DiffBearer(old, new)); diff != "" {
+		t.Fatalf("unexpected fields (-want +got):\n%s", diff)
+	}
+}
+
+func TestDiffSignal(t *testing.T) {
+	old := &Signal{
+		Rate: 10 * time.Second,
+		LTE:  &LTESignal{RSRP: -100},
+	}
+
+	new := &Signal{
+		Rate: 10 * time.Second,
+		LTE:  &LTESignal{RSRP: -101},
+	}
+
+	want := []string{"LTE"}
+	if diff := cmp.Diff(want, DiffSignal(old, new)); diff != "" {
+		t.Fatalf("unexpected fields (-want +got):\n%s", diff)
+	}
+}
+
+func BenchmarkDiffModem(b *testing.B) {
+	old := &Modem{Model: "MC7455", State: StateRegistered}
+	new := &Modem{Model: "MC7455", State: StateConnected}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_ = DiffModem(old, new)
+	}
+}
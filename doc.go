@@ -2,4 +2,4 @@
 // devices using D-Bus. MIT Licensed.
 package modemmanager
 
-//go:generate stringer -type=BearerIPMethod,PortType,PowerState,State -output strings.go
+//go:generate stringer -type=AccessTechnology,AssistanceDataType,Attachment,BearerAllowedAuth,BearerIPFamily,BearerIPMethod,BearerType,CDMAActivationError,CDMAActivationState,CallDirection,CallState,CallStateReason,CellBroadcastState,CellType,ConnectStep,ConnectionStatus,DRXCycle,DeliveryStatus,ESIMStatus,ErrorDomain,FacilityLock,FirmwareImageType,LocationSource,Lock,MICOMode,NetworkError,OMAFeature,OMASessionState,OMASessionStateFailedReason,OMASessionType,PacketServiceState,PortType,PowerState,RecoveryAction,RegistrationState3GPP,SIMRemovability,SIMType,SMSCDMATeleserviceID,SMSDeliveryState,SMSPDUType,SMSState,SMSStorage,SMSValidityType,State,StateChangeReason,USSDState -output strings.go
@@ -0,0 +1,210 @@
+package modemmanager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Categories of errors returned by ModemManager. An *Error wraps at most one of
+// these errors, which may be checked with 'errors.Is(err, ErrSIMBusy)' and
+// similar comparisons. ModemManager errors may also match os.ErrNotExist,
+// os.ErrPermission, os.ErrDeadlineExceeded, ErrUnsupported,
+// ErrIncorrectPassword, or ErrPUKRequired.
+var (
+	// ErrInProgress indicates that the operation is already in progress.
+	ErrInProgress = errors.New("operation already in progress")
+
+	// ErrWrongState indicates that the operation is not allowed in the
+	// object's current state, such as connecting a disabled Modem.
+	ErrWrongState = errors.New("operation not allowed in current state")
+
+	// ErrSIMNotInserted indicates that no SIM is inserted in the Modem.
+	ErrSIMNotInserted = errors.New("SIM not inserted")
+
+	// ErrSIMBusy indicates that the SIM is temporarily busy.
+	ErrSIMBusy = errors.New("SIM busy")
+
+	// ErrSIMFailure indicates that the SIM failed or is the wrong SIM.
+	ErrSIMFailure = errors.New("SIM failure")
+
+	// ErrPINRequired indicates that the SIM must be unlocked with a PIN.
+	ErrPINRequired = errors.New("SIM PIN required")
+
+	// ErrNoNetwork indicates that the Modem has no network service.
+	ErrNoNetwork = errors.New("no network service")
+
+	// ErrNetworkNotAllowed indicates that the network refused service to the
+	// Modem.
+	ErrNetworkNotAllowed = errors.New("network not allowed")
+
+	// ErrMemoryFull indicates that the storage for a message is full.
+	ErrMemoryFull = errors.New("memory full")
+
+	// ErrSerialFailure indicates that ModemManager failed to communicate with
+	// the Modem over one of its ports.
+	ErrSerialFailure = errors.New("modem communication failure")
+
+	// ErrActivationFailed indicates that CDMA activation failed.
+	ErrActivationFailed = errors.New("CDMA activation failed")
+)
+
+// An ErrorDomain is the domain of an error returned by ModemManager.
+type ErrorDomain int
+
+// Possible ErrorDomain values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Errors.html.
+const (
+	ErrorDomainUnknown ErrorDomain = iota
+	ErrorDomainCore
+	ErrorDomainMobileEquipment
+	ErrorDomainConnection
+	ErrorDomainSerial
+	ErrorDomainMessage
+	ErrorDomainCDMAActivation
+)
+
+// An Error is an error which occurred while communicating with ModemManager
+// over D-Bus, and describes the object and operation involved. ModemManager
+// errors are categorized by their domain. The original error, such as a
+// dbus.Error, is also available using errors.As.
+type Error struct {
+	// Name and Body are the D-Bus error name and body, such as
+	// "org.freedesktop.ModemManager1.Error.MobileEquipment.SimBusy" and a
+	// message describing the error. Both are empty if the error was not
+	// returned by D-Bus, such as when the context is canceled.
+	Name string
+	Body []interface{}
+
+	// Path, Interface, and Method are the object path, interface, and method
+	// involved in the error. Property is set when fetching a single property
+	// using the org.freedesktop.DBus.Properties.Get Method.
+	Path      dbus.ObjectPath
+	Interface string
+	Method    string
+	Property  string
+
+	// Domain is the domain of the error, or ErrorDomainUnknown if the error
+	// is not a ModemManager error.
+	Domain ErrorDomain
+
+	// Err is the category of the error, such as ErrSIMBusy, or nil if the
+	// error is not categorized.
+	Err error
+
+	// The original error.
+	err error
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return e.err.Error()
+	}
+
+	return fmt.Sprintf("%v: %v", e.Err, e.err)
+}
+
+// Is implements errors.Is, so that comparisons such as 'errors.Is(err,
+// ErrSIMBusy)' work as expected.
+func (e *Error) Is(target error) bool { return e.Err != nil && e.Err == target }
+
+// Unwrap implements errors unwrapping, so that the original error is available
+// using errors.Is and errors.As.
+func (e *Error) Unwrap() error { return e.err }
+
+// errorPrefix is the prefix for all ModemManager D-Bus error names.
+const errorPrefix = "org.freedesktop.ModemManager1.Error."
+
+// Error categories for each ModemManager error domain, keyed by the final
+// element of the D-Bus error name.
+var (
+	coreErrors = map[string]error{
+		"InProgress":   ErrInProgress,
+		"NotFound":     os.ErrNotExist,
+		"Unauthorized": os.ErrPermission,
+		"Unsupported":  ErrUnsupported,
+		"WrongState":   ErrWrongState,
+	}
+
+	// The MobileEquipment and Message domains share most error names.
+	equipmentErrors = map[string]error{
+		"IncorrectPassword": ErrIncorrectPassword,
+		"MemoryFull":        ErrMemoryFull,
+		"NetworkNotAllowed": ErrNetworkNotAllowed,
+		"NetworkTimeout":    os.ErrDeadlineExceeded,
+		"NoNetwork":         ErrNoNetwork,
+		"SimBusy":           ErrSIMBusy,
+		"SimFailure":        ErrSIMFailure,
+		"SimNotInserted":    ErrSIMNotInserted,
+		"SimPin":            ErrPINRequired,
+		"SimPuk":            ErrPUKRequired,
+		"SimWrong":          ErrSIMFailure,
+	}
+
+	connectionErrors = map[string]error{
+		"NoCarrier": ErrNoNetwork,
+	}
+
+	serialErrors = map[string]error{
+		"ResponseTimeout": os.ErrDeadlineExceeded,
+	}
+)
+
+// toError converts an error which occurred during the D-Bus operation
+// described by info to an *Error. If the error is a dbus.Error and mapError is
+// not nil and returns a non-nil error, that error is used as the *Error's
+// category.
+func toError(err error, info Error, mapError func(e *Error) error) error {
+	e := &info
+	e.err = err
+
+	var derr dbus.Error
+	if !errors.As(err, &derr) {
+		return e
+	}
+
+	e.Name, e.Body = derr.Name, derr.Body
+
+	domain, name, _ := strings.Cut(strings.TrimPrefix(derr.Name, errorPrefix), ".")
+	if !strings.HasPrefix(derr.Name, errorPrefix) {
+		domain = ""
+	}
+
+	switch domain {
+	case "Core":
+		e.Domain = ErrorDomainCore
+		e.Err = coreErrors[name]
+	case "MobileEquipment":
+		e.Domain = ErrorDomainMobileEquipment
+		e.Err = equipmentErrors[name]
+	case "Connection":
+		e.Domain = ErrorDomainConnection
+		e.Err = connectionErrors[name]
+	case "Serial":
+		e.Domain = ErrorDomainSerial
+		e.Err = serialErrors[name]
+		if e.Err == nil {
+			// All other serial errors are communication failures.
+			e.Err = ErrSerialFailure
+		}
+	case "Message":
+		e.Domain = ErrorDomainMessage
+		e.Err = equipmentErrors[name]
+	case "CdmaActivation":
+		e.Domain = ErrorDomainCDMAActivation
+		e.Err = ErrActivationFailed
+	}
+
+	if mapError != nil {
+		// The caller's mapping takes precedence over the default category.
+		if err := mapError(e); err != nil {
+			e.Err = err
+		}
+	}
+
+	return e
+}
@@ -0,0 +1,296 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestToError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		domain ErrorDomain
+		target error
+	}{
+		{
+			name:   "unauthorized",
+			err:    dbus.Error{Name: unauthorizedError},
+			domain: ErrorDomainCore,
+			target: os.ErrPermission,
+		},
+		{
+			name:   "unsupported",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Core.Unsupported"},
+			domain: ErrorDomainCore,
+			target: ErrUnsupported,
+		},
+		{
+			name:   "SIM busy",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.MobileEquipment.SimBusy"},
+			domain: ErrorDomainMobileEquipment,
+			target: ErrSIMBusy,
+		},
+		{
+			name:   "incorrect password",
+			err:    dbus.Error{Name: incorrectPasswordError},
+			domain: ErrorDomainMobileEquipment,
+			target: ErrIncorrectPassword,
+		},
+		{
+			name:   "no network",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.MobileEquipment.NoNetwork"},
+			domain: ErrorDomainMobileEquipment,
+			target: ErrNoNetwork,
+		},
+		{
+			name:   "no carrier",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Connection.NoCarrier"},
+			domain: ErrorDomainConnection,
+			target: ErrNoNetwork,
+		},
+		{
+			name:   "serial timeout",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Serial.ResponseTimeout"},
+			domain: ErrorDomainSerial,
+			target: os.ErrDeadlineExceeded,
+		},
+		{
+			name:   "serial",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Serial.OpenFailed"},
+			domain: ErrorDomainSerial,
+			target: ErrSerialFailure,
+		},
+		{
+			name:   "message memory full",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Message.MemoryFull"},
+			domain: ErrorDomainMessage,
+			target: ErrMemoryFull,
+		},
+		{
+			name:   "CDMA activation",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.CdmaActivation.NoSignal"},
+			domain: ErrorDomainCDMAActivation,
+			target: ErrActivationFailed,
+		},
+		{
+			name:   "uncategorized",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Core.Failed"},
+			domain: ErrorDomainCore,
+		},
+		{
+			name: "unknown domain",
+			err:  dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Foo.Bar"},
+		},
+		{
+			name: "D-Bus",
+			err: dbus.Error{
+				Name: unknownMethodError,
+				Body: []interface{}{"unknown method"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := Error{
+				Path:      "/org/freedesktop/ModemManager1/Modem/0",
+				Interface: "org.freedesktop.ModemManager1.Modem",
+				Method:    methodGet,
+				Property:  "State",
+			}
+
+			// Errors are wrapped when returned by a method call.
+			err := fmt.Errorf("failed to call: %w", toError(tt.err, info, nil))
+
+			var merr *Error
+			if !errors.As(err, &merr) {
+				t.Fatalf("expected *Error, but got: %v", err)
+			}
+
+			derr := tt.err.(dbus.Error)
+			want := &Error{
+				Name:      derr.Name,
+				Body:      derr.Body,
+				Path:      info.Path,
+				Interface: info.Interface,
+				Method:    info.Method,
+				Property:  info.Property,
+				Domain:    tt.domain,
+			}
+
+			// The error category is checked below.
+			opts := []cmp.Option{
+				cmpopts.IgnoreUnexported(Error{}),
+				cmpopts.IgnoreFields(Error{}, "Err"),
+				cmpopts.EquateEmpty(),
+			}
+
+			if diff := cmp.Diff(want, merr, opts...); diff != "" {
+				t.Fatalf("unexpected *Error (-want +got):\n%s", diff)
+			}
+
+			if tt.target == nil {
+				if merr.Err != nil {
+					t.Fatalf("expected uncategorized error, but got: %v", merr.Err)
+				}
+			} else if !errors.Is(err, tt.target) {
+				t.Fatalf("expected error matching %v, but got: %v", tt.target, err)
+			}
+
+			// The original error must remain available.
+			var gotErr dbus.Error
+			if !errors.As(err, &gotErr) {
+				t.Fatalf("expected dbus.Error, but got: %v", err)
+			}
+
+			if diff := cmp.Diff(derr, gotErr); diff != "" {
+				t.Fatalf("unexpected dbus.Error (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestToErrorMapError(t *testing.T) {
+	var (
+		errSIM   = errors.New("SIM problem")
+		errOther = errors.New("other problem")
+	)
+
+	// Map all SIM errors to a single category, and add a category for an error
+	// which this package does not categorize.
+	c := &Client{MapError: func(e *Error) error {
+		switch {
+		case errors.Is(e, ErrSIMBusy), errors.Is(e, ErrSIMFailure):
+			return errSIM
+		case e.Name == "org.freedesktop.ModemManager1.Error.Core.Failed":
+			return errOther
+		default:
+			return nil
+		}
+	}}
+
+	tests := []struct {
+		name   string
+		err    dbus.Error
+		target error
+	}{
+		{
+			name:   "SIM busy",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.MobileEquipment.SimBusy"},
+			target: errSIM,
+		},
+		{
+			name:   "failed",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.Core.Failed"},
+			target: errOther,
+		},
+		{
+			name:   "default",
+			err:    dbus.Error{Name: "org.freedesktop.ModemManager1.Error.MobileEquipment.NoNetwork"},
+			target: ErrNoNetwork,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := toError(tt.err, Error{}, c.mapError)
+			if !errors.Is(err, tt.target) {
+				t.Fatalf("expected error matching %v, but got: %v", tt.target, err)
+			}
+		})
+	}
+}
+
+func TestToErrorNotDBus(t *testing.T) {
+	info := Error{
+		Path:      "/org/freedesktop/ModemManager1/Modem/0",
+		Interface: "org.freedesktop.ModemManager1.Modem",
+		Method:    "org.freedesktop.ModemManager1.Modem.Enable",
+	}
+
+	// Errors which did not come from D-Bus are also wrapped with their
+	// context, but are not categorized.
+	err := toError(context.Canceled, info, func(_ *Error) error {
+		t.Fatal("unexpected call to map error")
+		return nil
+	})
+
+	var merr *Error
+	if !errors.As(err, &merr) {
+		t.Fatalf("expected *Error, but got: %v", err)
+	}
+
+	want := &Error{
+		Path:      info.Path,
+		Interface: info.Interface,
+		Method:    info.Method,
+	}
+
+	if diff := cmp.Diff(want, merr, cmpopts.IgnoreUnexported(Error{})); diff != "" {
+		t.Fatalf("unexpected *Error (-want +got):\n%s", diff)
+	}
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, but got: %v", err)
+	}
+}
+
+func TestToErrorPINError(t *testing.T) {
+	info := Error{
+		Path:      "/org/freedesktop/ModemManager1/SIM/0",
+		Interface: "org.freedesktop.ModemManager1.Sim",
+		Method:    "org.freedesktop.ModemManager1.Sim.SendPin",
+	}
+
+	c := &Client{}
+	err := c.toPINError(
+		context.Background(),
+		"",
+		toError(dbus.Error{Name: incorrectPasswordError}, info, nil),
+	)
+
+	var perr *PINError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *PINError, but got: %v", err)
+	}
+
+	if !errors.Is(err, ErrIncorrectPassword) {
+		t.Fatalf("expected incorrect password error, but got: %v", err)
+	}
+
+	if errors.Is(err, ErrPUKRequired) {
+		t.Fatalf("unexpected PUK required error: %v", err)
+	}
+
+	// The operation which failed must remain available.
+	var merr *Error
+	if !errors.As(err, &merr) {
+		t.Fatalf("expected *Error, but got: %v", err)
+	}
+
+	want := &Error{
+		Name:      incorrectPasswordError,
+		Path:      info.Path,
+		Interface: info.Interface,
+		Method:    info.Method,
+		Domain:    ErrorDomainMobileEquipment,
+	}
+
+	// The error category is checked above.
+	opts := []cmp.Option{
+		cmpopts.IgnoreUnexported(Error{}),
+		cmpopts.IgnoreFields(Error{}, "Err"),
+		cmpopts.EquateEmpty(),
+	}
+
+	if diff := cmp.Diff(want, merr, opts...); diff != "" {
+		t.Fatalf("unexpected *Error (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,204 @@
+package modemmanager
+
+import (
+	"context"
+	"time"
+)
+
+// A Failover keeps one of two Modems connected, preferring a primary Modem and
+// failing over to a secondary Modem when the primary loses connectivity or
+// signal. Both Modems are kept enabled and registered so that the secondary
+// is ready to take over, but only the active Modem is connected.
+type Failover struct {
+	// CheckInterval is the interval at which the health of both Modems is
+	// checked.
+	CheckInterval time.Duration
+
+	// ConnectTimeout bounds each attempt to connect a Modem.
+	ConnectTimeout time.Duration
+
+	// MinSignalQuality is the signal quality percentage below which a Modem
+	// is considered unhealthy.
+	MinSignalQuality int
+
+	// FailbackDelay is how long the primary Modem must remain healthy before
+	// the connection fails back to it from the secondary Modem.
+	FailbackDelay time.Duration
+
+	primary, secondary FailoverModem
+}
+
+// A FailoverModem is a Modem managed by a Failover and the configuration used
+// to connect it.
+type FailoverModem struct {
+	Modem  *Modem
+	Config ConnectConfig
+}
+
+// A FailoverEvent is an event which occurs when a Failover connects a Modem or
+// fails to do so.
+type FailoverEvent struct {
+	// Active is the Modem which is currently connected, or nil if no Modem
+	// has been connected.
+	Active *Modem
+
+	// Bearer is the Active Modem's Bearer if it was just connected.
+	Bearer *Bearer
+
+	// Err reports why connecting a Modem failed, in which case Active is
+	// unchanged.
+	Err error
+}
+
+// NewFailover creates a Failover for the primary and secondary Modems with
+// default settings, which may be modified before calling Run.
+func NewFailover(primary, secondary FailoverModem) *Failover {
+	return &Failover{
+		CheckInterval:    10 * time.Second,
+		ConnectTimeout:   2 * time.Minute,
+		MinSignalQuality: 10,
+		FailbackDelay:    time.Minute,
+
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// Run begins managing both Modems, delivering an event each time a Modem is
+// connected or fails to connect on the returned channel. Management stops and
+// the channel is closed when ctx is canceled. The caller must receive from the
+// channel until it is closed, or management will stall.
+//
+// A Modem is healthy when it is registered with a network, its signal quality
+// is at least MinSignalQuality, and it has not failed to connect within the
+// last FailbackDelay. The primary Modem is used whenever it is healthy, unless
+// the secondary Modem is active and the primary has not yet been healthy for
+// FailbackDelay. If neither Modem is healthy, the active Modem is kept.
+func (f *Failover) Run(ctx context.Context) <-chan FailoverEvent {
+	events := make(chan FailoverEvent)
+	go func() {
+		defer close(events)
+		f.run(ctx, events)
+	}()
+
+	return events
+}
+
+// run implements Run.
+func (f *Failover) run(ctx context.Context, events chan<- FailoverEvent) {
+	// Keep both Modems enabled so the standby can take over quickly. Failures
+	// are retried when a Modem is connected.
+	_ = f.primary.Modem.Enable(ctx, true)
+	_ = f.secondary.Modem.Enable(ctx, true)
+
+	t := time.NewTicker(f.CheckInterval)
+	defer t.Stop()
+
+	var (
+		active       *FailoverModem
+		healthySince time.Time
+
+		// The last time each Modem failed to connect. A Modem which recently
+		// failed is treated as unhealthy so the other Modem may be used.
+		failed = make(map[*FailoverModem]time.Time)
+	)
+
+	for {
+		ps, pOK := f.status(ctx, f.primary.Modem)
+		ss, sOK := f.status(ctx, f.secondary.Modem)
+
+		now := time.Now()
+		pOK = pOK && now.Sub(failed[&f.primary]) >= f.FailbackDelay
+		sOK = sOK && now.Sub(failed[&f.secondary]) >= f.FailbackDelay
+
+		if !pOK {
+			healthySince = time.Time{}
+		} else if healthySince.IsZero() {
+			healthySince = now
+		}
+
+		// Choose the Modem which should be connected.
+		var want *FailoverModem
+		switch {
+		case pOK && (active != &f.secondary || !sOK || now.Sub(healthySince) >= f.FailbackDelay):
+			want = &f.primary
+		case sOK:
+			want = &f.secondary
+		case active != nil:
+			want = active
+		default:
+			// Nothing is healthy, but try the preferred Modem anyway.
+			want = &f.primary
+		}
+
+		status := ps
+		if want == &f.secondary {
+			status = ss
+		}
+
+		if want != active || status == nil || status.State != StateConnected {
+			e := f.connect(ctx, want, f.other(want), want != active)
+			if e.Err == nil {
+				active = want
+			} else {
+				failed[want] = now
+			}
+
+			e.Active = nil
+			if active != nil {
+				e.Active = active.Modem
+			}
+
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// status fetches a Modem's Status and reports whether the Modem is healthy.
+func (f *Failover) status(ctx context.Context, m *Modem) (*Status, bool) {
+	s, err := m.GetStatus(ctx)
+	if err != nil {
+		return nil, false
+	}
+
+	return s, s.State >= StateRegistered && s.SignalQuality >= f.MinSignalQuality
+}
+
+// other returns the FailoverModem which is not fm.
+func (f *Failover) other(fm *FailoverModem) *FailoverModem {
+	if fm == &f.primary {
+		return &f.secondary
+	}
+
+	return &f.primary
+}
+
+// connect connects want and, if teardown is set, then disconnects other so
+// that only one Modem is connected.
+func (f *Failover) connect(ctx context.Context, want, other *FailoverModem, teardown bool) FailoverEvent {
+	cctx, cancel := context.WithTimeout(ctx, f.ConnectTimeout)
+	defer cancel()
+
+	b, err := want.Modem.ConnectLTE(cctx, want.Config)
+	if err != nil {
+		return FailoverEvent{Err: err}
+	}
+
+	if teardown {
+		// The other Modem is no longer used, so a failure to disconnect it
+		// is not reported.
+		_ = other.Modem.Disconnect(ctx, nil)
+	}
+
+	return FailoverEvent{Bearer: b}
+}
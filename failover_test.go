@@ -0,0 +1,197 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFailoverRun(t *testing.T) {
+	var (
+		registered = testStatus(StateRegistered, 50)
+		searching  = testStatus(StateSearching, 0)
+	)
+
+	tests := []struct {
+		name                 string
+		failback             time.Duration
+		primary, secondary   []map[string]dbus.Variant
+		failPrimary          bool
+		active               []int
+		errs                 int
+		primaryDisconnects   int
+		secondaryDisconnects int
+	}{
+		{
+			name:      "failover and failback",
+			primary:   []map[string]dbus.Variant{registered, searching, registered},
+			secondary: []map[string]dbus.Variant{registered},
+			// Primary, then secondary when the primary loses signal, and
+			// then back to the primary.
+			active:               []int{0, 1, 0},
+			primaryDisconnects:   1,
+			secondaryDisconnects: 2,
+		},
+		{
+			name:      "failback delay",
+			failback:  time.Hour,
+			primary:   []map[string]dbus.Variant{registered, searching, registered},
+			secondary: []map[string]dbus.Variant{registered},
+			// The primary has not been healthy for long enough to fail back.
+			active:               []int{0, 1},
+			primaryDisconnects:   1,
+			secondaryDisconnects: 1,
+		},
+		{
+			name:        "primary connect fails",
+			failback:    time.Hour,
+			primary:     []map[string]dbus.Variant{registered},
+			secondary:   []map[string]dbus.Variant{registered},
+			failPrimary: true,
+			// The primary fails to connect, so the secondary is used.
+			active:             []int{1},
+			errs:               1,
+			primaryDisconnects: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Stop after a number of checks of the primary Modem.
+			var checks int
+			primary := &failoverTestModem{
+				statuses: tt.primary,
+				fail:     tt.failPrimary,
+				check: func() {
+					checks++
+					if checks == 6 {
+						cancel()
+					}
+				},
+			}
+			secondary := &failoverTestModem{statuses: tt.secondary}
+
+			f := NewFailover(
+				FailoverModem{Modem: primary.modem(0)},
+				FailoverModem{Modem: secondary.modem(1)},
+			)
+			f.CheckInterval = time.Millisecond
+			f.FailbackDelay = tt.failback
+
+			var (
+				active []int
+				errs   int
+			)
+
+			for e := range f.Run(ctx) {
+				if e.Err != nil {
+					errs++
+					continue
+				}
+
+				active = append(active, e.Active.Index)
+			}
+
+			// Once connected, a Modem which stays healthy is not reconnected.
+			if diff := cmp.Diff(tt.active, active); diff != "" {
+				t.Fatalf("unexpected active Modems (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.errs, errs); diff != "" {
+				t.Fatalf("unexpected number of errors (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.primaryDisconnects, primary.disconnects); diff != "" {
+				t.Fatalf("unexpected primary disconnects (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.secondaryDisconnects, secondary.disconnects); diff != "" {
+				t.Fatalf("unexpected secondary disconnects (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// A failoverTestModem is a fake Modem for Failover tests.
+type failoverTestModem struct {
+	// Statuses reported by each check, repeating the last. A registered
+	// Modem reports that it is connected after it is connected.
+	statuses  []map[string]dbus.Variant
+	connected bool
+
+	// Whether connecting fails, and a function called on each check.
+	fail  bool
+	check func()
+
+	disconnects int
+}
+
+// modem creates a Modem with index i backed by the failoverTestModem.
+func (ftm *failoverTestModem) modem(i int) *Modem {
+	return &Modem{
+		Index: i,
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				switch method {
+				case "org.freedesktop.ModemManager1.Modem.Simple.GetStatus":
+					if ftm.check != nil {
+						ftm.check()
+					}
+
+					s := ftm.statuses[0]
+					if len(ftm.statuses) > 1 {
+						ftm.statuses = ftm.statuses[1:]
+					}
+
+					switch {
+					case s["state"].Value().(int32) < int32(StateRegistered):
+						// The connection drops with the registration.
+						ftm.connected = false
+					case ftm.connected:
+						s = testStatus(StateConnected, 50)
+					}
+
+					return dbus.Store([]interface{}{s}, out)
+				case "org.freedesktop.ModemManager1.Modem.Simple.Connect":
+					if ftm.fail {
+						return errors.New("connect failed")
+					}
+
+					ftm.connected = true
+
+					return dbus.Store(
+						[]interface{}{objectPath("Bearer", "0")},
+						out,
+					)
+				case "org.freedesktop.ModemManager1.Modem.Simple.Disconnect":
+					ftm.connected = false
+					ftm.disconnects++
+				}
+
+				return nil
+			},
+			get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+				if prop == "UnlockRequired" {
+					return dbus.MakeVariant(uint32(LockNone)), nil
+				}
+
+				return dbus.MakeVariant(int32(StateConnected)), nil
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{
+					"Connected": dbus.MakeVariant(true),
+				}, nil
+			},
+			watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				return testSignals(ctx), nil
+			},
+		},
+	}
+}
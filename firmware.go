@@ -0,0 +1,183 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ErrModemReset indicates that a Modem disconnected from ModemManager while
+// resetting to apply a firmware selection.
+var ErrModemReset = errors.New("modem reset to apply firmware selection")
+
+// modemRemovedWait is how long FirmwareSelect waits for ModemManager to report
+// the Modem's removal after the selection times out.
+const modemRemovedWait = time.Second
+
+// A FirmwareImage is a firmware image installed on a Modem.
+type FirmwareImage struct {
+	Type     FirmwareImageType
+	UniqueID string
+
+	// Gobi contains additional version information which is only reported
+	// for FirmwareImageTypeGobi images.
+	Gobi *GobiFirmware
+}
+
+// GobiFirmware contains version information for a Qualcomm Gobi firmware
+// image.
+type GobiFirmware struct {
+	BootVersion   string
+	ModemUniqueID string
+	PRIInfo       string
+	PRIUniqueID   string
+	PRIVersion    string
+}
+
+// A FirmwareImageType is the type of a FirmwareImage.
+type FirmwareImageType int
+
+// Possible FirmwareImageType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMFirmwareImageType.
+const (
+	FirmwareImageTypeUnknown FirmwareImageType = iota
+	FirmwareImageTypeGeneric
+	FirmwareImageTypeGobi
+)
+
+// A FirmwareList is the set of firmware images installed on a Modem.
+type FirmwareList struct {
+	// Selected is the image currently selected for use, or nil if none is
+	// selected. Selected is also present in Images.
+	Selected *FirmwareImage
+	Images   []*FirmwareImage
+}
+
+// FirmwareList lists the firmware images installed on the Modem.
+func (m *Modem) FirmwareList(ctx context.Context) (*FirmwareList, error) {
+	var (
+		selected string
+		images   []map[string]dbus.Variant
+	)
+
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Firmware", "List"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		[]interface{}{&selected, &images},
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	fl := FirmwareList{Images: make([]*FirmwareImage, 0, len(images))}
+	for _, ps := range images {
+		img, err := parseFirmwareImage(ps)
+		if err != nil {
+			return nil, err
+		}
+
+		if selected != "" && img.UniqueID == selected {
+			fl.Selected = img
+		}
+
+		fl.Images = append(fl.Images, img)
+	}
+
+	return &fl, nil
+}
+
+// FirmwareSelect selects the firmware image identified by uniqueID, such as a
+// FirmwareImage.UniqueID reported by FirmwareList, for use by the Modem. If no
+// such image exists, an error compatible with 'errors.Is(err,
+// os.ErrNotExist)' is returned.
+//
+// The Modem reboots to apply the selection, after which the Modem is removed
+// from ModemManager and must be fetched again using Client.ForEachModem once
+// it reappears. If the Modem is removed from ModemManager before it can reply,
+// an error compatible with 'errors.Is(err, ErrModemReset)' is returned and the
+// selection has most likely been applied. If the selection otherwise times
+// out, an error compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is
+// returned.
+func (m *Modem) FirmwareSelect(ctx context.Context, uniqueID string) error {
+	op := objectPath("Modem", strconv.Itoa(m.Index))
+
+	// Watch for the Modem's removal before selecting the image so that a
+	// reset can be told apart from an unresponsive Modem.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	removed, err := m.c.watchRemoved(ctx, op)
+	if err != nil {
+		return err
+	}
+
+	err = m.c.call(
+		ctx,
+		interfacePath("Modem", "Firmware", "Select"),
+		op,
+		nil,
+		uniqueID,
+	)
+	if err == nil {
+		return nil
+	}
+
+	var derr dbus.Error
+	if errors.As(err, &derr) && derr.Name == noReplyError {
+		// The removal may be reported just after the call fails.
+		timer := time.NewTimer(modemRemovedWait)
+		defer timer.Stop()
+
+		select {
+		case <-removed:
+			return fmt.Errorf("%v: %w", err, ErrModemReset)
+		case <-timer.C:
+		case <-ctx.Done():
+		}
+	}
+
+	return toNotExist(toTimeout(toPermission(err)), notFoundError)
+}
+
+// parseFirmwareImage parses a FirmwareImage from a properties map.
+func parseFirmwareImage(ps map[string]dbus.Variant) (*FirmwareImage, error) {
+	var (
+		img  FirmwareImage
+		gobi GobiFirmware
+	)
+
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "image-type":
+			img.Type = FirmwareImageType(vp.Int())
+		case "unique-id":
+			img.UniqueID = vp.String()
+		case "gobi-boot-version":
+			gobi.BootVersion = vp.String()
+		case "gobi-modem-unique-id":
+			gobi.ModemUniqueID = vp.String()
+		case "gobi-pri-info":
+			gobi.PRIInfo = vp.String()
+		case "gobi-pri-unique-id":
+			gobi.PRIUniqueID = vp.String()
+		case "gobi-pri-version":
+			gobi.PRIVersion = vp.String()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	if img.Type == FirmwareImageTypeGobi {
+		img.Gobi = &gobi
+	}
+
+	return &img, nil
+}
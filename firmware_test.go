@@ -0,0 +1,167 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemFirmwareList(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Firmware.List", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{
+				"ATT",
+				[]map[string]dbus.Variant{
+					{
+						"image-type":         dbus.MakeVariant(uint32(FirmwareImageTypeGobi)),
+						"unique-id":          dbus.MakeVariant("ATT"),
+						"gobi-pri-version":   dbus.MakeVariant("05.05.58.01"),
+						"gobi-pri-info":      dbus.MakeVariant("ATT"),
+						"gobi-boot-version":  dbus.MakeVariant("SWI9X30C_02.33.03.00"),
+						"gobi-pri-unique-id": dbus.MakeVariant("002.064_000"),
+					},
+					{
+						"image-type": dbus.MakeVariant(uint32(FirmwareImageTypeGeneric)),
+						"unique-id":  dbus.MakeVariant("GENERIC"),
+					},
+				},
+			}, out.([]interface{})...)
+		}},
+	}
+
+	fl, err := m.FirmwareList(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list firmware: %v", err)
+	}
+
+	att := &FirmwareImage{
+		Type:     FirmwareImageTypeGobi,
+		UniqueID: "ATT",
+		Gobi: &GobiFirmware{
+			BootVersion: "SWI9X30C_02.33.03.00",
+			PRIInfo:     "ATT",
+			PRIUniqueID: "002.064_000",
+			PRIVersion:  "05.05.58.01",
+		},
+	}
+
+	want := &FirmwareList{
+		Selected: att,
+		Images: []*FirmwareImage{
+			att,
+			{
+				Type:     FirmwareImageTypeGeneric,
+				UniqueID: "GENERIC",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, fl); diff != "" {
+		t.Fatalf("unexpected FirmwareList (-want +got):\n%s", diff)
+	}
+
+	if fl.Selected != fl.Images[0] {
+		t.Fatal("selected image is not present in images")
+	}
+}
+
+func Test_parseFirmwareImageError(t *testing.T) {
+	_, err := parseFirmwareImage(map[string]dbus.Variant{
+		"unique-id": dbus.MakeVariant(1),
+	})
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestModemFirmwareSelect(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		removed dbus.ObjectPath
+		want    error
+	}{
+		{
+			name: "OK",
+		},
+		{
+			name: "not found",
+			err:  dbus.Error{Name: notFoundError},
+			want: os.ErrNotExist,
+		},
+		{
+			name: "permission denied",
+			err:  dbus.Error{Name: unauthorizedError},
+			want: os.ErrPermission,
+		},
+		{
+			name:    "reset",
+			err:     dbus.Error{Name: noReplyError},
+			removed: "/org/freedesktop/ModemManager1/Modem/0",
+			want:    ErrModemReset,
+		},
+		{
+			name:    "timeout",
+			err:     dbus.Error{Name: noReplyError},
+			removed: "/org/freedesktop/ModemManager1/Modem/1",
+			want:    os.ErrDeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watch := func(ctx context.Context, op dbus.ObjectPath, iface, member string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.DBus.ObjectManager.InterfacesRemoved", iface+"."+member); diff != "" {
+					t.Fatalf("unexpected signal (-want +got):\n%s", diff)
+				}
+
+				if tt.removed == "" {
+					return testSignals(ctx), nil
+				}
+
+				return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+					tt.removed,
+					[]string{"org.freedesktop.ModemManager1.Modem"},
+				}}), nil
+			}
+
+			m := &Modem{
+				c: &Client{watch: watch, call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+					if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Firmware.Select", method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff([]interface{}{"ATT"}, args); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return tt.err
+				}},
+			}
+
+			if err := m.FirmwareSelect(context.Background(), "ATT"); !errors.Is(err, tt.want) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
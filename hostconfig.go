@@ -0,0 +1,99 @@
+package modemmanager
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+// A HostConfig is the network configuration a host must apply to its network
+// interface to use a Bearer's data connection, such as by using netlink or
+// systemd-networkd.
+type HostConfig struct {
+	// Interface is the name of the host's network interface.
+	Interface string
+
+	// Addresses and routes which must be statically configured.
+	Addresses []*net.IPNet
+	Routes    []HostRoute
+
+	// DHCPv4 and DHCPv6 report whether the host must obtain its IPv4 or IPv6
+	// addresses dynamically, such as with DHCP or IPv6 autoconfiguration.
+	DHCPv4, DHCPv6 bool
+
+	// DNS servers and MTU, if reported by the Modem.
+	DNS []net.IP
+	MTU int
+}
+
+// A HostRoute is a route which must be configured on the host.
+type HostRoute struct {
+	// Destination is the route's destination network.
+	Destination *net.IPNet
+
+	// Gateway is the route's next hop, or nil if the destination is reachable
+	// directly through the interface.
+	Gateway net.IP
+}
+
+// HostConfig converts the Bearer's IPv4 and IPv6 configuration into the
+// network configuration the host must apply. Bearers using PPP are configured
+// by the PPP daemon rather than the host, so for those ErrUnsupported is
+// returned.
+func (b *Bearer) HostConfig() (*HostConfig, error) {
+	if !b.Connected {
+		return nil, fmt.Errorf("bearer %d is not connected", b.Index)
+	}
+
+	hc := &HostConfig{Interface: b.Interface}
+	for _, ipc := range []struct {
+		c    *IPConfig
+		ip6  bool
+		dhcp *bool
+	}{
+		{c: b.IPv4Config, ip6: false, dhcp: &hc.DHCPv4},
+		{c: b.IPv6Config, ip6: true, dhcp: &hc.DHCPv6},
+	} {
+		if ipc.c == nil {
+			continue
+		}
+
+		switch ipc.c.Method {
+		case BearerIPMethodUnknown:
+			// This IP family is not in use.
+			continue
+		case BearerIPMethodPPP:
+			return nil, fmt.Errorf("bearer %d uses PPP: %w", b.Index, ErrUnsupported)
+		case BearerIPMethodStatic:
+			if ipc.c.Address == nil || ipc.c.Address.IP == nil {
+				return nil, errors.New("static IP configuration has no address")
+			}
+
+			hc.Addresses = append(hc.Addresses, ipc.c.Address)
+			hc.Routes = append(hc.Routes, HostRoute{
+				Destination: defaultRoute(ipc.ip6),
+				Gateway:     ipc.c.Gateway,
+			})
+		case BearerIPMethodDHCP:
+			*ipc.dhcp = true
+		}
+
+		hc.DNS = append(hc.DNS, ipc.c.DNS...)
+
+		// Prefer the first reported MTU, as both families share an interface.
+		if hc.MTU == 0 {
+			hc.MTU = ipc.c.MTU
+		}
+	}
+
+	return hc, nil
+}
+
+// defaultRoute returns the IPv4 or IPv6 default route destination.
+func defaultRoute(ip6 bool) *net.IPNet {
+	if ip6 {
+		return &net.IPNet{IP: net.IPv6zero, Mask: net.CIDRMask(0, 128)}
+	}
+
+	return &net.IPNet{IP: net.IPv4zero.To4(), Mask: net.CIDRMask(0, 32)}
+}
@@ -0,0 +1,108 @@
+package modemmanager
+
+import (
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBearerHostConfig(t *testing.T) {
+	b := &Bearer{
+		Connected: true,
+		Interface: "wwan0",
+		IPv4Config: &IPConfig{
+			Address: &net.IPNet{
+				IP:   net.IPv4(192, 0, 2, 10),
+				Mask: net.CIDRMask(24, 32),
+			},
+			DNS: []net.IP{
+				net.IPv4(192, 0, 2, 0),
+				net.IPv4(192, 0, 2, 1),
+			},
+			Gateway: net.IPv4(192, 0, 2, 0),
+			Method:  BearerIPMethodStatic,
+			MTU:     1500,
+		},
+		IPv6Config: &IPConfig{
+			DNS:    []net.IP{net.ParseIP("2001:db8::")},
+			Method: BearerIPMethodDHCP,
+			MTU:    1280,
+		},
+	}
+
+	hc, err := b.HostConfig()
+	if err != nil {
+		t.Fatalf("failed to get host config: %v", err)
+	}
+
+	want := &HostConfig{
+		Interface: "wwan0",
+		Addresses: []*net.IPNet{{
+			IP:   net.IPv4(192, 0, 2, 10),
+			Mask: net.CIDRMask(24, 32),
+		}},
+		Routes: []HostRoute{{
+			Destination: &net.IPNet{
+				IP:   net.IPv4zero.To4(),
+				Mask: net.CIDRMask(0, 32),
+			},
+			Gateway: net.IPv4(192, 0, 2, 0),
+		}},
+		DHCPv6: true,
+		DNS: []net.IP{
+			net.IPv4(192, 0, 2, 0),
+			net.IPv4(192, 0, 2, 1),
+			net.ParseIP("2001:db8::"),
+		},
+		MTU: 1500,
+	}
+
+	if diff := cmp.Diff(want, hc); diff != "" {
+		t.Fatalf("unexpected HostConfig (-want +got):\n%s", diff)
+	}
+}
+
+func TestBearerHostConfigErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		b           *Bearer
+		unsupported bool
+	}{
+		{
+			name: "not connected",
+			b:    &Bearer{},
+		},
+		{
+			name: "PPP",
+			b: &Bearer{
+				Connected:  true,
+				IPv4Config: &IPConfig{Method: BearerIPMethodPPP},
+			},
+			unsupported: true,
+		},
+		{
+			name: "static no address",
+			b: &Bearer{
+				Connected:  true,
+				IPv6Config: &IPConfig{Method: BearerIPMethodStatic},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.HostConfig()
+			if err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+
+			if diff := cmp.Diff(tt.unsupported, errors.Is(err, ErrUnsupported)); diff != "" {
+				t.Fatalf("unexpected unsupported error (-want +got):\n%s", diff)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
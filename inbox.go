@@ -0,0 +1,268 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A MessageStore persists SMS messages delivered by an Inbox, such as to disk
+// or a SQL database, so that messages are not delivered again after a
+// process restart.
+type MessageStore interface {
+	// Seen reports whether the SMS was previously stored.
+	Seen(ctx context.Context, s *SMS) (bool, error)
+
+	// Store stores an SMS after it is delivered by an Inbox.
+	Store(ctx context.Context, s *SMS) error
+}
+
+// An Inbox delivers complete SMS messages received by a Modem, including
+// messages received before the Inbox was started. Concatenated messages are
+// delivered once all of their parts are received.
+type Inbox struct {
+	// Retention, if not nil, is applied to each message after it is
+	// delivered and stored. It must be set before calling Messages.
+	Retention *RetentionPolicy
+
+	m     *Modem
+	store MessageStore
+
+	mu  sync.Mutex
+	err error
+}
+
+// NewInbox creates an Inbox for the Modem. If store is not nil, it is used to
+// skip previously delivered messages and to record newly delivered ones.
+func NewInbox(m *Modem, store MessageStore) *Inbox {
+	return &Inbox{
+		m:     m,
+		store: store,
+	}
+}
+
+// Messages delivers received SMS messages on the returned channel, which is
+// closed when the context is canceled or the Inbox's MessageStore or
+// RetentionPolicy returns an error. Once the channel is closed, Err reports
+// any such error.
+func (i *Inbox) Messages(ctx context.Context) (<-chan *SMS, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// Watch for new messages before listing the existing ones so that no
+	// messages are missed in between.
+	added, err := i.m.WatchMessages(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	existing, err := i.m.Messages(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	out := make(chan *SMS)
+	go func() {
+		var wg sync.WaitGroup
+		defer close(out)
+		defer wg.Wait()
+		defer cancel()
+
+		// Concatenated messages which are still being received are waited
+		// on in their own goroutines so that other messages are not held up.
+		// Each waiter sends the complete message, or nil if the message was
+		// deleted.
+		var (
+			received = make(chan *SMS)
+			pending  int
+		)
+
+		// A message may be both listed and added, so deduplicate by index.
+		seen := make(map[int]bool)
+		handle := func(s *SMS) bool {
+			if seen[s.Index] {
+				return true
+			}
+			seen[s.Index] = true
+
+			if s.State != SMSStateReceiving {
+				return i.deliver(ctx, out, s)
+			}
+
+			pending++
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				s, err := i.m.c.waitReceived(ctx, s)
+				if err != nil {
+					s = nil
+				}
+
+				select {
+				case received <- s:
+				case <-ctx.Done():
+				}
+			}()
+
+			return true
+		}
+
+		for _, s := range existing {
+			if s.State != SMSStateReceived && s.State != SMSStateReceiving {
+				continue
+			}
+
+			if !handle(s) {
+				return
+			}
+		}
+
+		// Keep delivering messages until no more can be added and no more
+		// are pending.
+		for added != nil || pending > 0 {
+			select {
+			case a, ok := <-added:
+				if !ok {
+					added = nil
+					continue
+				}
+				if !a.Received {
+					continue
+				}
+
+				if !handle(a.SMS) {
+					return
+				}
+			case s := <-received:
+				pending--
+				if s == nil {
+					continue
+				}
+
+				if !i.deliver(ctx, out, s) {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Err returns the MessageStore or RetentionPolicy error which caused the Inbox
+// to stop delivering messages, if any.
+func (i *Inbox) Err() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	return i.err
+}
+
+// deliver sends s on out unless it was previously stored, and then stores it.
+// It returns false if the Inbox must stop delivering messages.
+func (i *Inbox) deliver(ctx context.Context, out chan<- *SMS, s *SMS) bool {
+	if i.store != nil {
+		seen, err := i.store.Seen(ctx, s)
+		if err != nil {
+			i.setErr(err)
+			return false
+		}
+		if seen {
+			// The message was handed to the application before, so the
+			// RetentionPolicy may prune it.
+			if i.Retention != nil {
+				i.Retention.markHandled(s)
+			}
+
+			return true
+		}
+	}
+
+	select {
+	case out <- s:
+	case <-ctx.Done():
+		return false
+	}
+
+	if i.store != nil {
+		if err := i.store.Store(ctx, s); err != nil {
+			i.setErr(err)
+			return false
+		}
+	}
+
+	if i.Retention != nil {
+		if err := i.Retention.Handled(ctx, i.m, s); err != nil {
+			i.setErr(err)
+			return false
+		}
+	}
+
+	return true
+}
+
+// setErr sets the Inbox's error.
+func (i *Inbox) setErr(err error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.err = err
+}
+
+// waitReceived waits until all of the parts of a received SMS arrive, and
+// returns the complete SMS.
+func (c *Client) waitReceived(ctx context.Context, s *SMS) (*SMS, error) {
+	if s.State != SMSStateReceiving {
+		return s, nil
+	}
+
+	op := objectPath("SMS", strconv.Itoa(s.Index))
+
+	// Watch for state changes before fetching the SMS again so that the final
+	// state change is not missed.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	changes, err := c.watchProperties(ctx, op, interfacePath("Sms"))
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		s, err := c.sms(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+		if s.State != SMSStateReceiving {
+			return s, nil
+		}
+
+		if err := waitSMSState(ctx, changes); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// waitSMSState waits for a change to an SMS's State property.
+func waitSMSState(ctx context.Context, changes <-chan map[string]dbus.Variant) error {
+	for {
+		select {
+		case ps, ok := <-changes:
+			if !ok {
+				return errors.New("SMS properties watch closed")
+			}
+			if _, ok := ps["State"]; ok {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestInboxMessages(t *testing.T) {
+	store := &memoryStore{seen: map[string]bool{"old": true}}
+	in := NewInbox(testInboxModem(t), store)
+
+	msgs, err := in.Messages(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get messages: %v", err)
+	}
+
+	var got []string
+	for s := range msgs {
+		got = append(got, s.Text)
+	}
+
+	if err := in.Err(); err != nil {
+		t.Fatalf("failed to deliver messages: %v", err)
+	}
+
+	// The previously stored message, the sent message, and the locally created
+	// message are skipped. The concatenated message is delivered once
+	// complete.
+	want := []string{"hello", "hello, world!"}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected messages (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]string{"hello", "hello, world!"}, store.stored); diff != "" {
+		t.Fatalf("unexpected stored messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestInboxMessagesStoreError(t *testing.T) {
+	errStore := errors.New("store failed")
+	in := NewInbox(testInboxModem(t), &memoryStore{err: errStore})
+
+	msgs, err := in.Messages(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get messages: %v", err)
+	}
+
+	var n int
+	for range msgs {
+		n++
+	}
+
+	// The first message is delivered before it fails to be stored.
+	if diff := cmp.Diff(1, n); diff != "" {
+		t.Fatalf("unexpected number of messages (-want +got):\n%s", diff)
+	}
+
+	if !errors.Is(in.Err(), errStore) {
+		t.Fatalf("expected store error, but got: %v", in.Err())
+	}
+}
+
+func TestInboxMessagesReceiving(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sms := func(state SMSState, text string) map[string]dbus.Variant {
+		return map[string]dbus.Variant{
+			"State": dbus.MakeVariant(uint32(state)),
+			"Text":  dbus.MakeVariant(text),
+		}
+	}
+
+	var (
+		mu      sync.Mutex
+		fetches int
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, _ string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				return dbus.Store([]interface{}{[]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/SMS/0",
+				}}, out)
+			},
+			watch: func(ctx context.Context, op dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+				switch {
+				case member == "Added":
+					// A message which never completes is added before a
+					// complete one.
+					return testSignals(ctx,
+						&dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/1"), true}},
+						&dbus.Signal{Body: []interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/2"), true}},
+					), nil
+				case op == "/org/freedesktop/ModemManager1/SMS/0":
+					return testSignals(ctx, &dbus.Signal{Body: []interface{}{
+						"org.freedesktop.ModemManager1.Sms",
+						map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(SMSStateReceived))},
+						[]string{},
+					}}), nil
+				default:
+					return testSignals(ctx), nil
+				}
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				switch op {
+				case "/org/freedesktop/ModemManager1/SMS/0":
+					// Still being received when listed at startup, and then
+					// complete once its state changes.
+					mu.Lock()
+					defer mu.Unlock()
+
+					fetches++
+					if fetches < 3 {
+						return sms(SMSStateReceiving, "hello, "), nil
+					}
+
+					return sms(SMSStateReceived, "hello, world!"), nil
+				case "/org/freedesktop/ModemManager1/SMS/1":
+					return sms(SMSStateReceiving, "never"), nil
+				case "/org/freedesktop/ModemManager1/SMS/2":
+					return sms(SMSStateReceived, "hello"), nil
+				default:
+					t.Fatalf("unexpected object path: %q", op)
+					return nil, nil
+				}
+			},
+		},
+	}
+
+	msgs, err := NewInbox(m, nil).Messages(ctx)
+	if err != nil {
+		t.Fatalf("failed to get messages: %v", err)
+	}
+
+	// The incomplete message must not hold up the others.
+	var got []string
+	for len(got) < 2 {
+		s, ok := <-msgs
+		if !ok {
+			t.Fatalf("messages closed early: %v", got)
+		}
+
+		got = append(got, s.Text)
+	}
+
+	cancel()
+	for s := range msgs {
+		t.Fatalf("unexpected message: %q", s.Text)
+	}
+
+	want := []string{"hello", "hello, world!"}
+	if diff := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Fatalf("unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+// testInboxModem returns a Modem with canned messages for Inbox tests.
+func testInboxModem(t *testing.T) *Modem {
+	t.Helper()
+
+	var fetches int
+	return &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging.List", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				return dbus.Store([]interface{}{[]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/SMS/0",
+					"/org/freedesktop/ModemManager1/SMS/1",
+					"/org/freedesktop/ModemManager1/SMS/2",
+				}}, out)
+			},
+			watch: func(_ context.Context, op dbus.ObjectPath, _, member string) (<-chan *dbus.Signal, error) {
+				sigs := make(chan *dbus.Signal, 3)
+				defer close(sigs)
+
+				switch member {
+				case "Added":
+					// The existing message is added again, followed by a
+					// concatenated message which is still being received and
+					// a locally created message.
+					for _, s := range []struct {
+						op       dbus.ObjectPath
+						received bool
+					}{
+						{op: "/org/freedesktop/ModemManager1/SMS/2", received: true},
+						{op: "/org/freedesktop/ModemManager1/SMS/3", received: true},
+						{op: "/org/freedesktop/ModemManager1/SMS/4", received: false},
+					} {
+						sigs <- &dbus.Signal{Body: []interface{}{s.op, s.received}}
+					}
+				case "PropertiesChanged":
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/3"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					sigs <- &dbus.Signal{Body: []interface{}{
+						"org.freedesktop.ModemManager1.Sms",
+						map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(SMSStateReceived))},
+						[]string{},
+					}}
+				}
+
+				return sigs, nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				sms := func(state SMSState, text string) map[string]dbus.Variant {
+					return map[string]dbus.Variant{
+						"State": dbus.MakeVariant(uint32(state)),
+						"Text":  dbus.MakeVariant(text),
+					}
+				}
+
+				switch op {
+				case "/org/freedesktop/ModemManager1/SMS/0":
+					return sms(SMSStateReceived, "old"), nil
+				case "/org/freedesktop/ModemManager1/SMS/1":
+					return sms(SMSStateSent, "sent"), nil
+				case "/org/freedesktop/ModemManager1/SMS/2":
+					return sms(SMSStateReceived, "hello"), nil
+				case "/org/freedesktop/ModemManager1/SMS/3":
+					// Fetched once when added, once after the watch begins,
+					// and once more when complete.
+					fetches++
+					if fetches < 3 {
+						return sms(SMSStateReceiving, "hello, "), nil
+					}
+
+					return sms(SMSStateReceived, "hello, world!"), nil
+				case "/org/freedesktop/ModemManager1/SMS/4":
+					return sms(SMSStateStored, "local"), nil
+				default:
+					t.Fatalf("unexpected object path: %q", op)
+					return nil, nil
+				}
+			},
+		},
+	}
+}
+
+// A memoryStore is an in-memory MessageStore keyed by SMS text.
+type memoryStore struct {
+	seen   map[string]bool
+	stored []string
+	err    error
+}
+
+func (s *memoryStore) Seen(_ context.Context, sms *SMS) (bool, error) {
+	return s.seen[sms.Text], nil
+}
+
+func (s *memoryStore) Store(_ context.Context, sms *SMS) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.stored = append(s.stored, sms.Text)
+	return nil
+}
@@ -0,0 +1,224 @@
+package modemmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+)
+
+// Modem, Bearer, Signal, SMS, and their related types may be encoded to and
+// decoded from JSON using stable, lower-case field names. Enums are encoded
+// using their Name, time.Duration values are encoded as strings such as
+// "1m30s", and IP networks are encoded in CIDR notation such as
+// "192.0.2.1/24". Warnings and passwords are never encoded.
+
+// A jsonDuration is a time.Duration encoded as a string such as "1m30s".
+type jsonDuration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d jsonDuration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *jsonDuration) UnmarshalText(text []byte) error {
+	dur, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %v", text, err)
+	}
+
+	*d = jsonDuration(dur)
+	return nil
+}
+
+// A jsonIPNet is a net.IPNet encoded in CIDR notation. Unlike net.ParseCIDR,
+// the address is not masked so that the host address is preserved.
+type jsonIPNet net.IPNet
+
+// MarshalText implements encoding.TextMarshaler.
+func (n jsonIPNet) MarshalText() ([]byte, error) {
+	ipn := net.IPNet(n)
+	return []byte(ipn.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (n *jsonIPNet) UnmarshalText(text []byte) error {
+	ip, ipn, err := net.ParseCIDR(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid IP network %q: %v", text, err)
+	}
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+
+	*n = jsonIPNet{IP: ip, Mask: ipn.Mask}
+	return nil
+}
+
+// Each type with fields that need a custom encoding defines a local copy of
+// itself without its methods, and embeds it so that the remaining fields are
+// encoded as usual. The outer fields take precedence over the embedded fields
+// with the same JSON name, and start with the existing values so that missing
+// fields are left unchanged when decoding.
+
+// MarshalJSON implements json.Marshaler.
+func (b Bearer) MarshalJSON() ([]byte, error) {
+	type bearer Bearer
+	return json.Marshal(struct {
+		*bearer
+		IPTimeout jsonDuration `json:"ip_timeout"`
+	}{
+		bearer:    (*bearer)(&b),
+		IPTimeout: jsonDuration(b.IPTimeout),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (b *Bearer) UnmarshalJSON(buf []byte) error {
+	type bearer Bearer
+	v := struct {
+		*bearer
+		IPTimeout jsonDuration `json:"ip_timeout"`
+	}{
+		bearer:    (*bearer)(b),
+		IPTimeout: jsonDuration(b.IPTimeout),
+	}
+
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return err
+	}
+
+	b.IPTimeout = time.Duration(v.IPTimeout)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (c IPConfig) MarshalJSON() ([]byte, error) {
+	type ipConfig IPConfig
+	return json.Marshal(struct {
+		*ipConfig
+		Address *jsonIPNet `json:"address"`
+	}{
+		ipConfig: (*ipConfig)(&c),
+		Address:  (*jsonIPNet)(c.Address),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (c *IPConfig) UnmarshalJSON(buf []byte) error {
+	type ipConfig IPConfig
+	v := struct {
+		*ipConfig
+		Address *jsonIPNet `json:"address"`
+	}{
+		ipConfig: (*ipConfig)(c),
+		Address:  (*jsonIPNet)(c.Address),
+	}
+
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return err
+	}
+
+	c.Address = (*net.IPNet)(v.Address)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s BearerStats) MarshalJSON() ([]byte, error) {
+	type bearerStats BearerStats
+	return json.Marshal(struct {
+		*bearerStats
+		Duration      jsonDuration `json:"duration"`
+		TotalDuration jsonDuration `json:"total_duration"`
+	}{
+		bearerStats:   (*bearerStats)(&s),
+		Duration:      jsonDuration(s.Duration),
+		TotalDuration: jsonDuration(s.TotalDuration),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *BearerStats) UnmarshalJSON(buf []byte) error {
+	type bearerStats BearerStats
+	v := struct {
+		*bearerStats
+		Duration      jsonDuration `json:"duration"`
+		TotalDuration jsonDuration `json:"total_duration"`
+	}{
+		bearerStats:   (*bearerStats)(s),
+		Duration:      jsonDuration(s.Duration),
+		TotalDuration: jsonDuration(s.TotalDuration),
+	}
+
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return err
+	}
+
+	s.Duration = time.Duration(v.Duration)
+	s.TotalDuration = time.Duration(v.TotalDuration)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s Signal) MarshalJSON() ([]byte, error) {
+	type signal Signal
+	return json.Marshal(struct {
+		*signal
+		Rate jsonDuration `json:"rate"`
+	}{
+		signal: (*signal)(&s),
+		Rate:   jsonDuration(s.Rate),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *Signal) UnmarshalJSON(buf []byte) error {
+	type signal Signal
+	v := struct {
+		*signal
+		Rate jsonDuration `json:"rate"`
+	}{
+		signal: (*signal)(s),
+		Rate:   jsonDuration(s.Rate),
+	}
+
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return err
+	}
+
+	s.Rate = time.Duration(v.Rate)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s SMS) MarshalJSON() ([]byte, error) {
+	type sms SMS
+	return json.Marshal(struct {
+		*sms
+		Validity jsonDuration `json:"validity"`
+	}{
+		sms:      (*sms)(&s),
+		Validity: jsonDuration(s.Validity),
+	})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (s *SMS) UnmarshalJSON(buf []byte) error {
+	type sms SMS
+	v := struct {
+		*sms
+		Validity jsonDuration `json:"validity"`
+	}{
+		sms:      (*sms)(s),
+		Validity: jsonDuration(s.Validity),
+	}
+
+	if err := json.Unmarshal(buf, &v); err != nil {
+		return err
+	}
+
+	s.Validity = time.Duration(v.Validity)
+	return nil
+}
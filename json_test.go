@@ -0,0 +1,250 @@
+package modemmanager
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestJSONBearer(t *testing.T) {
+	b := &Bearer{
+		Index:     1,
+		Connected: true,
+		Interface: "wwan0",
+		IPTimeout: 20 * time.Second,
+		IPv4Config: &IPConfig{
+			Address: &net.IPNet{
+				IP:   net.IPv4(192, 0, 2, 1).To4(),
+				Mask: net.CIDRMask(24, 32),
+			},
+			DNS:     []net.IP{net.IPv4(192, 0, 2, 53).To4()},
+			Gateway: net.IPv4(192, 0, 2, 254).To4(),
+			Method:  BearerIPMethodStatic,
+			MTU:     1500,
+		},
+		Properties: &BearerProperties{
+			APN:      "internet",
+			IPType:   BearerIPFamilyIPv4 | BearerIPFamilyIPv6,
+			Password: "secret",
+		},
+		Stats: &BearerStats{
+			Duration: 90 * time.Second,
+			RXBytes:  1024,
+		},
+		Type:     BearerTypeDefault,
+		Warnings: []error{errors.New("warning")},
+	}
+
+	buf, err := json.Marshal(b)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(buf, &got); err != nil {
+		t.Fatalf("failed to unmarshal to map: %v", err)
+	}
+
+	// Spot check the fields which use custom encodings. IPv6Config is nil and
+	// Warnings and the password are never encoded.
+	want := map[string]interface{}{
+		"ip_timeout": "20s",
+		"type":       "default",
+		"ipv4_config": map[string]interface{}{
+			"address": "192.0.2.1/24",
+			"dns":     []interface{}{"192.0.2.53"},
+			"gateway": "192.0.2.254",
+			"method":  "static",
+			"mtu":     float64(1500),
+		},
+		"properties": map[string]interface{}{
+			"apn":          "internet",
+			"allowed_auth": "unknown",
+			"ip_type":      "ipv4, ipv6",
+			"user":         "",
+		},
+	}
+
+	for k, v := range want {
+		if diff := cmp.Diff(v, got[k]); diff != "" {
+			t.Fatalf("unexpected %q (-want +got):\n%s", k, diff)
+		}
+	}
+
+	for _, k := range []string{"ipv6_config", "warnings", "Warnings"} {
+		if _, ok := got[k]; ok {
+			t.Fatalf("unexpected field %q in JSON: %s", k, buf)
+		}
+	}
+
+	if diff := cmp.Diff("1m30s", got["stats"].(map[string]interface{})["duration"]); diff != "" {
+		t.Fatalf("unexpected stats duration (-want +got):\n%s", diff)
+	}
+
+	var rt Bearer
+	if err := json.Unmarshal(buf, &rt); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	// Only the password and warnings are lost in the round trip.
+	want2 := *b
+	want2.Warnings = nil
+	props := *b.Properties
+	props.Password = ""
+	want2.Properties = &props
+
+	if diff := cmp.Diff(want2, rt, cmpopts.IgnoreUnexported(Bearer{})); diff != "" {
+		t.Fatalf("unexpected Bearer (-want +got):\n%s", diff)
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	var (
+		taken = time.Date(2022, time.January, 1, 12, 0, 0, 0, time.UTC)
+		roam  = false
+	)
+
+	tests := []struct {
+		name string
+		v    interface{}
+		new  func() interface{}
+	}{
+		{
+			name: "Modem",
+			v: &Modem{
+				Index:              0,
+				AccessTechnologies: AccessTechnologyLTE | AccessTechnologyUMTS,
+				Manufacturer:       "Quectel",
+				Ports: []Port{
+					{Name: "cdc-wdm0", Type: PortTypeQMI},
+					{Name: "wwan0", Type: PortTypeNet},
+				},
+				PowerState:     PowerStateOn,
+				State:          StateConnected,
+				UnlockRequired: LockNone,
+				UnlockRetries: map[Lock]int{
+					LockSIMPIN: 3,
+					LockSIMPUK: 10,
+				},
+			},
+			new: func() interface{} { return &Modem{} },
+		},
+		{
+			name: "Bearer",
+			v: &Bearer{
+				IPTimeout: 20 * time.Second,
+				IPv6Config: &IPConfig{
+					Address: &net.IPNet{
+						IP:   net.ParseIP("2001:db8::1"),
+						Mask: net.CIDRMask(64, 128),
+					},
+					Method: BearerIPMethodDHCP,
+				},
+				Properties: &BearerProperties{
+					AllowRoaming: &roam,
+					AllowedAuth:  BearerAllowedAuthPAP | BearerAllowedAuthCHAP,
+				},
+				Stats: &BearerStats{
+					Duration:      time.Minute,
+					TotalDuration: time.Hour,
+					StartDate:     taken,
+				},
+			},
+			new: func() interface{} { return &Bearer{} },
+		},
+		{
+			name: "Signal",
+			v: &Signal{
+				Rate:  10 * time.Second,
+				Taken: taken,
+				LTE: &LTESignal{
+					RSRP: -95.5,
+					RSRQ: -11,
+					RSSI: -65,
+					SNR:  12.5,
+				},
+			},
+			new: func() interface{} { return &Signal{} },
+		},
+		{
+			name: "SMS",
+			v: &SMS{
+				Data:          []byte{0x01, 0x02},
+				DeliveryState: SMSDeliveryStateCompletedReceived,
+				Number:        "+15555551234",
+				PDUType:       SMSPDUTypeDeliver,
+				State:         SMSStateReceived,
+				Storage:       SMSStorageME,
+				Text:          "hello",
+				Timestamp:     taken,
+				Validity:      24 * time.Hour,
+				ValidityType:  SMSValidityTypeRelative,
+			},
+			new: func() interface{} { return &SMS{} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := json.Marshal(tt.v)
+			if err != nil {
+				t.Fatalf("failed to marshal: %v", err)
+			}
+
+			got := tt.new()
+			if err := json.Unmarshal(buf, got); err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreUnexported(Modem{}, Bearer{}, SMS{}),
+			}
+
+			if diff := cmp.Diff(tt.v, got, opts...); diff != "" {
+				t.Fatalf("unexpected value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestJSONUnmarshalInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		v    interface{}
+	}{
+		{
+			name: "duration",
+			s:    `{"ip_timeout":"forever"}`,
+			v:    &Bearer{},
+		},
+		{
+			name: "duration number",
+			s:    `{"rate":10}`,
+			v:    &Signal{},
+		},
+		{
+			name: "IP network",
+			s:    `{"address":"192.0.2.1"}`,
+			v:    &IPConfig{},
+		},
+		{
+			name: "enum",
+			s:    `{"state":"bogus"}`,
+			v:    &Modem{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := json.Unmarshal([]byte(tt.s), tt.v); err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+		})
+	}
+}
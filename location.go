@@ -0,0 +1,369 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A LocationSource is a bitmask of sources a Modem may use to determine its
+// location.
+type LocationSource uint32
+
+// Possible LocationSource values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemLocationSource.
+const (
+	LocationSourceNone      LocationSource = 0
+	LocationSource3GPPLACCI LocationSource = 1 << (iota - 1)
+	LocationSourceGPSRaw
+	LocationSourceGPSNMEA
+	LocationSourceCDMABS
+	LocationSourceGPSUnmanaged
+	LocationSourceAGPSMSA
+	LocationSourceAGPSMSB
+)
+
+// An AssistanceDataType is a bitmask of assistance data formats a Modem can
+// use to speed up acquiring a GPS fix.
+type AssistanceDataType uint32
+
+// Possible AssistanceDataType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemLocationAssistanceDataType.
+const (
+	AssistanceDataTypeNone AssistanceDataType = 0
+	AssistanceDataTypeXTRA AssistanceDataType = 1 << 0
+)
+
+// LocationInfo contains the location configuration of a Modem.
+type LocationInfo struct {
+	// AssistanceDataServers are the URLs from which assistance data in the
+	// SupportedAssistanceData formats may be downloaded and injected into
+	// the Modem.
+	AssistanceDataServers   []*url.URL
+	SupportedAssistanceData AssistanceDataType
+
+	// Capabilities are the LocationSources supported by the Modem, and
+	// Enabled are the LocationSources configured by LocationSetup.
+	Capabilities, Enabled LocationSource
+
+	// SignalsLocation reports whether location updates are signaled.
+	SignalsLocation bool
+}
+
+// A Location is the location of a Modem, as reported by each of its enabled
+// LocationSources. Fields are nil when the corresponding source is not
+// enabled or has not yet produced a location.
+type Location struct {
+	GPS      *GPSLocation
+	NMEA     []string
+	ThreeGPP *Location3GPP
+}
+
+// A GPSLocation is a location reported by the LocationSourceGPSRaw source.
+type GPSLocation struct {
+	// Time is the UTC time of the GPS fix.
+	Time time.Time
+
+	// Latitude and Longitude are in degrees, and Altitude is in meters above
+	// mean sea level.
+	Latitude, Longitude, Altitude float64
+}
+
+// A Location3GPP is a cell-based location reported by the
+// LocationSource3GPPLACCI source.
+type Location3GPP struct {
+	// MCC and MNC identify the network operator, such as "310" and "410".
+	MCC, MNC string
+
+	// LAC is the Location Area Code, TAC is the Tracking Area Code, and CID
+	// is the Cell ID of the serving cell. LAC is 0 on networks which only
+	// report a TAC, and vice versa.
+	LAC, TAC, CID uint32
+}
+
+// LocationInfo fetches the location configuration of the Modem.
+func (m *Modem) LocationInfo(ctx context.Context) (*LocationInfo, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Location"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var li LocationInfo
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "AssistanceDataServers":
+			for _, s := range vp.Strings() {
+				u, err := url.Parse(s)
+				if err != nil {
+					return nil, fmt.Errorf("error parsing assistance data server: %v", err)
+				}
+				li.AssistanceDataServers = append(li.AssistanceDataServers, u)
+			}
+		case "Capabilities":
+			li.Capabilities = LocationSource(vp.Int())
+		case "Enabled":
+			li.Enabled = LocationSource(vp.Int())
+		case "SignalsLocation":
+			li.SignalsLocation = vp.Bool()
+		case "SupportedAssistanceData":
+			li.SupportedAssistanceData = AssistanceDataType(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &li, nil
+}
+
+// LocationSetup configures the LocationSources the Modem uses to determine
+// its location. If signalLocation is true, the Modem's Location property is
+// updated as new locations are gathered, which allows location changes to be
+// watched without calling GetLocation.
+func (m *Modem) LocationSetup(ctx context.Context, sources LocationSource, signalLocation bool) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Location", "Setup"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		uint32(sources),
+		signalLocation,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// Location fetches the current Location of the Modem from each of its enabled
+// LocationSources.
+func (m *Modem) Location(ctx context.Context) (*Location, error) {
+	var ls map[uint32]dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Location", "GetLocation"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&ls,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	return parseLocation(ls, time.Now())
+}
+
+// WatchLocation enables signaled location updates for the Modem's currently
+// enabled LocationSources and watches for changes to its Location. Each
+// Location is delivered on the returned channel, which is closed when the
+// context is canceled.
+//
+// Signaled location updates remain enabled after the context is canceled, as
+// other clients may also rely on them. Use LocationSetup to disable them if
+// necessary.
+func (m *Modem) WatchLocation(ctx context.Context) (_ <-chan *Location, err error) {
+	op := objectPath("Modem", strconv.Itoa(m.Index))
+	iface := interfacePath("Modem", "Location")
+
+	// Stop watching if any of the remaining setup fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	// Begin watching before enabling signals so no updates are missed.
+	changes, err := m.c.watchProperties(ctx, op, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := m.c.get(ctx, op, iface, "Enabled")
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	enabled := vp.Int()
+	if err = vp.Err(); err != nil {
+		return nil, fmt.Errorf("error parsing enabled location sources: %v", err)
+	}
+
+	if err = m.LocationSetup(ctx, LocationSource(enabled), true); err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, changes, func(ps map[string]dbus.Variant) (*Location, error) {
+		v, ok := ps["Location"]
+		if !ok {
+			return nil, errors.New("location did not change")
+		}
+
+		vp := newValueParser(v)
+		ls := vp.Locations()
+		if err := vp.Err(); err != nil {
+			return nil, err
+		}
+
+		return parseLocation(ls, time.Now())
+	}), nil
+}
+
+// parseLocation parses a Location from a map of LocationSources to their
+// location values. Unknown sources are ignored. now is used to determine the
+// date of GPS fixes, which only report the time of day.
+func parseLocation(ls map[uint32]dbus.Variant, now time.Time) (*Location, error) {
+	var l Location
+	for k, v := range ls {
+		vp := newValueParser(v)
+		switch LocationSource(k) {
+		case LocationSourceGPSRaw:
+			g, err := parseGPSLocation(vp.Properties(), now)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing GPS location: %v", err)
+			}
+			l.GPS = g
+		case LocationSourceGPSNMEA:
+			l.NMEA = splitNMEA(vp.String())
+		case LocationSource3GPPLACCI:
+			g, err := parseLocation3GPP(vp.String())
+			if err != nil {
+				return nil, fmt.Errorf("error parsing 3GPP location: %v", err)
+			}
+			l.ThreeGPP = g
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing location source %d: %v", k, err)
+		}
+	}
+
+	return &l, nil
+}
+
+// splitNMEA splits a block of NMEA sentences separated by line breaks into
+// individual sentences.
+func splitNMEA(s string) []string {
+	var ss []string
+	for _, line := range strings.Split(s, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			ss = append(ss, line)
+		}
+	}
+
+	return ss
+}
+
+// parseLocation3GPP parses a Location3GPP from a comma-separated string of
+// the form "MCC,MNC,LAC,CID,TAC", where LAC, CID, and TAC are hexadecimal.
+// Older versions of ModemManager omit the TAC.
+func parseLocation3GPP(s string) (*Location3GPP, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	ss := strings.Split(s, ",")
+	if len(ss) != 4 && len(ss) != 5 {
+		return nil, fmt.Errorf("invalid 3GPP location: %q", s)
+	}
+
+	// Parse each of the hexadecimal fields, treating empty fields as 0.
+	hex := make([]uint32, 3)
+	for i, h := range ss[2:] {
+		if h == "" {
+			continue
+		}
+
+		v, err := strconv.ParseUint(h, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 3GPP location hexadecimal field: %q", h)
+		}
+
+		hex[i] = uint32(v)
+	}
+
+	return &Location3GPP{
+		MCC: ss[0],
+		MNC: ss[1],
+		LAC: hex[0],
+		CID: hex[1],
+		TAC: hex[2],
+	}, nil
+}
+
+// parseGPSLocation parses a GPSLocation from a properties map. The UTC time of
+// day reported by the GPS is combined with the date of now, accounting for a
+// fix taken just before midnight being reported just after it.
+func parseGPSLocation(ps map[string]dbus.Variant, now time.Time) (*GPSLocation, error) {
+	if len(ps) == 0 {
+		return nil, nil
+	}
+
+	var g GPSLocation
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "utc-time":
+			t, err := parseGPSTime(vp.String(), now)
+			if err != nil {
+				return nil, err
+			}
+			g.Time = t
+		case "latitude":
+			g.Latitude = vp.Float64()
+		case "longitude":
+			g.Longitude = vp.Float64()
+		case "altitude":
+			g.Altitude = vp.Float64()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &g, nil
+}
+
+// parseGPSTime parses an NMEA "hhmmss.ss" UTC time of day using the date of
+// now. An empty string is parsed as the zero time.
+func parseGPSTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	// Fractional seconds are accepted by time.Parse even though they are not
+	// present in the layout.
+	tod, err := time.Parse("150405", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid GPS UTC time: %q", s)
+	}
+
+	now = now.UTC()
+	t := time.Date(
+		now.Year(), now.Month(), now.Day(),
+		tod.Hour(), tod.Minute(), tod.Second(), tod.Nanosecond(),
+		time.UTC,
+	)
+
+	// A fix cannot be from the future, so a time of day far ahead of now must
+	// have been taken on the previous day.
+	if t.Sub(now) > 12*time.Hour {
+		t = t.AddDate(0, 0, -1)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,392 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemLocationSetup(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Location.Setup", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			want := []interface{}{uint32(LocationSource3GPPLACCI | LocationSourceGPSRaw), true}
+			if diff := cmp.Diff(want, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: unauthorizedError}
+		}},
+	}
+
+	err := m.LocationSetup(context.Background(), LocationSource3GPPLACCI|LocationSourceGPSRaw, true)
+	if !errors.Is(err, os.ErrPermission) {
+		t.Fatalf("expected permission error, but got: %v", err)
+	}
+}
+
+func TestModemLocation(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Location.GetLocation", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{map[uint32]dbus.Variant{
+				uint32(LocationSource3GPPLACCI): dbus.MakeVariant("310,410,FFFE,0A2B3C4D,1A2B"),
+				uint32(LocationSourceGPSNMEA): dbus.MakeVariant(
+					"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47\r\n" +
+						"$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39\r\n",
+				),
+				uint32(LocationSourceGPSRaw): dbus.MakeVariant(map[string]dbus.Variant{
+					"utc-time":  dbus.MakeVariant(""),
+					"latitude":  dbus.MakeVariant(38.8977),
+					"longitude": dbus.MakeVariant(-77.0365),
+					"altitude":  dbus.MakeVariant(18.0),
+				}),
+				// Unknown sources are ignored.
+				1 << 31: dbus.MakeVariant(1),
+			}}, out)
+		}},
+	}
+
+	l, err := m.Location(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get location: %v", err)
+	}
+
+	want := &Location{
+		GPS: &GPSLocation{
+			Latitude:  38.8977,
+			Longitude: -77.0365,
+			Altitude:  18.0,
+		},
+		NMEA: []string{
+			"$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47",
+			"$GPGSA,A,3,04,05,,09,12,,,24,,,,,2.5,1.3,2.1*39",
+		},
+		ThreeGPP: &Location3GPP{
+			MCC: "310",
+			MNC: "410",
+			LAC: 0xfffe,
+			TAC: 0x1a2b,
+			CID: 0x0a2b3c4d,
+		},
+	}
+
+	if diff := cmp.Diff(want, l); diff != "" {
+		t.Fatalf("unexpected Location (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseLocation3GPP(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		l    *Location3GPP
+		ok   bool
+	}{
+		{
+			name: "empty",
+			ok:   true,
+		},
+		{
+			name: "bad fields",
+			s:    "310,410",
+		},
+		{
+			name: "bad hex",
+			s:    "310,410,zz,1,1",
+		},
+		{
+			name: "no TAC",
+			s:    "310,410,FFFE,1A",
+			l: &Location3GPP{
+				MCC: "310",
+				MNC: "410",
+				LAC: 0xfffe,
+				CID: 0x1a,
+			},
+			ok: true,
+		},
+		{
+			name: "LTE",
+			s:    "310,260,,0A2B3C4D,1A2B",
+			l: &Location3GPP{
+				MCC: "310",
+				MNC: "260",
+				TAC: 0x1a2b,
+				CID: 0x0a2b3c4d,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := parseLocation3GPP(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse location: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.l, l); diff != "" {
+				t.Fatalf("unexpected Location3GPP (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func Test_parseGPSTime(t *testing.T) {
+	now := time.Date(2023, time.March, 1, 0, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		s    string
+		t    time.Time
+		ok   bool
+	}{
+		{
+			name: "empty",
+			ok:   true,
+		},
+		{
+			name: "invalid",
+			s:    "foo",
+		},
+		{
+			name: "same day",
+			s:    "000412",
+			t:    time.Date(2023, time.March, 1, 0, 4, 12, 0, time.UTC),
+			ok:   true,
+		},
+		{
+			name: "fractional",
+			s:    "000412.50",
+			t:    time.Date(2023, time.March, 1, 0, 4, 12, 500*int(time.Millisecond), time.UTC),
+			ok:   true,
+		},
+		{
+			name: "previous day",
+			s:    "235958.00",
+			t:    time.Date(2023, time.February, 28, 23, 59, 58, 0, time.UTC),
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGPSTime(tt.s, now)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse time: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				return
+			}
+
+			if !tt.t.Equal(got) {
+				t.Fatalf("unexpected time:\nwant: %v\n got: %v", tt.t, got)
+			}
+		})
+	}
+}
+
+func TestModemWatchLocation(t *testing.T) {
+	const iface = "org.freedesktop.ModemManager1.Modem.Location"
+
+	m := &Modem{
+		c: &Client{
+			get: func(_ context.Context, op dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+				if diff := cmp.Diff(iface, dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("Enabled", prop); diff != "" {
+					t.Fatalf("unexpected property (-want +got):\n%s", diff)
+				}
+
+				return dbus.MakeVariant(uint32(LocationSource3GPPLACCI)), nil
+			},
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+				if diff := cmp.Diff(iface+".Setup", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				want := []interface{}{uint32(LocationSource3GPPLACCI), true}
+				if diff := cmp.Diff(want, args); diff != "" {
+					t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+				}
+
+				return nil
+			},
+			watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				// An unrelated property changes, a malformed location is
+				// reported, and then the serving cell changes.
+				sigs := make(chan *dbus.Signal, 3)
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"SignalsLocation": dbus.MakeVariant(true)},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"Location": dbus.MakeVariant("foo")},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"Location": dbus.MakeVariant(map[uint32]dbus.Variant{
+						uint32(LocationSource3GPPLACCI): dbus.MakeVariant("310,410,,0A2B3C4D,1A2B"),
+					})},
+					[]string{},
+				}}
+				close(sigs)
+
+				return sigs, nil
+			},
+		},
+	}
+
+	locs, err := m.WatchLocation(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch location: %v", err)
+	}
+
+	var got []*Location
+	for l := range locs {
+		got = append(got, l)
+	}
+
+	want := []*Location{{
+		ThreeGPP: &Location3GPP{
+			MCC: "310",
+			MNC: "410",
+			TAC: 0x1a2b,
+			CID: 0x0a2b3c4d,
+		},
+	}}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected Locations (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemLocationInfo(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Location", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"AssistanceDataServers": dbus.MakeVariant([]string{
+					"https://xtrapath1.izatcloud.net/xtra3grc.bin",
+					"https://xtrapath2.izatcloud.net/xtra3grc.bin",
+				}),
+				"Capabilities": dbus.MakeVariant(uint32(
+					LocationSource3GPPLACCI | LocationSourceGPSRaw | LocationSourceGPSNMEA | LocationSourceAGPSMSB,
+				)),
+				"Enabled":                 dbus.MakeVariant(uint32(LocationSource3GPPLACCI)),
+				"SignalsLocation":         dbus.MakeVariant(true),
+				"SupportedAssistanceData": dbus.MakeVariant(uint32(AssistanceDataTypeXTRA)),
+			}, nil
+		}},
+	}
+
+	li, err := m.LocationInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get location info: %v", err)
+	}
+
+	want := &LocationInfo{
+		SupportedAssistanceData: AssistanceDataTypeXTRA,
+		Capabilities:            LocationSource3GPPLACCI | LocationSourceGPSRaw | LocationSourceGPSNMEA | LocationSourceAGPSMSB,
+		Enabled:                 LocationSource3GPPLACCI,
+		SignalsLocation:         true,
+	}
+
+	if diff := cmp.Diff(want, li, cmpopts.IgnoreFields(LocationInfo{}, "AssistanceDataServers")); diff != "" {
+		t.Fatalf("unexpected LocationInfo (-want +got):\n%s", diff)
+	}
+
+	var servers []string
+	for _, u := range li.AssistanceDataServers {
+		servers = append(servers, u.String())
+	}
+
+	wantServers := []string{
+		"https://xtrapath1.izatcloud.net/xtra3grc.bin",
+		"https://xtrapath2.izatcloud.net/xtra3grc.bin",
+	}
+
+	if diff := cmp.Diff(wantServers, servers); diff != "" {
+		t.Fatalf("unexpected assistance data servers (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchLocationSetupError(t *testing.T) {
+	done := make(chan struct{})
+	m := &Modem{
+		c: &Client{
+			get: func(_ context.Context, _ dbus.ObjectPath, _, _ string) (dbus.Variant, error) {
+				return dbus.Variant{}, errors.New("get failed")
+			},
+			watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				// The watch must be stopped when setup fails.
+				sigs := make(chan *dbus.Signal)
+				go func() {
+					<-ctx.Done()
+					close(sigs)
+					close(done)
+				}()
+
+				return sigs, nil
+			},
+		},
+	}
+
+	if _, err := m.WatchLocation(context.Background()); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch was not stopped")
+	}
+}
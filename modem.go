@@ -15,26 +15,90 @@ import (
 // denied by D-Bus, an error compatible with 'errors.Is(err, os.ErrPermission)'
 // is returned when methods are called.
 type Modem struct {
-	Index                        int
-	CarrierConfiguration         string
-	CarrierConfigurationRevision string
-	Device                       string
-	DeviceIdentifier             string
-	EquipmentIdentifier          string
-	HardwareRevision             string
-	Manufacturer                 string
-	Model                        string
-	Plugin                       string
-	Ports                        []Port
-	PowerState                   PowerState
-	PrimaryPort                  string
-	Revision                     string
-	State                        State
-
-	c       *Client
-	bearers []dbus.ObjectPath
+	Index                        int              `json:"index"`
+	AccessTechnologies           AccessTechnology `json:"access_technologies"`
+	CarrierConfiguration         string           `json:"carrier_configuration"`
+	CarrierConfigurationRevision string           `json:"carrier_configuration_revision"`
+	Device                       string           `json:"device"`
+	DeviceIdentifier             string           `json:"device_identifier"`
+	EquipmentIdentifier          string           `json:"equipment_identifier"`
+	HardwareRevision             string           `json:"hardware_revision"`
+	Manufacturer                 string           `json:"manufacturer"`
+	Model                        string           `json:"model"`
+	Plugin                       string           `json:"plugin"`
+	Ports                        []Port           `json:"ports"`
+	PowerState                   PowerState       `json:"power_state"`
+	PrimaryPort                  string           `json:"primary_port"`
+	PrimarySIMSlot               int              `json:"primary_sim_slot"`
+	Revision                     string           `json:"revision"`
+	State                        State            `json:"state"`
+	UnlockRequired               Lock             `json:"unlock_required"`
+	UnlockRetries                map[Lock]int     `json:"unlock_retries"`
+
+	// Warnings reports properties which were skipped because they could not
+	// be parsed. See Client.Lenient.
+	Warnings []error `json:"-"`
+
+	c        *Client
+	bearers  []dbus.ObjectPath
+	sim      dbus.ObjectPath
+	simSlots []dbus.ObjectPath
 }
 
+// An AccessTechnology is a bitmask of radio access technologies used by a
+// modem.
+type AccessTechnology uint32
+
+// Possible AccessTechnology values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemAccessTechnology.
+const (
+	AccessTechnologyUnknown AccessTechnology = 0
+	AccessTechnologyPOTS    AccessTechnology = 1 << (iota - 1)
+	AccessTechnologyGSM
+	AccessTechnologyGSMCompact
+	AccessTechnologyGPRS
+	AccessTechnologyEDGE
+	AccessTechnologyUMTS
+	AccessTechnologyHSDPA
+	AccessTechnologyHSUPA
+	AccessTechnologyHSPA
+	AccessTechnologyHSPAPlus
+	AccessTechnology1xRTT
+	AccessTechnologyEVDO0
+	AccessTechnologyEVDOA
+	AccessTechnologyEVDOB
+	AccessTechnologyLTE
+	AccessTechnologyNR5G
+	AccessTechnologyLTECatM
+	AccessTechnologyLTENBIoT
+	AccessTechnologyAny AccessTechnology = 0xffffffff
+)
+
+// A Lock is a type of lock which may prevent a modem from being used.
+type Lock int
+
+// Possible Lock values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemLock.
+const (
+	LockUnknown Lock = iota
+	LockNone
+	LockSIMPIN
+	LockSIMPIN2
+	LockSIMPUK
+	LockSIMPUK2
+	LockPHSPPIN
+	LockPHSPPUK
+	LockPHNetPIN
+	LockPHNetPUK
+	LockPHSIMPIN
+	LockPHCorpPIN
+	LockPHCorpPUK
+	LockPHFSIMPIN
+	LockPHFSIMPUK
+	LockPHNetSubPIN
+	LockPHNetSubPUK
+)
+
 // A PortType is the type of a modem port.
 type PortType int
 
@@ -53,8 +117,8 @@ const (
 
 // A Port is a modem port.
 type Port struct {
-	Name string
-	Type PortType
+	Name string   `json:"name"`
+	Type PortType `json:"type"`
 }
 
 // A PowerState is the power state of a modem.
@@ -90,28 +154,33 @@ const (
 	StateConnected
 )
 
+// A StateChangeReason is the reason for a modem State change.
+type StateChangeReason int
+
+// Possible StateChangeReason values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemStateChangeReason.
+const (
+	StateChangeReasonUnknown StateChangeReason = iota
+	StateChangeReasonUserRequested
+	StateChangeReasonSuspend
+	StateChangeReasonFailure
+)
+
+// A StateChange is an event which occurs when a modem changes State.
+type StateChange struct {
+	Old, New State
+	Reason   StateChangeReason
+}
+
 // GetNetworkTime fetches the current time from a Modem's network.
 func (m *Modem) GetNetworkTime(ctx context.Context) (time.Time, error) {
-	var v dbus.Variant
-	err := m.c.call(
-		ctx,
-		interfacePath("Modem", "Time", "GetNetworkTime"),
-		objectPath("Modem", strconv.Itoa(m.Index)),
-		&v,
-	)
-	if err != nil {
-		return time.Time{}, toPermission(err)
-	}
-
-	vp := newValueParser(v)
-	str := vp.String()
-	if err := vp.Err(); err != nil {
-		return time.Time{}, err
-	}
+	t, _, err := m.GetNetworkTimeRaw(ctx)
+	return t, err
+}
 
-	// The time is actually ISO 8601 but it seems that RFC 3339 is close enough:
-	// https://stackoverflow.com/questions/522251/whats-the-difference-between-iso-8601-and-rfc-3339-date-formats
-	t, err := time.Parse(time.RFC3339, str)
+// parseNetworkTime parses a network time string reported by a Modem.
+func parseNetworkTime(str string) (time.Time, error) {
+	t, err := ParseModemTime(str)
 	if err != nil {
 		return time.Time{}, err
 	}
@@ -127,6 +196,39 @@ func (m *Modem) GetNetworkTime(ctx context.Context) (time.Time, error) {
 	return time.Date(y, mon, d, hh, mm, ss, 0, time.UTC).In(t.Location()), nil
 }
 
+// Enable enables or disables the Modem. A Modem must be enabled before it can
+// register with a network and connect.
+func (m *Modem) Enable(ctx context.Context, enable bool) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Enable"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		enable,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// Reset resets the Modem, clearing any unsaved settings. The Modem is removed
+// and reappears with a new index once the reset completes.
+func (m *Modem) Reset(ctx context.Context) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Reset"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
 // SignalSetup sets the modem's extended signal quality refresh rate in seconds,
 // enabling future calls to Signal to return updated signal strength data. Any
 // fractional time values are rounded to the nearest second.
@@ -146,6 +248,104 @@ func (m *Modem) SignalSetup(ctx context.Context, rate time.Duration) error {
 	return nil
 }
 
+// Unlock unlocks the Modem's SIM by sending pin if the Modem currently
+// requires a SIM PIN. If the Modem is not locked, Unlock is a no-op. If the SIM
+// requires a PUK, an error compatible with 'errors.Is(err, ErrPUKRequired)' is
+// returned and SIM.SendPuk must be used instead. Any other type of lock results
+// in an error.
+func (m *Modem) Unlock(ctx context.Context, pin string) error {
+	// Fetch the current lock rather than trusting the Modem's fields, which
+	// may be out of date.
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem"),
+		"UnlockRequired",
+	)
+	if err != nil {
+		return err
+	}
+
+	vp := newValueParser(v)
+	lock := Lock(vp.Int())
+	if err := vp.Err(); err != nil {
+		return fmt.Errorf("failed to parse modem lock: %v", err)
+	}
+
+	switch lock {
+	case LockNone:
+		return nil
+	case LockSIMPIN:
+		s, err := m.SIM(ctx)
+		if err != nil {
+			return err
+		}
+
+		return s.SendPin(ctx, pin)
+	case LockSIMPUK:
+		return fmt.Errorf("failed to unlock modem: %w", ErrPUKRequired)
+	default:
+		return fmt.Errorf("failed to unlock modem: unsupported lock %s", lock)
+	}
+}
+
+// WatchState watches for changes to the Modem's State. Each change is
+// delivered on the returned channel, which is closed when the context is
+// canceled.
+func (m *Modem) WatchState(ctx context.Context) (<-chan StateChange, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem"),
+		"StateChanged",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, parseStateChange), nil
+}
+
+// Property fetches a raw D-Bus property by name from the input D-Bus interface
+// on the Modem's object, such as "org.freedesktop.ModemManager1.Modem". It can
+// be used to access properties which are not yet exposed by this package.
+func (m *Modem) Property(ctx context.Context, iface, name string) (dbus.Variant, error) {
+	return m.c.get(ctx, objectPath("Modem", strconv.Itoa(m.Index)), iface, name)
+}
+
+// AllProperties fetches all raw D-Bus properties from the input D-Bus interface
+// on the Modem's object. It can be used to access properties which are not yet
+// exposed by this package.
+func (m *Modem) AllProperties(ctx context.Context, iface string) (map[string]dbus.Variant, error) {
+	return m.c.getAll(ctx, objectPath("Modem", strconv.Itoa(m.Index)), iface)
+}
+
+// WatchProperties watches for changes to the Modem's raw D-Bus properties on
+// the input D-Bus interface, such as "org.freedesktop.ModemManager1.Modem".
+// Each map of changed properties is delivered on the returned channel, which
+// is closed when the context is canceled.
+func (m *Modem) WatchProperties(ctx context.Context, iface string) (<-chan map[string]dbus.Variant, error) {
+	return m.c.watchProperties(ctx, objectPath("Modem", strconv.Itoa(m.Index)), iface)
+}
+
+// parseStateChange parses a StateChange from a StateChanged signal.
+func parseStateChange(s *dbus.Signal) (StateChange, error) {
+	var (
+		old, new int32
+		reason   uint32
+	)
+
+	if err := dbus.Store(s.Body, &old, &new, &reason); err != nil {
+		return StateChange{}, fmt.Errorf("error parsing state change: %v", err)
+	}
+
+	return StateChange{
+		Old:    State(old),
+		New:    State(new),
+		Reason: StateChangeReason(reason),
+	}, nil
+}
+
 // parse parses a properties map into the Modem's fields.
 func (m *Modem) parse(ps map[string]dbus.Variant) error {
 	for k, v := range ps {
@@ -153,6 +353,8 @@ func (m *Modem) parse(ps map[string]dbus.Variant) error {
 		// with vp.Err if the types don't match as expected.
 		vp := newValueParser(v)
 		switch k {
+		case "AccessTechnologies":
+			m.AccessTechnologies = AccessTechnology(vp.Int())
 		case "Bearers":
 			m.bearers = vp.ObjectPaths()
 		case "CarrierConfiguration":
@@ -179,14 +381,29 @@ func (m *Modem) parse(ps map[string]dbus.Variant) error {
 			m.PowerState = PowerState(vp.Int())
 		case "PrimaryPort":
 			m.PrimaryPort = vp.String()
+		case "PrimarySimSlot":
+			m.PrimarySIMSlot = vp.Int()
 		case "Revision":
 			m.Revision = vp.String()
+		case "Sim":
+			m.sim = vp.ObjectPath()
+		case "SimSlots":
+			m.simSlots = vp.ObjectPaths()
 		case "State":
 			m.State = State(vp.Int())
+		case "UnlockRequired":
+			m.UnlockRequired = Lock(vp.Int())
+		case "UnlockRetries":
+			m.UnlockRetries = vp.UnlockRetries()
 		}
 
 		if err := vp.Err(); err != nil {
-			return fmt.Errorf("error parsing %q: %v", k, err)
+			err = fmt.Errorf("error parsing %q: %v", k, err)
+			if m.c == nil || !m.c.Lenient {
+				return err
+			}
+
+			m.Warnings = append(m.Warnings, err)
 		}
 	}
 
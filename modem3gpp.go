@@ -0,0 +1,570 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Modem3GPP contains 3GPP (GSM, UMTS, LTE, and 5G) network information for a
+// Modem.
+type Modem3GPP struct {
+	EnabledFacilityLocks FacilityLock
+	IMEI                 string
+	NetworkRejection     *NetworkRejection
+	OperatorCode         string
+	OperatorName         string
+	PacketServiceState   PacketServiceState
+	PCO                  []PCO
+	RegistrationState    RegistrationState3GPP
+}
+
+// NR5GRegistrationSettings are the power-saving settings a Modem requests when
+// registering with a 5G network. Zero values are unset and left to the modem's
+// defaults.
+type NR5GRegistrationSettings struct {
+	DRXCycle DRXCycle
+	MICOMode MICOMode
+}
+
+// A DRXCycle is a 5G discontinuous reception cycle length.
+type DRXCycle int
+
+// Possible DRXCycle values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppDrxCycle.
+const (
+	DRXCycleUnknown DRXCycle = iota
+	DRXCycleUnsupported
+	DRXCycle32
+	DRXCycle64
+	DRXCycle128
+	DRXCycle256
+)
+
+// A MICOMode is a 5G Mobile Initiated Connection Only mode.
+type MICOMode int
+
+// Possible MICOMode values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppMicoMode.
+const (
+	MICOModeUnknown MICOMode = iota
+	MICOModeUnsupported
+	MICOModeDisabled
+	MICOModeEnabled
+)
+
+// A FacilityLock is a bitmask of 3GPP facility locks, such as SIM PIN or
+// network personalization locks.
+type FacilityLock uint32
+
+// Possible FacilityLock values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppFacility.
+const (
+	FacilityLockNone         FacilityLock = 0
+	FacilityLockSIM          FacilityLock = 1 << 0
+	FacilityLockFixedDialing FacilityLock = 1 << 1
+	FacilityLockPHSIM        FacilityLock = 1 << 2
+	FacilityLockPHFSIM       FacilityLock = 1 << 3
+	FacilityLockNetPers      FacilityLock = 1 << 4
+	FacilityLockNetSubPers   FacilityLock = 1 << 5
+	FacilityLockProviderPers FacilityLock = 1 << 6
+	FacilityLockCorpPers     FacilityLock = 1 << 7
+)
+
+// A NetworkRejection describes the most recent rejection of a Modem's
+// registration attempt by a 3GPP network.
+type NetworkRejection struct {
+	AccessTechnology AccessTechnology
+	Error            NetworkError
+	OperatorID       string
+	OperatorName     string
+}
+
+// A NetworkError is a 3GPP network rejection cause, as described in 3GPP TS
+// 24.008 section 10.5.3.6.
+type NetworkError int
+
+// Possible NetworkError values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMNetworkError.
+const (
+	NetworkErrorNone                                        NetworkError = 0
+	NetworkErrorIMSIUnknownInHLR                            NetworkError = 2
+	NetworkErrorIllegalMS                                   NetworkError = 3
+	NetworkErrorIMSIUnknownInVLR                            NetworkError = 4
+	NetworkErrorIMEINotAccepted                             NetworkError = 5
+	NetworkErrorIllegalME                                   NetworkError = 6
+	NetworkErrorGPRSNotAllowed                              NetworkError = 7
+	NetworkErrorGPRSAndNonGPRSNotAllowed                    NetworkError = 8
+	NetworkErrorMSIdentityNotDerivedByNetwork               NetworkError = 9
+	NetworkErrorImplicitlyDetached                          NetworkError = 10
+	NetworkErrorPLMNNotAllowed                              NetworkError = 11
+	NetworkErrorLocationAreaNotAllowed                      NetworkError = 12
+	NetworkErrorRoamingNotAllowedInLocationArea             NetworkError = 13
+	NetworkErrorGPRSNotAllowedInPLMN                        NetworkError = 14
+	NetworkErrorNoCellsInLocationArea                       NetworkError = 15
+	NetworkErrorMSCTemporarilyNotReachable                  NetworkError = 16
+	NetworkErrorNetworkFailure                              NetworkError = 17
+	NetworkErrorCSDomainNotAvailable                        NetworkError = 18
+	NetworkErrorESMFailure                                  NetworkError = 19
+	NetworkErrorMACFailure                                  NetworkError = 20
+	NetworkErrorSynchFailure                                NetworkError = 21
+	NetworkErrorCongestion                                  NetworkError = 22
+	NetworkErrorGSMAuthenticationUnacceptable               NetworkError = 23
+	NetworkErrorNotAuthorizedForCSG                         NetworkError = 25
+	NetworkErrorInsufficientResources                       NetworkError = 26
+	NetworkErrorMissingOrUnknownAPN                         NetworkError = 27
+	NetworkErrorUnknownPDPAddressOrType                     NetworkError = 28
+	NetworkErrorUserAuthenticationFailed                    NetworkError = 29
+	NetworkErrorActivationRejectedByGGSNOrGW                NetworkError = 30
+	NetworkErrorActivationRejectedUnspecified               NetworkError = 31
+	NetworkErrorServiceOptionNotSupported                   NetworkError = 32
+	NetworkErrorRequestedServiceOptionNotSubscribed         NetworkError = 33
+	NetworkErrorServiceOptionTemporarilyOutOfOrder          NetworkError = 34
+	NetworkErrorNoPDPContextActivated                       NetworkError = 40
+	NetworkErrorSemanticErrorInTheTFTOperation              NetworkError = 41
+	NetworkErrorSyntacticalErrorInTheTFTOperation           NetworkError = 42
+	NetworkErrorUnknownPDPContext                           NetworkError = 43
+	NetworkErrorSemanticErrorsInPacketFilter                NetworkError = 44
+	NetworkErrorSyntacticalErrorInPacketFilter              NetworkError = 45
+	NetworkErrorPDPContextWithoutTFTAlreadyActivated        NetworkError = 46
+	NetworkErrorMulticastGroupMembershipTimeout             NetworkError = 47
+	NetworkErrorRequestRejectedBCMViolation                 NetworkError = 48
+	NetworkErrorLastPDNDisconnectionNotAllowed              NetworkError = 49
+	NetworkErrorPDPTypeIPv4OnlyAllowed                      NetworkError = 50
+	NetworkErrorPDPTypeIPv6OnlyAllowed                      NetworkError = 51
+	NetworkErrorMaximumNumberOfPDPContextsReached           NetworkError = 65
+	NetworkErrorRequestedAPNNotSupportedInCurrentRATAndPLMN NetworkError = 66
+	NetworkErrorInvalidTransactionIdentifierValue           NetworkError = 81
+	NetworkErrorSemanticallyIncorrectMessage                NetworkError = 95
+	NetworkErrorInvalidMandatoryInformation                 NetworkError = 96
+	NetworkErrorMessageTypeNonExistent                      NetworkError = 97
+	NetworkErrorMessageTypeNotCompatible                    NetworkError = 98
+	NetworkErrorIENonExistent                               NetworkError = 99
+	NetworkErrorConditionalIEError                          NetworkError = 100
+	NetworkErrorMessageNotCompatible                        NetworkError = 101
+	NetworkErrorProtocolErrorUnspecified                    NetworkError = 111
+	NetworkErrorAPNRestrictionValueIncompatible             NetworkError = 112
+	NetworkErrorMultipleAccessesToPLMNNotAllowed            NetworkError = 113
+)
+
+// A PacketServiceState indicates whether a modem is attached to a 3GPP
+// network's packet domain.
+type PacketServiceState int
+
+// Possible PacketServiceState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppPacketServiceState.
+const (
+	PacketServiceStateUnknown PacketServiceState = iota
+	PacketServiceStateDetached
+	PacketServiceStateAttached
+)
+
+// A RegistrationState3GPP is the registration state of a modem on a 3GPP
+// network.
+type RegistrationState3GPP int
+
+// Possible RegistrationState3GPP values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppRegistrationState.
+const (
+	RegistrationState3GPPIdle RegistrationState3GPP = iota
+	RegistrationState3GPPHome
+	RegistrationState3GPPSearching
+	RegistrationState3GPPDenied
+	RegistrationState3GPPUnknown
+	RegistrationState3GPPRoaming
+	RegistrationState3GPPHomeSMSOnly
+	RegistrationState3GPPRoamingSMSOnly
+	RegistrationState3GPPEmergencyOnly
+	RegistrationState3GPPHomeCSFBNotPreferred
+	RegistrationState3GPPRoamingCSFBNotPreferred
+	RegistrationState3GPPAttachedRLOS
+)
+
+// IsRoaming reports whether the registration state indicates that the modem
+// is registered with a roaming network.
+func (s RegistrationState3GPP) IsRoaming() bool {
+	switch s {
+	case RegistrationState3GPPRoaming,
+		RegistrationState3GPPRoamingSMSOnly,
+		RegistrationState3GPPRoamingCSFBNotPreferred:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRegistered reports whether the registration state indicates that the modem
+// is registered with a home or roaming network.
+func (s RegistrationState3GPP) IsRegistered() bool {
+	switch s {
+	case RegistrationState3GPPHome,
+		RegistrationState3GPPRoaming,
+		RegistrationState3GPPHomeSMSOnly,
+		RegistrationState3GPPRoamingSMSOnly,
+		RegistrationState3GPPHomeCSFBNotPreferred,
+		RegistrationState3GPPRoamingCSFBNotPreferred:
+		return true
+	default:
+		return false
+	}
+}
+
+// An Attachment classifies the type of 3GPP network a modem is attached to.
+type Attachment int
+
+// Possible Attachment values.
+const (
+	AttachmentNone Attachment = iota
+	AttachmentLegacy
+	AttachmentLTE
+	AttachmentNR5GNSA
+	AttachmentNR5GSA
+)
+
+// ClassifyAttachment classifies a modem's network attachment using its 3GPP
+// registration state and current access technologies.
+//
+// ModemManager reports both LTE and 5G NR access technologies for a modem
+// using 5G non-standalone mode, where an LTE anchor carries the control plane,
+// and only 5G NR for a modem using 5G standalone mode.
+func ClassifyAttachment(state RegistrationState3GPP, at AccessTechnology) Attachment {
+	if !state.IsRegistered() || at == AccessTechnologyUnknown {
+		return AttachmentNone
+	}
+
+	nr, lte := at&AccessTechnologyNR5G != 0, at&AccessTechnologyLTE != 0
+	switch {
+	case nr && lte:
+		return AttachmentNR5GNSA
+	case nr:
+		return AttachmentNR5GSA
+	case lte:
+		return AttachmentLTE
+	default:
+		return AttachmentLegacy
+	}
+}
+
+// ThreeGPP fetches 3GPP network information for the Modem.
+func (m *Modem) ThreeGPP(ctx context.Context) (*Modem3GPP, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Modem3gpp"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := parseModem3GPP(ps)
+	if err != nil {
+		return nil, err
+	}
+
+	g.OperatorName = m.c.operatorName(g.OperatorCode, g.OperatorName)
+	if nr := g.NetworkRejection; nr != nil {
+		nr.OperatorName = m.c.operatorName(nr.OperatorID, nr.OperatorName)
+	}
+
+	return g, nil
+}
+
+// Register registers the Modem with the 3GPP network identified by operatorID,
+// a numeric MCC/MNC operator code such as "310410". If operatorID is empty, the
+// Modem registers with its home network or any available roaming network.
+//
+// Registration may take a long time. If the operation times out, an error
+// compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (m *Modem) Register(ctx context.Context, operatorID string) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "Register"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		operatorID,
+	)
+	if err != nil {
+		return toTimeout(toPermission(err))
+	}
+
+	return nil
+}
+
+// InitialEPSBearer fetches the Bearer used by the Modem for its initial LTE
+// attach, if any. If no initial EPS bearer exists, an error compatible with
+// 'errors.Is(err, os.ErrNotExist)' is returned.
+func (m *Modem) InitialEPSBearer(ctx context.Context) (*Bearer, error) {
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Modem3gpp"),
+		"InitialEpsBearer",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	op := vp.ObjectPath()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse initial EPS bearer: %v", err)
+	}
+
+	// ModemManager uses an empty object path to indicate that no bearer is
+	// present.
+	if op == "" || op == "/" {
+		return nil, fmt.Errorf("no initial EPS bearer: %w", os.ErrNotExist)
+	}
+
+	return m.c.bearer(ctx, op)
+}
+
+// InitialEPSBearerSettings fetches the settings the Modem uses for its initial
+// LTE attach.
+func (m *Modem) InitialEPSBearerSettings(ctx context.Context) (*BearerProperties, error) {
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Modem3gpp"),
+		"InitialEpsBearerSettings",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	ps := vp.Properties()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse initial EPS bearer settings: %v", err)
+	}
+
+	return parseBearerProperties(ps)
+}
+
+// SetInitialEPSBearerSettings sets the settings the Modem uses for its initial
+// LTE attach. Only the APN, AllowedAuth, IPType, Password, and User properties
+// are used.
+func (m *Modem) SetInitialEPSBearerSettings(ctx context.Context, p BearerProperties) error {
+	if err := validateAPN(p.APN); err != nil {
+		return err
+	}
+
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "SetInitialEpsBearerSettings"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		p.properties(),
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// DisableFacilityLock disables a single facility lock on the Modem using the
+// lock's control key, such as a network personalization unlock code. If the
+// key is incorrect, a *PINError compatible with 'errors.Is(err,
+// ErrIncorrectPassword)' is returned.
+func (m *Modem) DisableFacilityLock(ctx context.Context, lock FacilityLock, key string) error {
+	// The lock and key are packed as a D-Bus (us) struct.
+	type facility struct {
+		Lock uint32
+		Key  string
+	}
+
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "DisableFacilityLock"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		facility{
+			Lock: uint32(lock),
+			Key:  key,
+		},
+	)
+	if err != nil {
+		return m.c.toPINError(ctx, objectPath("Modem", strconv.Itoa(m.Index)), err)
+	}
+
+	return nil
+}
+
+// NR5GRegistrationSettings fetches the settings the Modem uses when registering
+// with a 5G network. This method requires ModemManager 1.20 or newer.
+func (m *Modem) NR5GRegistrationSettings(ctx context.Context) (*NR5GRegistrationSettings, error) {
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Modem3gpp"),
+		"Nr5gRegistrationSettings",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	ps := vp.Properties()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse 5G registration settings: %v", err)
+	}
+
+	return parseNR5GRegistrationSettings(ps)
+}
+
+// SetNR5GRegistrationSettings sets the settings the Modem uses when registering
+// with a 5G network. This method requires ModemManager 1.20 or newer.
+func (m *Modem) SetNR5GRegistrationSettings(ctx context.Context, s NR5GRegistrationSettings) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "SetNr5gRegistrationSettings"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		s.properties(),
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// SetPacketServiceState explicitly attaches the Modem to or detaches it from
+// the 3GPP network's packet domain. This method requires ModemManager 1.20 or
+// newer.
+func (m *Modem) SetPacketServiceState(ctx context.Context, state PacketServiceState) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "SetPacketServiceState"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		uint32(state),
+	)
+	if err != nil {
+		return toTimeout(toPermission(err))
+	}
+
+	return nil
+}
+
+// SetCarrierLock sends a carrier lock configuration blob to the Modem, such
+// as one produced by an OEM's carrier provisioning tools. This method
+// requires ModemManager 1.22 or newer.
+func (m *Modem) SetCarrierLock(ctx context.Context, data []byte) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "SetCarrierLock"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		data,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// parseModem3GPP parses a properties map into Modem3GPP data.
+func parseModem3GPP(ps map[string]dbus.Variant) (*Modem3GPP, error) {
+	var g Modem3GPP
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "EnabledFacilityLocks":
+			g.EnabledFacilityLocks = FacilityLock(vp.Int())
+		case "Imei":
+			g.IMEI = vp.String()
+		case "NetworkRejection":
+			nr, err := parseNetworkRejection(vp.Properties())
+			if err != nil {
+				return nil, fmt.Errorf("error parsing network rejection: %v", err)
+			}
+			g.NetworkRejection = nr
+		case "OperatorCode":
+			g.OperatorCode = vp.String()
+		case "OperatorName":
+			g.OperatorName = vp.String()
+		case "PacketServiceState":
+			g.PacketServiceState = PacketServiceState(vp.Int())
+		case "Pco":
+			g.PCO = vp.PCO()
+		case "RegistrationState":
+			g.RegistrationState = RegistrationState3GPP(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &g, nil
+}
+
+// parseNR5GRegistrationSettings parses NR5GRegistrationSettings from a
+// properties map.
+func parseNR5GRegistrationSettings(ps map[string]dbus.Variant) (*NR5GRegistrationSettings, error) {
+	var s NR5GRegistrationSettings
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "drx-cycle":
+			s.DRXCycle = DRXCycle(vp.Int())
+		case "mico-mode":
+			s.MICOMode = MICOMode(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &s, nil
+}
+
+// properties packs NR5GRegistrationSettings into a properties map, omitting
+// any unset values.
+func (s NR5GRegistrationSettings) properties() map[string]dbus.Variant {
+	ps := make(map[string]dbus.Variant)
+	if s.DRXCycle != DRXCycleUnknown {
+		ps["drx-cycle"] = dbus.MakeVariant(uint32(s.DRXCycle))
+	}
+	if s.MICOMode != MICOModeUnknown {
+		ps["mico-mode"] = dbus.MakeVariant(uint32(s.MICOMode))
+	}
+
+	return ps
+}
+
+// parseNetworkRejection parses a NetworkRejection from a properties map. If
+// the map is empty, no rejection has occurred and nil is returned.
+func parseNetworkRejection(ps map[string]dbus.Variant) (*NetworkRejection, error) {
+	if len(ps) == 0 {
+		return nil, nil
+	}
+
+	var nr NetworkRejection
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "access-technology":
+			nr.AccessTechnology = AccessTechnology(vp.Int())
+		case "error":
+			nr.Error = NetworkError(vp.Int())
+		case "operator-id":
+			nr.OperatorID = vp.String()
+		case "operator-name":
+			nr.OperatorName = vp.String()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &nr, nil
+}
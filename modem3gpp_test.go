@@ -0,0 +1,485 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemThreeGPP(t *testing.T) {
+	m := &Modem{
+		// Verify all of the expected inputs before returning canned properties.
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"EnabledFacilityLocks": dbus.MakeVariant(uint32(FacilityLockSIM | FacilityLockNetPers)),
+				"Imei":                 dbus.MakeVariant("123456789012345"),
+				"NetworkRejection": dbus.MakeVariant(map[string]dbus.Variant{
+					"access-technology": dbus.MakeVariant(uint32(AccessTechnologyLTE)),
+					"error":             dbus.MakeVariant(uint32(NetworkErrorRoamingNotAllowedInLocationArea)),
+					"operator-id":       dbus.MakeVariant("310260"),
+					"operator-name":     dbus.MakeVariant("T-Mobile"),
+				}),
+				"OperatorCode":       dbus.MakeVariant("310410"),
+				"OperatorName":       dbus.MakeVariant("AT&T"),
+				"PacketServiceState": dbus.MakeVariant(uint32(PacketServiceStateAttached)),
+				"Pco": dbus.MakeVariant([][]interface{}{
+					{uint32(1), true, []byte{0x80, 0x00, 0x10, 0x02, 0x05, 0xdc}},
+				}),
+				"RegistrationState": dbus.MakeVariant(uint32(RegistrationState3GPPRoaming)),
+			}, nil
+		}},
+	}
+
+	g, err := m.ThreeGPP(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get 3GPP information: %v", err)
+	}
+
+	want := &Modem3GPP{
+		EnabledFacilityLocks: FacilityLockSIM | FacilityLockNetPers,
+		IMEI:                 "123456789012345",
+		NetworkRejection: &NetworkRejection{
+			AccessTechnology: AccessTechnologyLTE,
+			Error:            NetworkErrorRoamingNotAllowedInLocationArea,
+			OperatorID:       "310260",
+			OperatorName:     "T-Mobile",
+		},
+		OperatorCode:       "310410",
+		OperatorName:       "AT&T",
+		PacketServiceState: PacketServiceStateAttached,
+		PCO: []PCO{{
+			SessionID: 1,
+			Complete:  true,
+			Data:      []byte{0x80, 0x00, 0x10, 0x02, 0x05, 0xdc},
+		}},
+		RegistrationState: RegistrationState3GPPRoaming,
+	}
+
+	if diff := cmp.Diff(want, g); diff != "" {
+		t.Fatalf("unexpected Modem3GPP (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemRegister(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		is   error
+	}{
+		{
+			name: "OK",
+		},
+		{
+			name: "permission denied",
+			err:  dbus.Error{Name: unauthorizedError},
+			is:   os.ErrPermission,
+		},
+		{
+			name: "network timeout",
+			err:  dbus.Error{Name: networkTimeoutError},
+			is:   os.ErrDeadlineExceeded,
+		},
+		{
+			name: "no reply",
+			err:  dbus.Error{Name: noReplyError},
+			is:   os.ErrDeadlineExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+					if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.Register", method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff([]interface{}{"310410"}, args); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return tt.err
+				}},
+			}
+
+			err := m.Register(context.Background(), "310410")
+			if tt.is == nil {
+				if err != nil {
+					t.Fatalf("failed to register: %v", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, tt.is) {
+				t.Fatalf("expected %v, but got: %v", tt.is, err)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
+
+func TestRegistrationState3GPPIsRoaming(t *testing.T) {
+	tests := []struct {
+		s    RegistrationState3GPP
+		want bool
+	}{
+		{s: RegistrationState3GPPIdle},
+		{s: RegistrationState3GPPHome},
+		{s: RegistrationState3GPPHomeSMSOnly},
+		{s: RegistrationState3GPPRoaming, want: true},
+		{s: RegistrationState3GPPRoamingSMSOnly, want: true},
+		{s: RegistrationState3GPPRoamingCSFBNotPreferred, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s.String(), func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.s.IsRoaming()); diff != "" {
+				t.Fatalf("unexpected roaming state (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClassifyAttachment(t *testing.T) {
+	tests := []struct {
+		name  string
+		state RegistrationState3GPP
+		at    AccessTechnology
+		want  Attachment
+	}{
+		{
+			name:  "searching",
+			state: RegistrationState3GPPSearching,
+			at:    AccessTechnologyLTE,
+			want:  AttachmentNone,
+		},
+		{
+			name:  "unknown access technology",
+			state: RegistrationState3GPPHome,
+			want:  AttachmentNone,
+		},
+		{
+			name:  "UMTS",
+			state: RegistrationState3GPPHome,
+			at:    AccessTechnologyUMTS | AccessTechnologyHSPA,
+			want:  AttachmentLegacy,
+		},
+		{
+			name:  "LTE",
+			state: RegistrationState3GPPRoaming,
+			at:    AccessTechnologyLTE,
+			want:  AttachmentLTE,
+		},
+		{
+			name:  "5G NSA",
+			state: RegistrationState3GPPHome,
+			at:    AccessTechnologyLTE | AccessTechnologyNR5G,
+			want:  AttachmentNR5GNSA,
+		},
+		{
+			name:  "5G SA",
+			state: RegistrationState3GPPHome,
+			at:    AccessTechnologyNR5G,
+			want:  AttachmentNR5GSA,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ClassifyAttachment(tt.state, tt.at)); diff != "" {
+				t.Fatalf("unexpected Attachment (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModemInitialEPSBearer(t *testing.T) {
+	tests := []struct {
+		name string
+		op   dbus.ObjectPath
+		want *Bearer
+	}{
+		{
+			name: "not found",
+			op:   "/",
+		},
+		{
+			name: "OK",
+			op:   "/org/freedesktop/ModemManager1/Bearer/2",
+			want: &Bearer{
+				Index:     2,
+				Connected: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{
+					get: func(_ context.Context, _ dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+						if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp", dInterface); diff != "" {
+							t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+						}
+
+						if diff := cmp.Diff("InitialEpsBearer", prop); diff != "" {
+							t.Fatalf("unexpected property (-want +got):\n%s", diff)
+						}
+
+						return dbus.MakeVariant(tt.op), nil
+					},
+					getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+						if diff := cmp.Diff(tt.op, op); diff != "" {
+							t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+						}
+
+						return map[string]dbus.Variant{
+							"Connected": dbus.MakeVariant(true),
+						}, nil
+					},
+				},
+			}
+
+			b, err := m.InitialEPSBearer(context.Background())
+			if tt.want == nil {
+				if !errors.Is(err, os.ErrNotExist) {
+					t.Fatalf("expected is not exist error, but got: %v", err)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("failed to get initial EPS bearer: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, b, cmpopts.IgnoreUnexported(Bearer{})); diff != "" {
+				t.Fatalf("unexpected Bearer (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModemInitialEPSBearerSettings(t *testing.T) {
+	m := &Modem{
+		c: &Client{get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff("InitialEpsBearerSettings", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant(map[string]dbus.Variant{
+				"apn":          dbus.MakeVariant("broadband"),
+				"allowed-auth": dbus.MakeVariant(uint32(BearerAllowedAuthCHAP)),
+				"ip-type":      dbus.MakeVariant(uint32(BearerIPFamilyIPv4v6)),
+				"user":         dbus.MakeVariant("user"),
+				"password":     dbus.MakeVariant("pass"),
+			}), nil
+		}},
+	}
+
+	p, err := m.InitialEPSBearerSettings(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get initial EPS bearer settings: %v", err)
+	}
+
+	want := &BearerProperties{
+		APN:         "broadband",
+		AllowedAuth: BearerAllowedAuthCHAP,
+		IPType:      BearerIPFamilyIPv4v6,
+		Password:    "pass",
+		User:        "user",
+	}
+
+	if diff := cmp.Diff(want, p); diff != "" {
+		t.Fatalf("unexpected BearerProperties (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetInitialEPSBearerSettings(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.SetInitialEpsBearerSettings", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			// Only the set properties should be sent.
+			want := []interface{}{map[string]dbus.Variant{
+				"allow-roaming": dbus.MakeVariant(false),
+				"apn":           dbus.MakeVariant("broadband"),
+				"ip-type":       dbus.MakeVariant(uint32(BearerIPFamilyIPv4)),
+			}}
+
+			if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	roaming := false
+	err := m.SetInitialEPSBearerSettings(context.Background(), BearerProperties{
+		APN:          "broadband",
+		AllowRoaming: &roaming,
+		IPType:       BearerIPFamilyIPv4,
+	})
+	if err != nil {
+		t.Fatalf("failed to set initial EPS bearer settings: %v", err)
+	}
+}
+
+func TestModemDisableFacilityLock(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.DisableFacilityLock", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("(us)", dbus.SignatureOf(args...).String()); diff != "" {
+					t.Fatalf("unexpected arguments signature (-want +got):\n%s", diff)
+				}
+
+				return dbus.Error{Name: incorrectPasswordError}
+			},
+			get: func(_ context.Context, _ dbus.ObjectPath, _, _ string) (dbus.Variant, error) {
+				return dbus.MakeVariant(map[uint32]uint32{uint32(LockPHNetPIN): 9}), nil
+			},
+		},
+	}
+
+	err := m.DisableFacilityLock(context.Background(), FacilityLockNetPers, "12345678")
+
+	var perr *PINError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *PINError, but got: %v", err)
+	}
+
+	if diff := cmp.Diff(map[Lock]int{LockPHNetPIN: 9}, perr.Retries); diff != "" {
+		t.Fatalf("unexpected retries (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetCarrierLock(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.SetCarrierLock", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{[]byte{0xde, 0xad, 0xbe, 0xef}}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: unauthorizedError}
+		}},
+	}
+
+	err := m.SetCarrierLock(context.Background(), []byte{0xde, 0xad, 0xbe, 0xef})
+	if !errors.Is(err, os.ErrPermission) {
+		t.Fatalf("expected permission error, but got: %v", err)
+	}
+}
+
+func TestModemSetPacketServiceState(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.SetPacketServiceState", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{uint32(PacketServiceStateDetached)}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	if err := m.SetPacketServiceState(context.Background(), PacketServiceStateDetached); err != nil {
+		t.Fatalf("failed to set packet service state: %v", err)
+	}
+}
+
+func TestModemNR5GRegistrationSettings(t *testing.T) {
+	m := &Modem{
+		c: &Client{get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff("Nr5gRegistrationSettings", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant(map[string]dbus.Variant{
+				"drx-cycle": dbus.MakeVariant(uint32(DRXCycle128)),
+				"mico-mode": dbus.MakeVariant(uint32(MICOModeEnabled)),
+			}), nil
+		}},
+	}
+
+	s, err := m.NR5GRegistrationSettings(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get 5G registration settings: %v", err)
+	}
+
+	want := &NR5GRegistrationSettings{
+		DRXCycle: DRXCycle128,
+		MICOMode: MICOModeEnabled,
+	}
+
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Fatalf("unexpected NR5GRegistrationSettings (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetNR5GRegistrationSettings(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.SetNr5gRegistrationSettings", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			// Only the set properties should be sent.
+			want := []interface{}{map[string]dbus.Variant{
+				"mico-mode": dbus.MakeVariant(uint32(MICOModeDisabled)),
+			}}
+
+			if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	err := m.SetNR5GRegistrationSettings(context.Background(), NR5GRegistrationSettings{
+		MICOMode: MICOModeDisabled,
+	})
+	if err != nil {
+		t.Fatalf("failed to set 5G registration settings: %v", err)
+	}
+}
@@ -87,3 +87,215 @@ func TestModemSignalSetup(t *testing.T) {
 		t.Fatalf("failed to perform signal setup: %v", err)
 	}
 }
+
+func TestModemProperty(t *testing.T) {
+	m := &Modem{
+		// Verify all of the expected inputs before returning a canned value.
+		c: &Client{get: func(_ context.Context, op dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("Imei", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant("123456789012345"), nil
+		}},
+	}
+
+	v, err := m.Property(context.Background(), "org.freedesktop.ModemManager1.Modem.Modem3gpp", "Imei")
+	if err != nil {
+		t.Fatalf("failed to get property: %v", err)
+	}
+
+	if diff := cmp.Diff("123456789012345", v.Value()); diff != "" {
+		t.Fatalf("unexpected property value (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchState(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("StateChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal which should be skipped, followed by
+			// a valid one.
+			sigs := make(chan *dbus.Signal, 2)
+			sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				int32(StateRegistered),
+				int32(StateConnected),
+				uint32(StateChangeReasonUserRequested),
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := m.WatchState(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch state: %v", err)
+	}
+
+	var got []StateChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []StateChange{{
+		Old:    StateRegistered,
+		New:    StateConnected,
+		Reason: StateChangeReasonUserRequested,
+	}}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected state changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchProperties(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.DBus.Properties", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("PropertiesChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver changes for an unrelated interface which should be
+			// skipped, followed by the expected interface.
+			sigs := make(chan *dbus.Signal, 2)
+			sigs <- &dbus.Signal{Body: []interface{}{
+				"org.freedesktop.ModemManager1.Modem.Signal",
+				map[string]dbus.Variant{"Rate": dbus.MakeVariant(uint32(10))},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				"org.freedesktop.ModemManager1.Modem",
+				map[string]dbus.Variant{"PowerState": dbus.MakeVariant(uint32(PowerStateOn))},
+				[]string{},
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := m.WatchProperties(context.Background(), "org.freedesktop.ModemManager1.Modem")
+	if err != nil {
+		t.Fatalf("failed to watch properties: %v", err)
+	}
+
+	var got []interface{}
+	for ps := range changes {
+		for k, v := range ps {
+			got = append(got, k, v.Value())
+		}
+	}
+
+	want := []interface{}{"PowerState", uint32(PowerStateOn)}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected property changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemUnlock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock Lock
+		pin  bool
+		ok   bool
+		is   error
+	}{
+		{
+			name: "none",
+			lock: LockNone,
+			ok:   true,
+		},
+		{
+			name: "PIN",
+			lock: LockSIMPIN,
+			pin:  true,
+			ok:   true,
+		},
+		{
+			name: "PUK",
+			lock: LockSIMPUK,
+			is:   ErrPUKRequired,
+		},
+		{
+			name: "unsupported",
+			lock: LockPHNetPIN,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sentPin bool
+			m := &Modem{
+				c: &Client{
+					get: func(_ context.Context, _ dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+						if diff := cmp.Diff("UnlockRequired", prop); diff != "" {
+							t.Fatalf("unexpected property (-want +got):\n%s", diff)
+						}
+
+						return dbus.MakeVariant(uint32(tt.lock)), nil
+					},
+					getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+						return map[string]dbus.Variant{}, nil
+					},
+					call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+						if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim.SendPin", method); diff != "" {
+							t.Fatalf("unexpected method (-want +got):\n%s", diff)
+						}
+
+						if diff := cmp.Diff([]interface{}{"1234"}, args); diff != "" {
+							t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+						}
+
+						sentPin = true
+						return nil
+					},
+				},
+				sim: "/org/freedesktop/ModemManager1/SIM/0",
+			}
+
+			err := m.Unlock(context.Background(), "1234")
+			if tt.ok && err != nil {
+				t.Fatalf("failed to unlock: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+			if tt.is != nil && !errors.Is(err, tt.is) {
+				t.Fatalf("expected %v, but got: %v", tt.is, err)
+			}
+
+			if diff := cmp.Diff(tt.pin, sentPin); diff != "" {
+				t.Fatalf("unexpected PIN sent state (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
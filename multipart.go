@@ -0,0 +1,196 @@
+package modemmanager
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// An SMSPart is a single part of a concatenated (multipart) SMS, identified by
+// its sender, concatenation reference number, and sequence number.
+//
+// ModemManager reassembles concatenated messages it receives into a single SMS
+// which remains in the SMSStateReceiving state until all parts arrive, so a
+// Reassembler is only needed for messages obtained by other means, such as
+// raw PDUs read from a modem's AT or QMI ports.
+type SMSPart struct {
+	Number    string
+	Reference int
+	Total     int
+	Sequence  int
+	Text      string
+	Data      []byte
+}
+
+// A ConcatenatedSMS is a message reassembled from all of its SMSParts.
+type ConcatenatedSMS struct {
+	Number    string
+	Reference int
+	Parts     []SMSPart
+	Text      string
+	Data      []byte
+}
+
+// ParseConcatenation parses the concatenation reference, total number of
+// parts, and sequence number of an SMS part from the SMS's User Data Header,
+// as described in 3GPP TS 23.040 section 9.2.3.24. If the header contains no
+// concatenation information element, ok is false.
+func ParseConcatenation(udh []byte) (reference, total, sequence int, ok bool) {
+	// Skip the header length octet if present.
+	if len(udh) > 0 && int(udh[0]) == len(udh)-1 {
+		udh = udh[1:]
+	}
+
+	for len(udh) >= 2 {
+		iei, l := udh[0], int(udh[1])
+		udh = udh[2:]
+		if l > len(udh) {
+			return 0, 0, 0, false
+		}
+
+		ie := udh[:l]
+		udh = udh[l:]
+
+		switch {
+		case iei == 0x00 && l == 3:
+			// 8-bit reference number.
+			return int(ie[0]), int(ie[1]), int(ie[2]), true
+		case iei == 0x08 && l == 4:
+			// 16-bit reference number.
+			return int(ie[0])<<8 | int(ie[1]), int(ie[2]), int(ie[3]), true
+		}
+	}
+
+	return 0, 0, 0, false
+}
+
+// A Reassembler groups SMSParts by their concatenation reference and
+// reassembles complete messages. Reassembler is safe for concurrent use.
+type Reassembler struct {
+	mu      sync.Mutex
+	pending map[partKey]*partGroup
+}
+
+// A partKey identifies the parts of a single concatenated SMS.
+type partKey struct {
+	number           string
+	reference, total int
+}
+
+// A partGroup is a set of received parts for a single concatenated SMS.
+type partGroup struct {
+	added time.Time
+	parts map[int]SMSPart
+}
+
+// NewReassembler creates a Reassembler.
+func NewReassembler() *Reassembler {
+	return &Reassembler{pending: make(map[partKey]*partGroup)}
+}
+
+// Add adds an SMSPart to the Reassembler. If p completes a message, the
+// ConcatenatedSMS is returned and its parts are discarded by the Reassembler.
+// Otherwise, nil is returned. Duplicate parts replace previously added parts.
+func (r *Reassembler) Add(p SMSPart) (*ConcatenatedSMS, error) {
+	if p.Total < 1 || p.Sequence < 1 || p.Sequence > p.Total {
+		return nil, fmt.Errorf("invalid SMS part sequence %d of %d", p.Sequence, p.Total)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	k := partKey{
+		number:    p.Number,
+		reference: p.Reference,
+		total:     p.Total,
+	}
+
+	g, ok := r.pending[k]
+	if !ok {
+		g = &partGroup{
+			added: time.Now(),
+			parts: make(map[int]SMSPart, p.Total),
+		}
+		r.pending[k] = g
+	}
+
+	g.parts[p.Sequence] = p
+	if len(g.parts) < p.Total {
+		return nil, nil
+	}
+
+	delete(r.pending, k)
+	return g.concatenate(k), nil
+}
+
+// Pending returns the number of incomplete messages held by the Reassembler.
+func (r *Reassembler) Pending() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.pending)
+}
+
+// Expire discards the parts of any incomplete messages whose first part was
+// added before t, and returns the discarded parts. Parts of a message may be
+// lost by the network, so Expire should be called periodically to bound the
+// Reassembler's memory use.
+func (r *Reassembler) Expire(t time.Time) []SMSPart {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ps []SMSPart
+	for k, g := range r.pending {
+		if !g.added.Before(t) {
+			continue
+		}
+
+		ps = append(ps, g.sorted()...)
+		delete(r.pending, k)
+	}
+
+	return ps
+}
+
+// sorted returns the partGroup's parts in sequence order.
+func (g *partGroup) sorted() []SMSPart {
+	ps := make([]SMSPart, 0, len(g.parts))
+	for _, p := range g.parts {
+		ps = append(ps, p)
+	}
+
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].Sequence < ps[j].Sequence
+	})
+
+	return ps
+}
+
+// concatenate joins all of a complete partGroup's parts.
+func (g *partGroup) concatenate(k partKey) *ConcatenatedSMS {
+	var (
+		ps   = g.sorted()
+		text strings.Builder
+		data bytes.Buffer
+	)
+
+	for _, p := range ps {
+		text.WriteString(p.Text)
+		data.Write(p.Data)
+	}
+
+	c := &ConcatenatedSMS{
+		Number:    k.number,
+		Reference: k.reference,
+		Parts:     ps,
+		Text:      text.String(),
+	}
+	if data.Len() > 0 {
+		c.Data = data.Bytes()
+	}
+
+	return c
+}
@@ -0,0 +1,143 @@
+package modemmanager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseConcatenation(t *testing.T) {
+	tests := []struct {
+		name                       string
+		udh                        []byte
+		reference, total, sequence int
+		ok                         bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "truncated",
+			udh:  []byte{0x00, 0x03, 0x01},
+		},
+		{
+			name: "no concatenation",
+			udh:  []byte{0x05, 0x04, 0x0b, 0x84, 0x23, 0xf0},
+		},
+		{
+			name:      "8-bit reference",
+			udh:       []byte{0x05, 0x00, 0x03, 0x2a, 0x03, 0x02},
+			reference: 0x2a,
+			total:     3,
+			sequence:  2,
+			ok:        true,
+		},
+		{
+			name: "16-bit reference after port addressing",
+			udh: []byte{
+				0x05, 0x04, 0x0b, 0x84, 0x23, 0xf0,
+				0x08, 0x04, 0x12, 0x34, 0x02, 0x01,
+			},
+			reference: 0x1234,
+			total:     2,
+			sequence:  1,
+			ok:        true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, total, seq, ok := ParseConcatenation(tt.udh)
+			if diff := cmp.Diff(tt.ok, ok); diff != "" {
+				t.Fatalf("unexpected ok (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]int{tt.reference, tt.total, tt.sequence}, []int{ref, total, seq}); diff != "" {
+				t.Fatalf("unexpected concatenation (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReassembler(t *testing.T) {
+	r := NewReassembler()
+
+	if _, err := r.Add(SMSPart{Total: 2, Sequence: 3}); err == nil {
+		t.Fatal("expected an invalid sequence error, but none occurred")
+	}
+
+	parts := []SMSPart{
+		{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 3, Text: "!"},
+		{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 1, Text: "hello, "},
+		// Same reference from another sender.
+		{Number: "+15555555678", Reference: 1, Total: 2, Sequence: 1, Text: "foo"},
+		// Duplicate part.
+		{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 1, Text: "hello, "},
+	}
+
+	for _, p := range parts {
+		c, err := r.Add(p)
+		if err != nil {
+			t.Fatalf("failed to add part: %v", err)
+		}
+		if c != nil {
+			t.Fatalf("unexpected complete message: %+v", c)
+		}
+	}
+
+	if diff := cmp.Diff(2, r.Pending()); diff != "" {
+		t.Fatalf("unexpected pending messages (-want +got):\n%s", diff)
+	}
+
+	c, err := r.Add(SMSPart{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 2, Text: "world"})
+	if err != nil {
+		t.Fatalf("failed to add part: %v", err)
+	}
+
+	want := &ConcatenatedSMS{
+		Number:    "+15555551234",
+		Reference: 1,
+		Parts: []SMSPart{
+			{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 1, Text: "hello, "},
+			{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 2, Text: "world"},
+			{Number: "+15555551234", Reference: 1, Total: 3, Sequence: 3, Text: "!"},
+		},
+		Text: "hello, world!",
+	}
+
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Fatalf("unexpected ConcatenatedSMS (-want +got):\n%s", diff)
+	}
+
+	// Only the other sender's incomplete message remains, and can be expired.
+	expired := r.Expire(time.Now().Add(time.Minute))
+
+	wantExpired := []SMSPart{
+		{Number: "+15555555678", Reference: 1, Total: 2, Sequence: 1, Text: "foo"},
+	}
+
+	if diff := cmp.Diff(wantExpired, expired); diff != "" {
+		t.Fatalf("unexpected expired parts (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(0, r.Pending()); diff != "" {
+		t.Fatalf("unexpected pending messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestReassemblerSinglePart(t *testing.T) {
+	c, err := NewReassembler().Add(SMSPart{Total: 1, Sequence: 1, Data: []byte{0xff}})
+	if err != nil {
+		t.Fatalf("failed to add part: %v", err)
+	}
+
+	want := &ConcatenatedSMS{
+		Parts: []SMSPart{{Total: 1, Sequence: 1, Data: []byte{0xff}}},
+		Data:  []byte{0xff},
+	}
+
+	if diff := cmp.Diff(want, c); diff != "" {
+		t.Fatalf("unexpected ConcatenatedSMS (-want +got):\n%s", diff)
+	}
+}
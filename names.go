@@ -0,0 +1,1124 @@
+package modemmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Each enum type has a Name method which returns a lower-case name matching
+// the output of mmcli, such as "connected" for StateConnected, which is often
+// more suitable for logs and dashboards than the Go identifier returned by
+// String. Bitmask types return the names of each set bit separated by ", ",
+// as mmcli does.
+//
+// Each enum type also implements encoding.TextMarshaler and
+// encoding.TextUnmarshaler using these names, so that values are encoded as
+// readable strings in formats such as JSON. Decimal values are also accepted
+// when unmarshaling.
+
+// An enum is any of this package's enum types.
+type enum interface {
+	~int | ~uint32
+}
+
+// enumName returns the name of v from names, or the decimal value of v if v
+// has no name.
+func enumName[T enum](v T, names map[T]string) string {
+	if n, ok := names[v]; ok {
+		return n
+	}
+
+	return strconv.FormatInt(int64(v), 10)
+}
+
+// flagsName returns the name of the bitmask v from names. If v itself has no
+// name, the names of each set bit are joined in ascending order.
+func flagsName[T ~uint32](v T, names map[T]string) string {
+	if n, ok := names[v]; ok {
+		return n
+	}
+
+	var ss []string
+	for i := 0; i < 32; i++ {
+		b := T(1) << i
+		if v&b == 0 {
+			continue
+		}
+
+		ss = append(ss, enumName(b, names))
+	}
+
+	return strings.Join(ss, ", ")
+}
+
+// parseEnum parses the name or decimal value of an enum from b into v.
+func parseEnum[T enum](b []byte, v *T, names map[T]string) error {
+	s := string(b)
+	for k, n := range names {
+		if n == s {
+			*v = k
+			return nil
+		}
+	}
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || int64(T(i)) != i {
+		return fmt.Errorf("invalid %T name: %q", *v, s)
+	}
+
+	*v = T(i)
+	return nil
+}
+
+// parseFlags parses the name of a bitmask, or the names of each of its set
+// bits separated by ", ", from b into v.
+func parseFlags[T ~uint32](b []byte, v *T, names map[T]string) error {
+	// A bitmask with its own name, such as "any", may contain the separator.
+	var out T
+	if err := parseEnum(b, &out, names); err == nil {
+		*v = out
+		return nil
+	}
+
+	for _, s := range strings.Split(string(b), ", ") {
+		var f T
+		if err := parseEnum([]byte(s), &f, names); err != nil {
+			return fmt.Errorf("invalid %T name: %q", *v, string(b))
+		}
+
+		out |= f
+	}
+
+	*v = out
+	return nil
+}
+
+// Name returns the AccessTechnology's name as reported by mmcli.
+func (a AccessTechnology) Name() string { return flagsName(a, accessTechnologyNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (a AccessTechnology) MarshalText() ([]byte, error) { return []byte(a.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (a *AccessTechnology) UnmarshalText(text []byte) error {
+	return parseFlags(text, a, accessTechnologyNames)
+}
+
+// Name returns the AssistanceDataType's name as reported by mmcli.
+func (a AssistanceDataType) Name() string { return flagsName(a, assistanceDataTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (a AssistanceDataType) MarshalText() ([]byte, error) { return []byte(a.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (a *AssistanceDataType) UnmarshalText(text []byte) error {
+	return parseFlags(text, a, assistanceDataTypeNames)
+}
+
+// Name returns the Attachment's name in the style of mmcli.
+func (a Attachment) Name() string { return enumName(a, attachmentNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (a Attachment) MarshalText() ([]byte, error) { return []byte(a.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (a *Attachment) UnmarshalText(text []byte) error { return parseEnum(text, a, attachmentNames) }
+
+// Name returns the BearerAllowedAuth's name as reported by mmcli.
+func (b BearerAllowedAuth) Name() string { return flagsName(b, bearerAllowedAuthNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (b BearerAllowedAuth) MarshalText() ([]byte, error) { return []byte(b.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (b *BearerAllowedAuth) UnmarshalText(text []byte) error {
+	return parseFlags(text, b, bearerAllowedAuthNames)
+}
+
+// Name returns the BearerIPFamily's name as reported by mmcli.
+func (b BearerIPFamily) Name() string { return flagsName(b, bearerIPFamilyNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (b BearerIPFamily) MarshalText() ([]byte, error) { return []byte(b.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (b *BearerIPFamily) UnmarshalText(text []byte) error {
+	return parseFlags(text, b, bearerIPFamilyNames)
+}
+
+// Name returns the BearerIPMethod's name as reported by mmcli.
+func (b BearerIPMethod) Name() string { return enumName(b, bearerIPMethodNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (b BearerIPMethod) MarshalText() ([]byte, error) { return []byte(b.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (b *BearerIPMethod) UnmarshalText(text []byte) error {
+	return parseEnum(text, b, bearerIPMethodNames)
+}
+
+// Name returns the BearerType's name as reported by mmcli.
+func (b BearerType) Name() string { return enumName(b, bearerTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (b BearerType) MarshalText() ([]byte, error) { return []byte(b.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (b *BearerType) UnmarshalText(text []byte) error { return parseEnum(text, b, bearerTypeNames) }
+
+// Name returns the CDMAActivationError's name as reported by mmcli.
+func (c CDMAActivationError) Name() string { return enumName(c, cdmaActivationErrorNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CDMAActivationError) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CDMAActivationError) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, cdmaActivationErrorNames)
+}
+
+// Name returns the CDMAActivationState's name as reported by mmcli.
+func (c CDMAActivationState) Name() string { return enumName(c, cdmaActivationStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CDMAActivationState) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CDMAActivationState) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, cdmaActivationStateNames)
+}
+
+// Name returns the CallDirection's name as reported by mmcli.
+func (c CallDirection) Name() string { return enumName(c, callDirectionNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CallDirection) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CallDirection) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, callDirectionNames)
+}
+
+// Name returns the CallState's name as reported by mmcli.
+func (c CallState) Name() string { return enumName(c, callStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CallState) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CallState) UnmarshalText(text []byte) error { return parseEnum(text, c, callStateNames) }
+
+// Name returns the CallStateReason's name as reported by mmcli.
+func (c CallStateReason) Name() string { return enumName(c, callStateReasonNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CallStateReason) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CallStateReason) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, callStateReasonNames)
+}
+
+// Name returns the CellBroadcastState's name as reported by mmcli.
+func (c CellBroadcastState) Name() string { return enumName(c, cellBroadcastStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CellBroadcastState) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CellBroadcastState) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, cellBroadcastStateNames)
+}
+
+// Name returns the CellType's name as reported by mmcli.
+func (c CellType) Name() string { return enumName(c, cellTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c CellType) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *CellType) UnmarshalText(text []byte) error { return parseEnum(text, c, cellTypeNames) }
+
+// Name returns the ConnectStep's name in the style of mmcli.
+func (c ConnectStep) Name() string { return enumName(c, connectStepNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c ConnectStep) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *ConnectStep) UnmarshalText(text []byte) error { return parseEnum(text, c, connectStepNames) }
+
+// Name returns the ConnectionStatus's name in the style of mmcli.
+func (c ConnectionStatus) Name() string { return enumName(c, connectionStatusNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (c ConnectionStatus) MarshalText() ([]byte, error) { return []byte(c.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (c *ConnectionStatus) UnmarshalText(text []byte) error {
+	return parseEnum(text, c, connectionStatusNames)
+}
+
+// Name returns the DRXCycle's name as reported by mmcli.
+func (d DRXCycle) Name() string { return enumName(d, drxCycleNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (d DRXCycle) MarshalText() ([]byte, error) { return []byte(d.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (d *DRXCycle) UnmarshalText(text []byte) error { return parseEnum(text, d, drxCycleNames) }
+
+// Name returns the DeliveryStatus's name in the style of mmcli.
+func (d DeliveryStatus) Name() string { return enumName(d, deliveryStatusNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (d DeliveryStatus) MarshalText() ([]byte, error) { return []byte(d.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (d *DeliveryStatus) UnmarshalText(text []byte) error {
+	return parseEnum(text, d, deliveryStatusNames)
+}
+
+// Name returns the ESIMStatus's name as reported by mmcli.
+func (e ESIMStatus) Name() string { return enumName(e, esimStatusNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (e ESIMStatus) MarshalText() ([]byte, error) { return []byte(e.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (e *ESIMStatus) UnmarshalText(text []byte) error { return parseEnum(text, e, esimStatusNames) }
+
+// Name returns the ErrorDomain's name in the style of mmcli.
+func (e ErrorDomain) Name() string { return enumName(e, errorDomainNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (e ErrorDomain) MarshalText() ([]byte, error) { return []byte(e.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (e *ErrorDomain) UnmarshalText(text []byte) error { return parseEnum(text, e, errorDomainNames) }
+
+// Name returns the FacilityLock's name as reported by mmcli.
+func (f FacilityLock) Name() string { return flagsName(f, facilityLockNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (f FacilityLock) MarshalText() ([]byte, error) { return []byte(f.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (f *FacilityLock) UnmarshalText(text []byte) error {
+	return parseFlags(text, f, facilityLockNames)
+}
+
+// Name returns the FirmwareImageType's name as reported by mmcli.
+func (f FirmwareImageType) Name() string { return enumName(f, firmwareImageTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (f FirmwareImageType) MarshalText() ([]byte, error) { return []byte(f.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (f *FirmwareImageType) UnmarshalText(text []byte) error {
+	return parseEnum(text, f, firmwareImageTypeNames)
+}
+
+// Name returns the LocationSource's name as reported by mmcli.
+func (l LocationSource) Name() string { return flagsName(l, locationSourceNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (l LocationSource) MarshalText() ([]byte, error) { return []byte(l.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (l *LocationSource) UnmarshalText(text []byte) error {
+	return parseFlags(text, l, locationSourceNames)
+}
+
+// Name returns the Lock's name as reported by mmcli.
+func (l Lock) Name() string { return enumName(l, lockNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (l Lock) MarshalText() ([]byte, error) { return []byte(l.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (l *Lock) UnmarshalText(text []byte) error { return parseEnum(text, l, lockNames) }
+
+// Name returns the MICOMode's name as reported by mmcli.
+func (m MICOMode) Name() string { return enumName(m, micoModeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (m MICOMode) MarshalText() ([]byte, error) { return []byte(m.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (m *MICOMode) UnmarshalText(text []byte) error { return parseEnum(text, m, micoModeNames) }
+
+// Name returns the NetworkError's name as reported by mmcli.
+func (n NetworkError) Name() string { return enumName(n, networkErrorNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (n NetworkError) MarshalText() ([]byte, error) { return []byte(n.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (n *NetworkError) UnmarshalText(text []byte) error { return parseEnum(text, n, networkErrorNames) }
+
+// Name returns the OMAFeature's name as reported by mmcli.
+func (o OMAFeature) Name() string { return flagsName(o, omaFeatureNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (o OMAFeature) MarshalText() ([]byte, error) { return []byte(o.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (o *OMAFeature) UnmarshalText(text []byte) error { return parseFlags(text, o, omaFeatureNames) }
+
+// Name returns the OMASessionState's name as reported by mmcli.
+func (o OMASessionState) Name() string { return enumName(o, omaSessionStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (o OMASessionState) MarshalText() ([]byte, error) { return []byte(o.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (o *OMASessionState) UnmarshalText(text []byte) error {
+	return parseEnum(text, o, omaSessionStateNames)
+}
+
+// Name returns the OMASessionStateFailedReason's name as reported by mmcli.
+func (o OMASessionStateFailedReason) Name() string {
+	return enumName(o, omaSessionStateFailedReasonNames)
+}
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (o OMASessionStateFailedReason) MarshalText() ([]byte, error) { return []byte(o.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (o *OMASessionStateFailedReason) UnmarshalText(text []byte) error {
+	return parseEnum(text, o, omaSessionStateFailedReasonNames)
+}
+
+// Name returns the OMASessionType's name as reported by mmcli.
+func (o OMASessionType) Name() string { return enumName(o, omaSessionTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (o OMASessionType) MarshalText() ([]byte, error) { return []byte(o.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (o *OMASessionType) UnmarshalText(text []byte) error {
+	return parseEnum(text, o, omaSessionTypeNames)
+}
+
+// Name returns the PacketServiceState's name as reported by mmcli.
+func (p PacketServiceState) Name() string { return enumName(p, packetServiceStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (p PacketServiceState) MarshalText() ([]byte, error) { return []byte(p.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (p *PacketServiceState) UnmarshalText(text []byte) error {
+	return parseEnum(text, p, packetServiceStateNames)
+}
+
+// Name returns the PortType's name as reported by mmcli.
+func (p PortType) Name() string { return enumName(p, portTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (p PortType) MarshalText() ([]byte, error) { return []byte(p.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (p *PortType) UnmarshalText(text []byte) error { return parseEnum(text, p, portTypeNames) }
+
+// Name returns the PowerState's name as reported by mmcli.
+func (p PowerState) Name() string { return enumName(p, powerStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (p PowerState) MarshalText() ([]byte, error) { return []byte(p.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (p *PowerState) UnmarshalText(text []byte) error { return parseEnum(text, p, powerStateNames) }
+
+// Name returns the RecoveryAction's name in the style of mmcli.
+func (r RecoveryAction) Name() string { return enumName(r, recoveryActionNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (r RecoveryAction) MarshalText() ([]byte, error) { return []byte(r.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (r *RecoveryAction) UnmarshalText(text []byte) error {
+	return parseEnum(text, r, recoveryActionNames)
+}
+
+// Name returns the RegistrationState3GPP's name as reported by mmcli.
+func (r RegistrationState3GPP) Name() string { return enumName(r, registrationState3GPPNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (r RegistrationState3GPP) MarshalText() ([]byte, error) { return []byte(r.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (r *RegistrationState3GPP) UnmarshalText(text []byte) error {
+	return parseEnum(text, r, registrationState3GPPNames)
+}
+
+// Name returns the SIMRemovability's name as reported by mmcli.
+func (s SIMRemovability) Name() string { return enumName(s, simRemovabilityNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SIMRemovability) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SIMRemovability) UnmarshalText(text []byte) error {
+	return parseEnum(text, s, simRemovabilityNames)
+}
+
+// Name returns the SIMType's name as reported by mmcli.
+func (s SIMType) Name() string { return enumName(s, simTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SIMType) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SIMType) UnmarshalText(text []byte) error { return parseEnum(text, s, simTypeNames) }
+
+// Name returns the SMSCDMATeleserviceID's name as reported by mmcli.
+func (s SMSCDMATeleserviceID) Name() string { return enumName(s, smsCDMATeleserviceIDNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSCDMATeleserviceID) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSCDMATeleserviceID) UnmarshalText(text []byte) error {
+	return parseEnum(text, s, smsCDMATeleserviceIDNames)
+}
+
+// Name returns the SMSDeliveryState's name as reported by mmcli.
+func (s SMSDeliveryState) Name() string { return enumName(s, smsDeliveryStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSDeliveryState) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSDeliveryState) UnmarshalText(text []byte) error {
+	return parseEnum(text, s, smsDeliveryStateNames)
+}
+
+// Name returns the SMSPDUType's name as reported by mmcli.
+func (s SMSPDUType) Name() string { return enumName(s, smsPDUTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSPDUType) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSPDUType) UnmarshalText(text []byte) error { return parseEnum(text, s, smsPDUTypeNames) }
+
+// Name returns the SMSState's name as reported by mmcli.
+func (s SMSState) Name() string { return enumName(s, smsStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSState) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSState) UnmarshalText(text []byte) error { return parseEnum(text, s, smsStateNames) }
+
+// Name returns the SMSStorage's name as reported by mmcli.
+func (s SMSStorage) Name() string { return enumName(s, smsStorageNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSStorage) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSStorage) UnmarshalText(text []byte) error { return parseEnum(text, s, smsStorageNames) }
+
+// Name returns the SMSValidityType's name as reported by mmcli.
+func (s SMSValidityType) Name() string { return enumName(s, smsValidityTypeNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s SMSValidityType) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *SMSValidityType) UnmarshalText(text []byte) error {
+	return parseEnum(text, s, smsValidityTypeNames)
+}
+
+// Name returns the State's name as reported by mmcli.
+func (s State) Name() string { return enumName(s, stateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s State) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *State) UnmarshalText(text []byte) error { return parseEnum(text, s, stateNames) }
+
+// Name returns the StateChangeReason's name as reported by mmcli.
+func (s StateChangeReason) Name() string { return enumName(s, stateChangeReasonNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (s StateChangeReason) MarshalText() ([]byte, error) { return []byte(s.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (s *StateChangeReason) UnmarshalText(text []byte) error {
+	return parseEnum(text, s, stateChangeReasonNames)
+}
+
+// Name returns the USSDState's name as reported by mmcli.
+func (u USSDState) Name() string { return enumName(u, ussdStateNames) }
+
+// MarshalText implements encoding.TextMarshaler using Name.
+func (u USSDState) MarshalText() ([]byte, error) { return []byte(u.Name()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler for the output of
+// MarshalText.
+func (u *USSDState) UnmarshalText(text []byte) error { return parseEnum(text, u, ussdStateNames) }
+
+// Names for each type's values, as reported by mmcli. Values which are not
+// reported by ModemManager use names in the same style.
+var (
+	accessTechnologyNames = map[AccessTechnology]string{
+		AccessTechnologyUnknown:    "unknown",
+		AccessTechnologyPOTS:       "pots",
+		AccessTechnologyGSM:        "gsm",
+		AccessTechnologyGSMCompact: "gsm-compact",
+		AccessTechnologyGPRS:       "gprs",
+		AccessTechnologyEDGE:       "edge",
+		AccessTechnologyUMTS:       "umts",
+		AccessTechnologyHSDPA:      "hsdpa",
+		AccessTechnologyHSUPA:      "hsupa",
+		AccessTechnologyHSPA:       "hspa",
+		AccessTechnologyHSPAPlus:   "hspa-plus",
+		AccessTechnology1xRTT:      "1xrtt",
+		AccessTechnologyEVDO0:      "evdo0",
+		AccessTechnologyEVDOA:      "evdoa",
+		AccessTechnologyEVDOB:      "evdob",
+		AccessTechnologyLTE:        "lte",
+		AccessTechnologyNR5G:       "5gnr",
+		AccessTechnologyLTECatM:    "lte-cat-m",
+		AccessTechnologyLTENBIoT:   "lte-nb-iot",
+		AccessTechnologyAny:        "any",
+	}
+
+	assistanceDataTypeNames = map[AssistanceDataType]string{
+		AssistanceDataTypeNone: "none",
+		AssistanceDataTypeXTRA: "xtra",
+	}
+
+	attachmentNames = map[Attachment]string{
+		AttachmentNone:    "none",
+		AttachmentLegacy:  "legacy",
+		AttachmentLTE:     "lte",
+		AttachmentNR5GNSA: "5gnr-nsa",
+		AttachmentNR5GSA:  "5gnr-sa",
+	}
+
+	bearerAllowedAuthNames = map[BearerAllowedAuth]string{
+		BearerAllowedAuthUnknown:  "unknown",
+		BearerAllowedAuthNone:     "none",
+		BearerAllowedAuthPAP:      "pap",
+		BearerAllowedAuthCHAP:     "chap",
+		BearerAllowedAuthMSCHAP:   "mschap",
+		BearerAllowedAuthMSCHAPv2: "mschapv2",
+		BearerAllowedAuthEAP:      "eap",
+	}
+
+	bearerIPFamilyNames = map[BearerIPFamily]string{
+		BearerIPFamilyNone:   "none",
+		BearerIPFamilyIPv4:   "ipv4",
+		BearerIPFamilyIPv6:   "ipv6",
+		BearerIPFamilyIPv4v6: "ipv4v6",
+		BearerIPFamilyNonIP:  "non-ip",
+		BearerIPFamilyAny:    "any",
+	}
+
+	bearerIPMethodNames = map[BearerIPMethod]string{
+		BearerIPMethodUnknown: "unknown",
+		BearerIPMethodPPP:     "ppp",
+		BearerIPMethodStatic:  "static",
+		BearerIPMethodDHCP:    "dhcp",
+	}
+
+	bearerTypeNames = map[BearerType]string{
+		BearerTypeUnknown:       "unknown",
+		BearerTypeDefault:       "default",
+		BearerTypeDefaultAttach: "default-attach",
+		BearerTypeDedicated:     "dedicated",
+	}
+
+	cdmaActivationErrorNames = map[CDMAActivationError]string{
+		CDMAActivationErrorNone:                         "none",
+		CDMAActivationErrorUnknown:                      "unknown",
+		CDMAActivationErrorRoaming:                      "roaming",
+		CDMAActivationErrorWrongRadioInterface:          "wrong-radio-interface",
+		CDMAActivationErrorCouldNotConnect:              "could-not-connect",
+		CDMAActivationErrorSecurityAuthenticationFailed: "security-authentication-failed",
+		CDMAActivationErrorProvisioningFailed:           "provisioning-failed",
+		CDMAActivationErrorNoSignal:                     "no-signal",
+		CDMAActivationErrorTimedOut:                     "timed-out",
+		CDMAActivationErrorStartFailed:                  "start-failed",
+	}
+
+	cdmaActivationStateNames = map[CDMAActivationState]string{
+		CDMAActivationStateUnknown:            "unknown",
+		CDMAActivationStateNotActivated:       "not-activated",
+		CDMAActivationStateActivating:         "activating",
+		CDMAActivationStatePartiallyActivated: "partially-activated",
+		CDMAActivationStateActivated:          "activated",
+	}
+
+	callDirectionNames = map[CallDirection]string{
+		CallDirectionUnknown:  "unknown",
+		CallDirectionIncoming: "incoming",
+		CallDirectionOutgoing: "outgoing",
+	}
+
+	callStateNames = map[CallState]string{
+		CallStateUnknown:    "unknown",
+		CallStateDialing:    "dialing",
+		CallStateRingingOut: "ringing-out",
+		CallStateRingingIn:  "ringing-in",
+		CallStateActive:     "active",
+		CallStateHeld:       "held",
+		CallStateWaiting:    "waiting",
+		CallStateTerminated: "terminated",
+	}
+
+	callStateReasonNames = map[CallStateReason]string{
+		CallStateReasonUnknown:          "unknown",
+		CallStateReasonOutgoingStarted:  "outgoing-started",
+		CallStateReasonIncomingNew:      "incoming-new",
+		CallStateReasonAccepted:         "accepted",
+		CallStateReasonTerminated:       "terminated",
+		CallStateReasonRefusedOrBusy:    "refused-or-busy",
+		CallStateReasonError:            "error",
+		CallStateReasonAudioSetupFailed: "audio-setup-failed",
+		CallStateReasonTransferred:      "transferred",
+		CallStateReasonDeflected:        "deflected",
+	}
+
+	cellBroadcastStateNames = map[CellBroadcastState]string{
+		CellBroadcastStateUnknown:   "unknown",
+		CellBroadcastStateReceiving: "receiving",
+		CellBroadcastStateReceived:  "received",
+	}
+
+	cellTypeNames = map[CellType]string{
+		CellTypeUnknown: "unknown",
+		CellTypeCDMA:    "cdma",
+		CellTypeGSM:     "gsm",
+		CellTypeUMTS:    "umts",
+		CellTypeTDSCDMA: "tdscdma",
+		CellTypeLTE:     "lte",
+		CellTypeNR5G:    "5gnr",
+	}
+
+	connectStepNames = map[ConnectStep]string{
+		ConnectStepUnknown:       "unknown",
+		ConnectStepUnlock:        "unlock",
+		ConnectStepEnable:        "enable",
+		ConnectStepRegister:      "register",
+		ConnectStepConnect:       "connect",
+		ConnectStepWaitConnected: "wait-connected",
+	}
+
+	connectionStatusNames = map[ConnectionStatus]string{
+		ConnectionStatusUnknown:      "unknown",
+		ConnectionStatusConnecting:   "connecting",
+		ConnectionStatusConnected:    "connected",
+		ConnectionStatusDisconnected: "disconnected",
+		ConnectionStatusFailed:       "failed",
+	}
+
+	drxCycleNames = map[DRXCycle]string{
+		DRXCycleUnknown:     "unknown",
+		DRXCycleUnsupported: "unsupported",
+		DRXCycle32:          "32",
+		DRXCycle64:          "64",
+		DRXCycle128:         "128",
+		DRXCycle256:         "256",
+	}
+
+	deliveryStatusNames = map[DeliveryStatus]string{
+		DeliveryStatusPending:   "pending",
+		DeliveryStatusDelivered: "delivered",
+		DeliveryStatusFailed:    "failed",
+	}
+
+	esimStatusNames = map[ESIMStatus]string{
+		ESIMStatusUnknown:      "unknown",
+		ESIMStatusNoProfiles:   "no-profiles",
+		ESIMStatusWithProfiles: "with-profiles",
+	}
+
+	errorDomainNames = map[ErrorDomain]string{
+		ErrorDomainUnknown:         "unknown",
+		ErrorDomainCore:            "core",
+		ErrorDomainMobileEquipment: "mobile-equipment",
+		ErrorDomainConnection:      "connection",
+		ErrorDomainSerial:          "serial",
+		ErrorDomainMessage:         "message",
+		ErrorDomainCDMAActivation:  "cdma-activation",
+	}
+
+	facilityLockNames = map[FacilityLock]string{
+		FacilityLockNone:         "none",
+		FacilityLockSIM:          "sim",
+		FacilityLockFixedDialing: "fixed-dialing",
+		FacilityLockPHSIM:        "ph-sim",
+		FacilityLockPHFSIM:       "ph-fsim",
+		FacilityLockNetPers:      "net-pers",
+		FacilityLockNetSubPers:   "net-sub-pers",
+		FacilityLockProviderPers: "provider-pers",
+		FacilityLockCorpPers:     "corp-pers",
+	}
+
+	firmwareImageTypeNames = map[FirmwareImageType]string{
+		FirmwareImageTypeUnknown: "unknown",
+		FirmwareImageTypeGeneric: "generic",
+		FirmwareImageTypeGobi:    "gobi",
+	}
+
+	locationSourceNames = map[LocationSource]string{
+		LocationSourceNone:         "none",
+		LocationSource3GPPLACCI:    "3gpp-lac-ci",
+		LocationSourceGPSRaw:       "gps-raw",
+		LocationSourceGPSNMEA:      "gps-nmea",
+		LocationSourceCDMABS:       "cdma-bs",
+		LocationSourceGPSUnmanaged: "gps-unmanaged",
+		LocationSourceAGPSMSA:      "agps-msa",
+		LocationSourceAGPSMSB:      "agps-msb",
+	}
+
+	lockNames = map[Lock]string{
+		LockUnknown:     "unknown",
+		LockNone:        "none",
+		LockSIMPIN:      "sim-pin",
+		LockSIMPIN2:     "sim-pin2",
+		LockSIMPUK:      "sim-puk",
+		LockSIMPUK2:     "sim-puk2",
+		LockPHSPPIN:     "ph-sp-pin",
+		LockPHSPPUK:     "ph-sp-puk",
+		LockPHNetPIN:    "ph-net-pin",
+		LockPHNetPUK:    "ph-net-puk",
+		LockPHSIMPIN:    "ph-sim-pin",
+		LockPHCorpPIN:   "ph-corp-pin",
+		LockPHCorpPUK:   "ph-corp-puk",
+		LockPHFSIMPIN:   "ph-fsim-pin",
+		LockPHFSIMPUK:   "ph-fsim-puk",
+		LockPHNetSubPIN: "ph-netsub-pin",
+		LockPHNetSubPUK: "ph-netsub-puk",
+	}
+
+	micoModeNames = map[MICOMode]string{
+		MICOModeUnknown:     "unknown",
+		MICOModeUnsupported: "unsupported",
+		MICOModeDisabled:    "disabled",
+		MICOModeEnabled:     "enabled",
+	}
+
+	networkErrorNames = map[NetworkError]string{
+		NetworkErrorNone:                                        "none",
+		NetworkErrorIMSIUnknownInHLR:                            "imsi-unknown-in-hlr",
+		NetworkErrorIllegalMS:                                   "illegal-ms",
+		NetworkErrorIMSIUnknownInVLR:                            "imsi-unknown-in-vlr",
+		NetworkErrorIMEINotAccepted:                             "imei-not-accepted",
+		NetworkErrorIllegalME:                                   "illegal-me",
+		NetworkErrorGPRSNotAllowed:                              "gprs-not-allowed",
+		NetworkErrorGPRSAndNonGPRSNotAllowed:                    "gprs-and-non-gprs-not-allowed",
+		NetworkErrorMSIdentityNotDerivedByNetwork:               "ms-identity-not-derived-by-network",
+		NetworkErrorImplicitlyDetached:                          "implicitly-detached",
+		NetworkErrorPLMNNotAllowed:                              "plmn-not-allowed",
+		NetworkErrorLocationAreaNotAllowed:                      "location-area-not-allowed",
+		NetworkErrorRoamingNotAllowedInLocationArea:             "roaming-not-allowed-in-location-area",
+		NetworkErrorGPRSNotAllowedInPLMN:                        "gprs-not-allowed-in-plmn",
+		NetworkErrorNoCellsInLocationArea:                       "no-cells-in-location-area",
+		NetworkErrorMSCTemporarilyNotReachable:                  "msc-temporarily-not-reachable",
+		NetworkErrorNetworkFailure:                              "network-failure",
+		NetworkErrorCSDomainNotAvailable:                        "cs-domain-not-available",
+		NetworkErrorESMFailure:                                  "esm-failure",
+		NetworkErrorMACFailure:                                  "mac-failure",
+		NetworkErrorSynchFailure:                                "synch-failure",
+		NetworkErrorCongestion:                                  "congestion",
+		NetworkErrorGSMAuthenticationUnacceptable:               "gsm-authentication-unacceptable",
+		NetworkErrorNotAuthorizedForCSG:                         "not-authorized-for-csg",
+		NetworkErrorInsufficientResources:                       "insufficient-resources",
+		NetworkErrorMissingOrUnknownAPN:                         "missing-or-unknown-apn",
+		NetworkErrorUnknownPDPAddressOrType:                     "unknown-pdp-address-or-type",
+		NetworkErrorUserAuthenticationFailed:                    "user-authentication-failed",
+		NetworkErrorActivationRejectedByGGSNOrGW:                "activation-rejected-by-ggsn-or-gw",
+		NetworkErrorActivationRejectedUnspecified:               "activation-rejected-unspecified",
+		NetworkErrorServiceOptionNotSupported:                   "service-option-not-supported",
+		NetworkErrorRequestedServiceOptionNotSubscribed:         "requested-service-option-not-subscribed",
+		NetworkErrorServiceOptionTemporarilyOutOfOrder:          "service-option-temporarily-out-of-order",
+		NetworkErrorNoPDPContextActivated:                       "no-pdp-context-activated",
+		NetworkErrorSemanticErrorInTheTFTOperation:              "semantic-error-in-the-tft-operation",
+		NetworkErrorSyntacticalErrorInTheTFTOperation:           "syntactical-error-in-the-tft-operation",
+		NetworkErrorUnknownPDPContext:                           "unknown-pdp-context",
+		NetworkErrorSemanticErrorsInPacketFilter:                "semantic-errors-in-packet-filter",
+		NetworkErrorSyntacticalErrorInPacketFilter:              "syntactical-error-in-packet-filter",
+		NetworkErrorPDPContextWithoutTFTAlreadyActivated:        "pdp-context-without-tft-already-activated",
+		NetworkErrorMulticastGroupMembershipTimeout:             "multicast-group-membership-timeout",
+		NetworkErrorRequestRejectedBCMViolation:                 "request-rejected-bcm-violation",
+		NetworkErrorLastPDNDisconnectionNotAllowed:              "last-pdn-disconnection-not-allowed",
+		NetworkErrorPDPTypeIPv4OnlyAllowed:                      "pdp-type-ipv4-only-allowed",
+		NetworkErrorPDPTypeIPv6OnlyAllowed:                      "pdp-type-ipv6-only-allowed",
+		NetworkErrorMaximumNumberOfPDPContextsReached:           "maximum-number-of-pdp-contexts-reached",
+		NetworkErrorRequestedAPNNotSupportedInCurrentRATAndPLMN: "requested-apn-not-supported-in-current-rat-and-plmn",
+		NetworkErrorInvalidTransactionIdentifierValue:           "invalid-transaction-identifier-value",
+		NetworkErrorSemanticallyIncorrectMessage:                "semantically-incorrect-message",
+		NetworkErrorInvalidMandatoryInformation:                 "invalid-mandatory-information",
+		NetworkErrorMessageTypeNonExistent:                      "message-type-non-existent",
+		NetworkErrorMessageTypeNotCompatible:                    "message-type-not-compatible",
+		NetworkErrorIENonExistent:                               "ie-non-existent",
+		NetworkErrorConditionalIEError:                          "conditional-ie-error",
+		NetworkErrorMessageNotCompatible:                        "message-not-compatible",
+		NetworkErrorProtocolErrorUnspecified:                    "protocol-error-unspecified",
+		NetworkErrorAPNRestrictionValueIncompatible:             "apn-restriction-value-incompatible",
+		NetworkErrorMultipleAccessesToPLMNNotAllowed:            "multiple-accesses-to-plmn-not-allowed",
+	}
+
+	omaFeatureNames = map[OMAFeature]string{
+		OMAFeatureNone:                "none",
+		OMAFeatureDeviceProvisioning:  "device-provisioning",
+		OMAFeaturePRLUpdate:           "prl-update",
+		OMAFeatureHandsFreeActivation: "hands-free-activation",
+	}
+
+	omaSessionStateNames = map[OMASessionState]string{
+		OMASessionStateFailed:               "failed",
+		OMASessionStateUnknown:              "unknown",
+		OMASessionStateStarted:              "started",
+		OMASessionStateRetrying:             "retrying",
+		OMASessionStateConnecting:           "connecting",
+		OMASessionStateConnected:            "connected",
+		OMASessionStateAuthenticated:        "authenticated",
+		OMASessionStateMDNDownloaded:        "mdn-downloaded",
+		OMASessionStateMSIDDownloaded:       "msid-downloaded",
+		OMASessionStatePRLDownloaded:        "prl-downloaded",
+		OMASessionStateMIPProfileDownloaded: "mip-profile-downloaded",
+		OMASessionStateCompleted:            "completed",
+	}
+
+	omaSessionStateFailedReasonNames = map[OMASessionStateFailedReason]string{
+		OMASessionStateFailedReasonUnknown:              "unknown",
+		OMASessionStateFailedReasonNetworkUnavailable:   "network-unavailable",
+		OMASessionStateFailedReasonServerUnavailable:    "server-unavailable",
+		OMASessionStateFailedReasonAuthenticationFailed: "authentication-failed",
+		OMASessionStateFailedReasonMaxRetryExceeded:     "max-retry-exceeded",
+		OMASessionStateFailedReasonSessionCancelled:     "session-cancelled",
+	}
+
+	omaSessionTypeNames = map[OMASessionType]string{
+		OMASessionTypeUnknown:                            "unknown",
+		OMASessionTypeClientInitiatedDeviceConfigure:     "client-initiated-device-configure",
+		OMASessionTypeClientInitiatedPRLUpdate:           "client-initiated-prl-update",
+		OMASessionTypeClientInitiatedHandsFreeActivation: "client-initiated-hands-free-activation",
+		OMASessionTypeNetworkInitiatedDeviceConfigure:    "network-initiated-device-configure",
+		OMASessionTypeNetworkInitiatedPRLUpdate:          "network-initiated-prl-update",
+		OMASessionTypeDeviceInitiatedPRLUpdate:           "device-initiated-prl-update",
+		OMASessionTypeDeviceInitiatedHandsFreeActivation: "device-initiated-hands-free-activation",
+	}
+
+	packetServiceStateNames = map[PacketServiceState]string{
+		PacketServiceStateUnknown:  "unknown",
+		PacketServiceStateDetached: "detached",
+		PacketServiceStateAttached: "attached",
+	}
+
+	portTypeNames = map[PortType]string{
+		PortTypeUnknown: "unknown",
+		PortTypeNet:     "net",
+		PortTypeAT:      "at",
+		PortTypeQCDM:    "qcdm",
+		PortTypeGPS:     "gps",
+		PortTypeQMI:     "qmi",
+		PortTypeMBIM:    "mbim",
+		PortTypeAudio:   "audio",
+	}
+
+	powerStateNames = map[PowerState]string{
+		PowerStateUnknown: "unknown",
+		PowerStateOff:     "off",
+		PowerStateLow:     "low",
+		PowerStateOn:      "on",
+	}
+
+	recoveryActionNames = map[RecoveryAction]string{
+		RecoveryActionUnknown:    "unknown",
+		RecoveryActionDisconnect: "disconnect",
+		RecoveryActionReenable:   "reenable",
+		RecoveryActionReset:      "reset",
+	}
+
+	registrationState3GPPNames = map[RegistrationState3GPP]string{
+		RegistrationState3GPPIdle:                    "idle",
+		RegistrationState3GPPHome:                    "home",
+		RegistrationState3GPPSearching:               "searching",
+		RegistrationState3GPPDenied:                  "denied",
+		RegistrationState3GPPUnknown:                 "unknown",
+		RegistrationState3GPPRoaming:                 "roaming",
+		RegistrationState3GPPHomeSMSOnly:             "home-sms-only",
+		RegistrationState3GPPRoamingSMSOnly:          "roaming-sms-only",
+		RegistrationState3GPPEmergencyOnly:           "emergency-only",
+		RegistrationState3GPPHomeCSFBNotPreferred:    "home-csfb-not-preferred",
+		RegistrationState3GPPRoamingCSFBNotPreferred: "roaming-csfb-not-preferred",
+		RegistrationState3GPPAttachedRLOS:            "attached-rlos",
+	}
+
+	simRemovabilityNames = map[SIMRemovability]string{
+		SIMRemovabilityUnknown:      "unknown",
+		SIMRemovabilityRemovable:    "removable",
+		SIMRemovabilityNotRemovable: "not-removable",
+	}
+
+	simTypeNames = map[SIMType]string{
+		SIMTypeUnknown:  "unknown",
+		SIMTypePhysical: "physical",
+		SIMTypeESIM:     "esim",
+	}
+
+	smsCDMATeleserviceIDNames = map[SMSCDMATeleserviceID]string{
+		SMSCDMATeleserviceIDUnknown: "unknown",
+		SMSCDMATeleserviceIDCMT91:   "cmt91",
+		SMSCDMATeleserviceIDWPT:     "wpt",
+		SMSCDMATeleserviceIDWMT:     "wmt",
+		SMSCDMATeleserviceIDVMN:     "vmn",
+		SMSCDMATeleserviceIDWAP:     "wap",
+		SMSCDMATeleserviceIDWEMT:    "wemt",
+		SMSCDMATeleserviceIDSCPT:    "scpt",
+		SMSCDMATeleserviceIDCATPT:   "catpt",
+	}
+
+	smsDeliveryStateNames = map[SMSDeliveryState]string{
+		SMSDeliveryStateCompletedReceived:                    "completed-received",
+		SMSDeliveryStateCompletedForwardedUnconfirmed:        "completed-forwarded-unconfirmed",
+		SMSDeliveryStateCompletedReplacedBySC:                "completed-replaced-by-sc",
+		SMSDeliveryStateTemporaryErrorCongestion:             "temporary-error-congestion",
+		SMSDeliveryStateTemporaryErrorSMEBusy:                "temporary-error-sme-busy",
+		SMSDeliveryStateTemporaryErrorNoResponseFromSME:      "temporary-error-no-response-from-sme",
+		SMSDeliveryStateTemporaryErrorServiceRejected:        "temporary-error-service-rejected",
+		SMSDeliveryStateTemporaryErrorQoSNotAvailable:        "temporary-error-qos-not-available",
+		SMSDeliveryStateTemporaryErrorInSME:                  "temporary-error-in-sme",
+		SMSDeliveryStateErrorRemoteProcedure:                 "error-remote-procedure",
+		SMSDeliveryStateErrorIncompatibleDestination:         "error-incompatible-destination",
+		SMSDeliveryStateErrorConnectionRejectedBySME:         "error-connection-rejected-by-sme",
+		SMSDeliveryStateErrorNotObtainable:                   "error-not-obtainable",
+		SMSDeliveryStateErrorQoSNotAvailable:                 "error-qos-not-available",
+		SMSDeliveryStateErrorNoInterworkingAvailable:         "error-no-interworking-available",
+		SMSDeliveryStateErrorValidityPeriodExpired:           "error-validity-period-expired",
+		SMSDeliveryStateErrorDeletedByOriginatingSME:         "error-deleted-by-originating-sme",
+		SMSDeliveryStateErrorDeletedBySCAdministration:       "error-deleted-by-sc-administration",
+		SMSDeliveryStateErrorMessageDoesNotExist:             "error-message-does-not-exist",
+		SMSDeliveryStateTemporaryFatalErrorCongestion:        "temporary-fatal-error-congestion",
+		SMSDeliveryStateTemporaryFatalErrorSMEBusy:           "temporary-fatal-error-sme-busy",
+		SMSDeliveryStateTemporaryFatalErrorNoResponseFromSME: "temporary-fatal-error-no-response-from-sme",
+		SMSDeliveryStateTemporaryFatalErrorServiceRejected:   "temporary-fatal-error-service-rejected",
+		SMSDeliveryStateTemporaryFatalErrorQoSNotAvailable:   "temporary-fatal-error-qos-not-available",
+		SMSDeliveryStateTemporaryFatalErrorInSME:             "temporary-fatal-error-in-sme",
+		SMSDeliveryStateUnknown:                              "unknown",
+	}
+
+	smsPDUTypeNames = map[SMSPDUType]string{
+		SMSPDUTypeUnknown:                     "unknown",
+		SMSPDUTypeDeliver:                     "deliver",
+		SMSPDUTypeSubmit:                      "submit",
+		SMSPDUTypeStatusReport:                "status-report",
+		SMSPDUTypeCDMADeliver:                 "cdma-deliver",
+		SMSPDUTypeCDMASubmit:                  "cdma-submit",
+		SMSPDUTypeCDMACancellation:            "cdma-cancellation",
+		SMSPDUTypeCDMADeliveryAcknowledgement: "cdma-delivery-acknowledgement",
+		SMSPDUTypeCDMAUserAcknowledgement:     "cdma-user-acknowledgement",
+		SMSPDUTypeCDMAReadAcknowledgement:     "cdma-read-acknowledgement",
+	}
+
+	smsStateNames = map[SMSState]string{
+		SMSStateUnknown:   "unknown",
+		SMSStateStored:    "stored",
+		SMSStateReceiving: "receiving",
+		SMSStateReceived:  "received",
+		SMSStateSending:   "sending",
+		SMSStateSent:      "sent",
+	}
+
+	smsStorageNames = map[SMSStorage]string{
+		SMSStorageUnknown: "unknown",
+		SMSStorageSM:      "sm",
+		SMSStorageME:      "me",
+		SMSStorageMT:      "mt",
+		SMSStorageSR:      "sr",
+		SMSStorageBM:      "bm",
+		SMSStorageTA:      "ta",
+	}
+
+	smsValidityTypeNames = map[SMSValidityType]string{
+		SMSValidityTypeUnknown:  "unknown",
+		SMSValidityTypeRelative: "relative",
+		SMSValidityTypeAbsolute: "absolute",
+		SMSValidityTypeEnhanced: "enhanced",
+	}
+
+	stateNames = map[State]string{
+		StateFailed:        "failed",
+		StateUnknown:       "unknown",
+		StateInitializing:  "initializing",
+		StateLocked:        "locked",
+		StateDisabled:      "disabled",
+		StateDisabling:     "disabling",
+		StateEnabling:      "enabling",
+		StateEnabled:       "enabled",
+		StateSearching:     "searching",
+		StateRegistered:    "registered",
+		StateDisconnecting: "disconnecting",
+		StateConnecting:    "connecting",
+		StateConnected:     "connected",
+	}
+
+	stateChangeReasonNames = map[StateChangeReason]string{
+		StateChangeReasonUnknown:       "unknown",
+		StateChangeReasonUserRequested: "user-requested",
+		StateChangeReasonSuspend:       "suspend",
+		StateChangeReasonFailure:       "failure",
+	}
+
+	ussdStateNames = map[USSDState]string{
+		USSDStateUnknown:      "unknown",
+		USSDStateIdle:         "idle",
+		USSDStateActive:       "active",
+		USSDStateUserResponse: "user-response",
+	}
+)
@@ -0,0 +1,239 @@
+package modemmanager
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name string
+		v    interface{ Name() string }
+		want string
+	}{
+		{
+			name: "state",
+			v:    StateConnected,
+			want: "connected",
+		},
+		{
+			name: "port type",
+			v:    PortTypeMBIM,
+			want: "mbim",
+		},
+		{
+			name: "lock",
+			v:    LockSIMPIN2,
+			want: "sim-pin2",
+		},
+		{
+			name: "registration state",
+			v:    RegistrationState3GPPHomeSMSOnly,
+			want: "home-sms-only",
+		},
+		{
+			name: "delivery state",
+			v:    SMSDeliveryStateTemporaryErrorQoSNotAvailable,
+			want: "temporary-error-qos-not-available",
+		},
+		{
+			name: "network error",
+			v:    NetworkErrorPDPTypeIPv4OnlyAllowed,
+			want: "pdp-type-ipv4-only-allowed",
+		},
+		{
+			name: "unknown value",
+			v:    State(100),
+			want: "100",
+		},
+		{
+			name: "flags none",
+			v:    AccessTechnologyUnknown,
+			want: "unknown",
+		},
+		{
+			name: "flags single",
+			v:    AccessTechnologyNR5G,
+			want: "5gnr",
+		},
+		{
+			name: "flags multiple",
+			v:    AccessTechnologyLTE | AccessTechnologyUMTS | AccessTechnologyLTENBIoT,
+			want: "umts, lte, lte-nb-iot",
+		},
+		{
+			name: "flags any",
+			v:    AccessTechnologyAny,
+			want: "any",
+		},
+		{
+			name: "flags unknown bit",
+			v:    BearerAllowedAuthPAP | 1<<31,
+			want: "pap, 2147483648",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, tt.v.Name()); diff != "" {
+				t.Fatalf("unexpected name (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTextJSON(t *testing.T) {
+	type T struct {
+		State         State
+		Power         PowerState
+		Ports         []PortType
+		Method        BearerIPMethod
+		Access        AccessTechnology
+		UnlockRetries map[Lock]int
+	}
+
+	want := T{
+		State:         StateConnected,
+		Power:         PowerStateOn,
+		Ports:         []PortType{PortTypeAT, PortTypeMBIM},
+		Method:        BearerIPMethodDHCP,
+		Access:        AccessTechnologyUMTS | AccessTechnologyLTE,
+		UnlockRetries: map[Lock]int{LockSIMPIN: 3, LockSIMPUK: 10},
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("failed to marshal: %v", err)
+	}
+
+	const wantJSON = `{"State":"connected","Power":"on","Ports":["at","mbim"],"Method":"dhcp","Access":"umts, lte","UnlockRetries":{"sim-pin":3,"sim-puk":10}}`
+	if diff := cmp.Diff(wantJSON, string(b)); diff != "" {
+		t.Fatalf("unexpected JSON (-want +got):\n%s", diff)
+	}
+
+	var got T
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("failed to unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected round trip (-want +got):\n%s", diff)
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		v    interface{ UnmarshalText([]byte) error }
+		want interface{}
+		ok   bool
+	}{
+		{
+			name: "name",
+			text: "registered",
+			v:    new(State),
+			want: StateRegistered,
+			ok:   true,
+		},
+		{
+			name: "negative",
+			text: "-1",
+			v:    new(State),
+			want: StateFailed,
+			ok:   true,
+		},
+		{
+			name: "decimal",
+			text: "100",
+			v:    new(State),
+			want: State(100),
+			ok:   true,
+		},
+		{
+			name: "flags",
+			text: "pap, chap",
+			v:    new(BearerAllowedAuth),
+			want: BearerAllowedAuthPAP | BearerAllowedAuthCHAP,
+			ok:   true,
+		},
+		{
+			name: "flags decimal",
+			text: "pap, 2147483648",
+			v:    new(BearerAllowedAuth),
+			want: BearerAllowedAuthPAP | 1<<31,
+			ok:   true,
+		},
+		{
+			name: "bad name",
+			text: "Connected",
+			v:    new(State),
+		},
+		{
+			name: "bad flags",
+			text: "pap,chap",
+			v:    new(BearerAllowedAuth),
+		},
+		{
+			name: "out of range",
+			text: "-1",
+			v:    new(AccessTechnology),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.v.UnmarshalText([]byte(tt.text))
+			if tt.ok && err != nil {
+				t.Fatalf("failed to unmarshal: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				t.Logf("err: %v", err)
+				return
+			}
+
+			got := reflect.ValueOf(tt.v).Elem().Interface()
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected value (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNamesUnique(t *testing.T) {
+	// Each name must identify a single value of its type.
+	for _, names := range []interface{}{
+		accessTechnologyNames, assistanceDataTypeNames, attachmentNames,
+		bearerAllowedAuthNames, bearerIPFamilyNames, bearerIPMethodNames,
+		bearerTypeNames, cdmaActivationErrorNames, cdmaActivationStateNames,
+		callDirectionNames, callStateNames, callStateReasonNames,
+		cellBroadcastStateNames, cellTypeNames, connectStepNames,
+		connectionStatusNames, drxCycleNames, deliveryStatusNames,
+		esimStatusNames, errorDomainNames, facilityLockNames,
+		firmwareImageTypeNames, locationSourceNames, lockNames, micoModeNames,
+		networkErrorNames, omaFeatureNames, omaSessionStateNames,
+		omaSessionStateFailedReasonNames, omaSessionTypeNames,
+		packetServiceStateNames, portTypeNames, powerStateNames,
+		recoveryActionNames, registrationState3GPPNames, simRemovabilityNames,
+		simTypeNames, smsCDMATeleserviceIDNames, smsDeliveryStateNames,
+		smsPDUTypeNames, smsStateNames, smsStorageNames, smsValidityTypeNames,
+		stateNames, stateChangeReasonNames, ussdStateNames,
+	} {
+		v := reflect.ValueOf(names)
+		seen := make(map[string]bool)
+		for _, k := range v.MapKeys() {
+			n := v.MapIndex(k).String()
+			if seen[n] {
+				t.Fatalf("duplicate name %q for %s", n, v.Type())
+			}
+			seen[n] = true
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A NetworkTimezone is the timezone information reported by a Modem's
+// network. Values which the network does not report are zero.
+type NetworkTimezone struct {
+	// Offset is the offset of local time from UTC, including any daylight
+	// saving time adjustment.
+	Offset time.Duration
+
+	// DSTOffset is the daylight saving time adjustment included in Offset.
+	DSTOffset time.Duration
+
+	// LeapSeconds is the number of leap seconds between GPS time and UTC.
+	LeapSeconds int
+}
+
+// NetworkTimezone fetches the timezone information reported by the Modem's
+// network.
+func (m *Modem) NetworkTimezone(ctx context.Context) (*NetworkTimezone, error) {
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Time"),
+		"NetworkTimezone",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	vp := newValueParser(v)
+	ps := vp.Properties()
+	if err := vp.Err(); err != nil {
+		return nil, fmt.Errorf("failed to parse network timezone: %v", err)
+	}
+
+	return parseNetworkTimezone(ps)
+}
+
+// WatchNetworkTime watches for changes to the time reported by the Modem's
+// network, such as when the Modem registers with a network. Each time is
+// delivered on the returned channel, which is closed when the context is
+// canceled.
+func (m *Modem) WatchNetworkTime(ctx context.Context) (<-chan time.Time, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Time"),
+		"NetworkTimeChanged",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (time.Time, error) {
+		var str string
+		if err := dbus.Store(s.Body, &str); err != nil {
+			return time.Time{}, fmt.Errorf("error parsing network time change: %v", err)
+		}
+
+		return parseNetworkTime(str)
+	}), nil
+}
+
+// ParseModemTime parses an ISO 8601 timestamp string produced by a modem. In
+// addition to RFC 3339, the variants emitted by real modems and networks are
+// accepted:
+//   - truncated zone offsets, such as "2020-07-15T16:31:02+02" or
+//     "2020-07-15T16:31:02+0200"
+//   - missing seconds, such as "2020-07-15T16:31+02:00"
+//   - missing zone offsets, such as "2020-07-15T16:31:02", which are parsed
+//     as UTC
+func ParseModemTime(s string) (time.Time, error) {
+	for _, layout := range []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05Z07",
+		"2006-01-02T15:04:05Z0700",
+		"2006-01-02T15:04:05",
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02T15:04Z07",
+		"2006-01-02T15:04Z0700",
+		"2006-01-02T15:04",
+	} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid timestamp: %q", s)
+}
+
+// GetNetworkTimeRaw is like GetNetworkTime, but also returns the raw time
+// string reported by the Modem's network. If the time cannot be parsed, the
+// raw string is returned along with the error.
+func (m *Modem) GetNetworkTimeRaw(ctx context.Context) (time.Time, string, error) {
+	var v dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Time", "GetNetworkTime"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&v,
+	)
+	if err != nil {
+		return time.Time{}, "", toPermission(err)
+	}
+
+	vp := newValueParser(v)
+	str := vp.String()
+	if err := vp.Err(); err != nil {
+		return time.Time{}, "", err
+	}
+
+	t, err := parseNetworkTime(str)
+	if err != nil {
+		return time.Time{}, str, err
+	}
+
+	return t, str, nil
+}
+
+// Location returns a fixed zone *time.Location for the NetworkTimezone's
+// Offset, which includes any daylight saving time adjustment. The zone is
+// named after its offset, such as "UTC-04:00".
+func (tz *NetworkTimezone) Location() *time.Location {
+	offset := int(tz.Offset / time.Second)
+
+	sign := '+'
+	abs := offset
+	if offset < 0 {
+		sign = '-'
+		abs = -offset
+	}
+
+	name := fmt.Sprintf("UTC%c%02d:%02d", sign, abs/3600, abs%3600/60)
+	return time.FixedZone(name, offset)
+}
+
+// GetLocalNetworkTime fetches the current time from the Modem's network and
+// converts it to the local time of the network's timezone.
+func (m *Modem) GetLocalNetworkTime(ctx context.Context) (time.Time, error) {
+	t, err := m.GetNetworkTime(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	tz, err := m.NetworkTimezone(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return t.In(tz.Location()), nil
+}
+
+// parseNetworkTimezone parses a NetworkTimezone from a properties map.
+func parseNetworkTimezone(ps map[string]dbus.Variant) (*NetworkTimezone, error) {
+	var tz NetworkTimezone
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "offset":
+			tz.Offset = time.Duration(vp.Int()) * time.Minute
+		case "dst-offset":
+			tz.DSTOffset = time.Duration(vp.Int()) * time.Minute
+		case "leap-seconds":
+			tz.LeapSeconds = vp.Int()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &tz, nil
+}
@@ -0,0 +1,278 @@
+package modemmanager
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemNetworkTimezone(t *testing.T) {
+	m := &Modem{
+		c: &Client{get: func(_ context.Context, op dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Time", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("NetworkTimezone", prop); diff != "" {
+				t.Fatalf("unexpected property (-want +got):\n%s", diff)
+			}
+
+			return dbus.MakeVariant(map[string]dbus.Variant{
+				"offset":       dbus.MakeVariant(int32(-240)),
+				"dst-offset":   dbus.MakeVariant(int32(60)),
+				"leap-seconds": dbus.MakeVariant(int32(18)),
+			}), nil
+		}},
+	}
+
+	tz, err := m.NetworkTimezone(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get network timezone: %v", err)
+	}
+
+	want := &NetworkTimezone{
+		Offset:      -4 * time.Hour,
+		DSTOffset:   1 * time.Hour,
+		LeapSeconds: 18,
+	}
+
+	if diff := cmp.Diff(want, tz); diff != "" {
+		t.Fatalf("unexpected NetworkTimezone (-want +got):\n%s", diff)
+	}
+}
+
+func Test_parseNetworkTimezoneError(t *testing.T) {
+	_, err := parseNetworkTimezone(map[string]dbus.Variant{
+		"offset": dbus.MakeVariant("foo"),
+	})
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestModemWatchNetworkTime(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Time", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("NetworkTimeChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal and an invalid time which should be
+			// skipped, followed by a valid time.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{1}}
+			sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+			sigs <- &dbus.Signal{Body: []interface{}{"2020-07-15T16:31:02-04:00"}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	times, err := m.WatchNetworkTime(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch network time: %v", err)
+	}
+
+	var got []time.Time
+	for t := range times {
+		got = append(got, t)
+	}
+
+	want := []time.Time{
+		time.Date(2020, time.July, 15, 12, 31, 2, 0, time.FixedZone("EDT", -4*60*60)),
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected network times (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseModemTime(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want time.Time
+		ok   bool
+	}{
+		{
+			name: "invalid",
+			s:    "foo",
+		},
+		{
+			name: "RFC 3339",
+			s:    "2020-07-15T16:31:02-04:00",
+			want: time.Date(2020, time.July, 15, 16, 31, 2, 0, time.FixedZone("", -4*60*60)),
+			ok:   true,
+		},
+		{
+			name: "hours offset",
+			s:    "2020-07-15T16:31:02+02",
+			want: time.Date(2020, time.July, 15, 16, 31, 2, 0, time.FixedZone("", 2*60*60)),
+			ok:   true,
+		},
+		{
+			name: "UTC hours offset",
+			s:    "2020-07-15T16:31:02Z",
+			want: time.Date(2020, time.July, 15, 16, 31, 2, 0, time.UTC),
+			ok:   true,
+		},
+		{
+			name: "hours and minutes offset",
+			s:    "2020-07-15T16:31:02+0530",
+			want: time.Date(2020, time.July, 15, 16, 31, 2, 0, time.FixedZone("", 5*60*60+30*60)),
+			ok:   true,
+		},
+		{
+			name: "no seconds",
+			s:    "2020-07-15T16:31+01:00",
+			want: time.Date(2020, time.July, 15, 16, 31, 0, 0, time.FixedZone("", 1*60*60)),
+			ok:   true,
+		},
+		{
+			name: "no seconds hours offset",
+			s:    "2020-07-15T16:31+01",
+			want: time.Date(2020, time.July, 15, 16, 31, 0, 0, time.FixedZone("", 1*60*60)),
+			ok:   true,
+		},
+		{
+			name: "no offset",
+			s:    "2020-07-15T16:31:02",
+			want: time.Date(2020, time.July, 15, 16, 31, 2, 0, time.UTC),
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseModemTime(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse time: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				return
+			}
+
+			if !tt.want.Equal(got) {
+				t.Fatalf("unexpected time:\nwant: %v\n got: %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestModemGetNetworkTimeRaw(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Time.GetNetworkTime", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{dbus.MakeVariant("2020-07-15T16:31+01")}, out)
+		}},
+	}
+
+	now, raw, err := m.GetNetworkTimeRaw(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get network time: %v", err)
+	}
+
+	if diff := cmp.Diff("2020-07-15T16:31+01", raw); diff != "" {
+		t.Fatalf("unexpected raw time (-want +got):\n%s", diff)
+	}
+
+	want := time.Date(2020, time.July, 15, 17, 31, 0, 0, time.FixedZone("", 1*60*60))
+	if diff := cmp.Diff(want, now); diff != "" {
+		t.Fatalf("unexpected time (-want +got):\n%s", diff)
+	}
+}
+
+func TestNetworkTimezoneLocation(t *testing.T) {
+	tests := []struct {
+		name   string
+		tz     NetworkTimezone
+		zone   string
+		offset int
+	}{
+		{
+			name: "UTC",
+			zone: "UTC+00:00",
+		},
+		{
+			name: "EDT",
+			tz: NetworkTimezone{
+				Offset:    -4 * time.Hour,
+				DSTOffset: 1 * time.Hour,
+			},
+			zone:   "UTC-04:00",
+			offset: -4 * 60 * 60,
+		},
+		{
+			name:   "IST",
+			tz:     NetworkTimezone{Offset: 5*time.Hour + 30*time.Minute},
+			zone:   "UTC+05:30",
+			offset: 5*60*60 + 30*60,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, offset := time.Date(2020, time.July, 15, 0, 0, 0, 0, tt.tz.Location()).Zone()
+
+			if diff := cmp.Diff(tt.zone, zone); diff != "" {
+				t.Fatalf("unexpected zone name (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.offset, offset); diff != "" {
+				t.Fatalf("unexpected zone offset (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModemGetLocalNetworkTime(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, _ string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				return dbus.Store([]interface{}{dbus.MakeVariant("2020-07-15T16:31:02+00:00")}, out)
+			},
+			get: func(_ context.Context, _ dbus.ObjectPath, _, _ string) (dbus.Variant, error) {
+				return dbus.MakeVariant(map[string]dbus.Variant{
+					"offset": dbus.MakeVariant(int32(-240)),
+				}), nil
+			},
+		},
+	}
+
+	now, err := m.GetLocalNetworkTime(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get local network time: %v", err)
+	}
+
+	if diff := cmp.Diff(time.Date(2020, time.July, 15, 16, 31, 2, 0, time.UTC), now); diff != "" {
+		t.Fatalf("unexpected time (-want +got):\n%s", diff)
+	}
+
+	zone, _ := now.Zone()
+	if diff := cmp.Diff("UTC-04:00", zone); diff != "" {
+		t.Fatalf("unexpected zone name (-want +got):\n%s", diff)
+	}
+}
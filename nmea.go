@@ -0,0 +1,97 @@
+package modemmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// An NMEASentence is a parsed NMEA 0183 sentence, such as one reported by the
+// LocationSourceGPSNMEA source.
+type NMEASentence struct {
+	// Type is the talker ID and sentence type, such as "GPGGA".
+	Type string
+
+	// Fields are the comma-separated data fields which follow Type.
+	Fields []string
+
+	// Raw is the original sentence.
+	Raw string
+}
+
+// ParseNMEASentence parses an NMEA 0183 sentence of the form
+// "$GPGGA,...*47", verifying its checksum.
+func ParseNMEASentence(s string) (NMEASentence, error) {
+	if len(s) < 4 || s[0] != '$' {
+		return NMEASentence{}, fmt.Errorf("invalid NMEA sentence: %q", s)
+	}
+
+	star := strings.LastIndexByte(s, '*')
+	if star == -1 || len(s)-star != 3 {
+		return NMEASentence{}, fmt.Errorf("missing NMEA sentence checksum: %q", s)
+	}
+
+	want, err := strconv.ParseUint(s[star+1:], 16, 8)
+	if err != nil {
+		return NMEASentence{}, fmt.Errorf("invalid NMEA sentence checksum: %q", s)
+	}
+
+	// The checksum is the XOR of all bytes between '$' and '*'.
+	body := s[1:star]
+	var got byte
+	for i := 0; i < len(body); i++ {
+		got ^= body[i]
+	}
+
+	if got != byte(want) {
+		return NMEASentence{}, fmt.Errorf("NMEA sentence checksum mismatch: want %02X, got %02X: %q", want, got, s)
+	}
+
+	fields := strings.Split(body, ",")
+	return NMEASentence{
+		Type:   fields[0],
+		Fields: fields[1:],
+		Raw:    s,
+	}, nil
+}
+
+// An NMEAScanner iterates over and validates a series of NMEA sentences, such
+// as Location.NMEA. Its use is similar to bufio.Scanner:
+//
+//	s := NewNMEAScanner(l.NMEA)
+//	for s.Next() {
+//		fmt.Println(s.Sentence().Type)
+//	}
+//	if err := s.Err(); err != nil {
+//		// Handle error.
+//	}
+type NMEAScanner struct {
+	ss  []string
+	s   NMEASentence
+	err error
+}
+
+// NewNMEAScanner creates an NMEAScanner for the input sentences.
+func NewNMEAScanner(sentences []string) *NMEAScanner {
+	return &NMEAScanner{ss: sentences}
+}
+
+// Next advances to the next sentence, which is then available through the
+// Sentence method. It returns false when there are no more sentences or a
+// sentence fails to parse, in which case Err returns the error.
+func (s *NMEAScanner) Next() bool {
+	if s.err != nil || len(s.ss) == 0 {
+		return false
+	}
+
+	s.s, s.err = ParseNMEASentence(s.ss[0])
+	s.ss = s.ss[1:]
+
+	return s.err == nil
+}
+
+// Sentence returns the sentence parsed by the most recent call to Next.
+func (s *NMEAScanner) Sentence() NMEASentence { return s.s }
+
+// Err returns the first error encountered by the NMEAScanner, if any.
+func (s *NMEAScanner) Err() error { return s.err }
@@ -0,0 +1,96 @@
+package modemmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testGGA = "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*47"
+
+func TestParseNMEASentence(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		ns   NMEASentence
+		ok   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "no dollar",
+			s:    "GPGGA*00",
+		},
+		{
+			name: "no checksum",
+			s:    "$GPGGA,123519",
+		},
+		{
+			name: "bad checksum hex",
+			s:    "$GPGGA,123519*ZZ",
+		},
+		{
+			name: "checksum mismatch",
+			s:    "$GPGGA,123519,4807.038,N,01131.000,E,1,08,0.9,545.4,M,46.9,M,,*48",
+		},
+		{
+			name: "OK",
+			s:    testGGA,
+			ns: NMEASentence{
+				Type: "GPGGA",
+				Fields: []string{
+					"123519", "4807.038", "N", "01131.000", "E", "1", "08",
+					"0.9", "545.4", "M", "46.9", "M", "", "",
+				},
+				Raw: testGGA,
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ns, err := ParseNMEASentence(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse sentence: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.ns, ns); diff != "" {
+				t.Fatalf("unexpected NMEASentence (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNMEAScanner(t *testing.T) {
+	s := NewNMEAScanner([]string{
+		testGGA,
+		testGGA,
+		"$GPGGA,bad*00",
+		testGGA,
+	})
+
+	var n int
+	for s.Next() {
+		if diff := cmp.Diff("GPGGA", s.Sentence().Type); diff != "" {
+			t.Fatalf("unexpected sentence type (-want +got):\n%s", diff)
+		}
+		n++
+	}
+
+	if diff := cmp.Diff(2, n); diff != "" {
+		t.Fatalf("unexpected number of sentences (-want +got):\n%s", diff)
+	}
+
+	if s.Err() == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
@@ -0,0 +1,239 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// OMA contains the OMA device management status of a Modem.
+type OMA struct {
+	Features        OMAFeature
+	PendingSessions []OMAPendingSession
+	SessionState    OMASessionState
+	SessionType     OMASessionType
+}
+
+// An OMAPendingSession is a network initiated OMA device management session
+// which may be accepted or rejected using Modem.AcceptOMASession.
+type OMAPendingSession struct {
+	Type OMASessionType
+	ID   int
+}
+
+// An OMASessionStateChange is an event which occurs when the state of an OMA
+// device management session changes.
+type OMASessionStateChange struct {
+	Old, New OMASessionState
+
+	// FailedReason indicates why the session failed when New is
+	// OMASessionStateFailed.
+	FailedReason OMASessionStateFailedReason
+}
+
+// An OMAFeature is a bitmask of OMA device management features.
+type OMAFeature uint32
+
+// Possible OMAFeature values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMOmaFeature.
+const (
+	OMAFeatureNone               OMAFeature = 0
+	OMAFeatureDeviceProvisioning OMAFeature = 1 << (iota - 1)
+	OMAFeaturePRLUpdate
+	OMAFeatureHandsFreeActivation
+)
+
+// An OMASessionType is the type of an OMA device management session.
+type OMASessionType int
+
+// Possible OMASessionType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMOmaSessionType.
+const (
+	OMASessionTypeUnknown                            OMASessionType = 0
+	OMASessionTypeClientInitiatedDeviceConfigure     OMASessionType = 10
+	OMASessionTypeClientInitiatedPRLUpdate           OMASessionType = 11
+	OMASessionTypeClientInitiatedHandsFreeActivation OMASessionType = 12
+	OMASessionTypeNetworkInitiatedDeviceConfigure    OMASessionType = 20
+	OMASessionTypeNetworkInitiatedPRLUpdate          OMASessionType = 21
+	OMASessionTypeDeviceInitiatedPRLUpdate           OMASessionType = 30
+	OMASessionTypeDeviceInitiatedHandsFreeActivation OMASessionType = 31
+)
+
+// An OMASessionState is the state of an OMA device management session.
+type OMASessionState int
+
+// Possible OMASessionState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMOmaSessionState.
+const (
+	OMASessionStateFailed               OMASessionState = -1
+	OMASessionStateUnknown              OMASessionState = 0
+	OMASessionStateStarted              OMASessionState = 1
+	OMASessionStateRetrying             OMASessionState = 2
+	OMASessionStateConnecting           OMASessionState = 3
+	OMASessionStateConnected            OMASessionState = 4
+	OMASessionStateAuthenticated        OMASessionState = 5
+	OMASessionStateMDNDownloaded        OMASessionState = 10
+	OMASessionStateMSIDDownloaded       OMASessionState = 11
+	OMASessionStatePRLDownloaded        OMASessionState = 12
+	OMASessionStateMIPProfileDownloaded OMASessionState = 13
+	OMASessionStateCompleted            OMASessionState = 20
+)
+
+// An OMASessionStateFailedReason indicates why an OMA device management session
+// failed.
+type OMASessionStateFailedReason int
+
+// Possible OMASessionStateFailedReason values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMOmaSessionStateFailedReason.
+const (
+	OMASessionStateFailedReasonUnknown OMASessionStateFailedReason = iota
+	OMASessionStateFailedReasonNetworkUnavailable
+	OMASessionStateFailedReasonServerUnavailable
+	OMASessionStateFailedReasonAuthenticationFailed
+	OMASessionStateFailedReasonMaxRetryExceeded
+	OMASessionStateFailedReasonSessionCancelled
+)
+
+// OMA fetches the OMA device management status of the Modem.
+func (m *Modem) OMA(ctx context.Context) (*OMA, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Oma"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var o OMA
+	if err := o.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return &o, nil
+}
+
+// WatchOMASessionState watches for changes to the state of the Modem's OMA
+// device management session. Each change is delivered on the returned channel,
+// which is closed when the context is canceled.
+func (m *Modem) WatchOMASessionState(ctx context.Context) (<-chan OMASessionStateChange, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Oma"),
+		"SessionStateChanged",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (OMASessionStateChange, error) {
+		var (
+			from, to int32
+			reason   uint32
+		)
+
+		if err := dbus.Store(s.Body, &from, &to, &reason); err != nil {
+			return OMASessionStateChange{}, fmt.Errorf("error parsing OMA session state change: %v", err)
+		}
+
+		return OMASessionStateChange{
+			Old:          OMASessionState(from),
+			New:          OMASessionState(to),
+			FailedReason: OMASessionStateFailedReason(reason),
+		}, nil
+	}), nil
+}
+
+// WatchOMAPendingSessions watches for changes to the Modem's pending network
+// initiated OMA device management sessions, so that they may be accepted or
+// rejected by policy. Each updated list of sessions is delivered on the
+// returned channel, which is closed when the context is canceled.
+func (m *Modem) WatchOMAPendingSessions(ctx context.Context) (<-chan []OMAPendingSession, error) {
+	changes, err := m.c.watchProperties(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Oma"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, changes, func(ps map[string]dbus.Variant) ([]OMAPendingSession, error) {
+		if _, ok := ps["PendingNetworkInitiatedSessions"]; !ok {
+			return nil, errors.New("pending OMA sessions did not change")
+		}
+
+		var o OMA
+		if err := o.parse(ps); err != nil {
+			return nil, err
+		}
+
+		return o.PendingSessions, nil
+	}), nil
+}
+
+// OMASetup configures the OMA device management features enabled on the
+// Modem.
+func (m *Modem) OMASetup(ctx context.Context, features OMAFeature) error {
+	return m.omaMethod(ctx, "Setup", uint32(features))
+}
+
+// StartOMASession starts a client initiated OMA device management session of
+// the specified type.
+func (m *Modem) StartOMASession(ctx context.Context, typ OMASessionType) error {
+	return m.omaMethod(ctx, "StartClientInitiatedSession", uint32(typ))
+}
+
+// AcceptOMASession accepts or rejects the pending network initiated OMA device
+// management session identified by id.
+func (m *Modem) AcceptOMASession(ctx context.Context, id int, accept bool) error {
+	return m.omaMethod(ctx, "AcceptNetworkInitiatedSession", uint32(id), accept)
+}
+
+// CancelOMASession cancels the ongoing OMA device management session.
+func (m *Modem) CancelOMASession(ctx context.Context) error {
+	return m.omaMethod(ctx, "CancelSession")
+}
+
+// omaMethod calls an Oma method on the Modem with optional arguments.
+func (m *Modem) omaMethod(ctx context.Context, method string, args ...interface{}) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Oma", method),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		args...,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// parse parses a properties map into the OMA's fields.
+func (o *OMA) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "Features":
+			o.Features = OMAFeature(vp.Int())
+		case "PendingNetworkInitiatedSessions":
+			o.PendingSessions = vp.OMAPendingSessions()
+		case "SessionState":
+			o.SessionState = OMASessionState(vp.Int())
+		case "SessionType":
+			o.SessionType = OMASessionType(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemOMA(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Oma", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"Features": dbus.MakeVariant(uint32(OMAFeatureDeviceProvisioning | OMAFeaturePRLUpdate)),
+				"PendingNetworkInitiatedSessions": dbus.MakeVariant([][]interface{}{
+					{uint32(OMASessionTypeNetworkInitiatedPRLUpdate), uint32(3)},
+				}),
+				"SessionState": dbus.MakeVariant(int32(OMASessionStateFailed)),
+				"SessionType":  dbus.MakeVariant(uint32(OMASessionTypeClientInitiatedPRLUpdate)),
+			}, nil
+		}},
+	}
+
+	o, err := m.OMA(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get OMA: %v", err)
+	}
+
+	want := &OMA{
+		Features: OMAFeatureDeviceProvisioning | OMAFeaturePRLUpdate,
+		PendingSessions: []OMAPendingSession{{
+			Type: OMASessionTypeNetworkInitiatedPRLUpdate,
+			ID:   3,
+		}},
+		SessionState: OMASessionStateFailed,
+		SessionType:  OMASessionTypeClientInitiatedPRLUpdate,
+	}
+
+	if diff := cmp.Diff(want, o); diff != "" {
+		t.Fatalf("unexpected OMA (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemOMAMethods(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		args   []interface{}
+		fn     func(ctx context.Context, m *Modem) error
+	}{
+		{
+			name:   "setup",
+			method: "Setup",
+			args:   []interface{}{uint32(OMAFeatureHandsFreeActivation)},
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.OMASetup(ctx, OMAFeatureHandsFreeActivation)
+			},
+		},
+		{
+			name:   "start",
+			method: "StartClientInitiatedSession",
+			args:   []interface{}{uint32(OMASessionTypeClientInitiatedDeviceConfigure)},
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.StartOMASession(ctx, OMASessionTypeClientInitiatedDeviceConfigure)
+			},
+		},
+		{
+			name:   "accept",
+			method: "AcceptNetworkInitiatedSession",
+			args:   []interface{}{uint32(7), true},
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.AcceptOMASession(ctx, 7, true)
+			},
+		},
+		{
+			name:   "cancel",
+			method: "CancelSession",
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.CancelOMASession(ctx)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+					if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Oma."+tt.method, method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(tt.args, args, cmpopts.EquateEmpty()); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Error{Name: unauthorizedError}
+				}},
+			}
+
+			if err := tt.fn(context.Background(), m); !errors.Is(err, os.ErrPermission) {
+				t.Fatalf("expected permission error, but got: %v", err)
+			}
+		})
+	}
+}
+
+func TestModemWatchOMASessionState(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Oma", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("SessionStateChanged", member); diff != "" {
+				t.Fatalf("unexpected member (-want +got):\n%s", diff)
+			}
+
+			// Deliver a malformed signal which should be skipped, followed by
+			// a session connecting and then failing.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				int32(OMASessionStateStarted),
+				int32(OMASessionStateConnecting),
+				uint32(OMASessionStateFailedReasonUnknown),
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				int32(OMASessionStateConnecting),
+				int32(OMASessionStateFailed),
+				uint32(OMASessionStateFailedReasonServerUnavailable),
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	changes, err := m.WatchOMASessionState(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch OMA session state: %v", err)
+	}
+
+	var got []OMASessionStateChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []OMASessionStateChange{
+		{
+			Old: OMASessionStateStarted,
+			New: OMASessionStateConnecting,
+		},
+		{
+			Old:          OMASessionStateConnecting,
+			New:          OMASessionStateFailed,
+			FailedReason: OMASessionStateFailedReasonServerUnavailable,
+		},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected OMA session state changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchOMAPendingSessions(t *testing.T) {
+	const iface = "org.freedesktop.ModemManager1.Modem.Oma"
+
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			// An unrelated property changes, a session becomes pending, and
+			// then the pending sessions are cleared.
+			sigs := make(chan *dbus.Signal, 3)
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"SessionState": dbus.MakeVariant(int32(OMASessionStateStarted))},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"PendingNetworkInitiatedSessions": dbus.MakeVariant([][]interface{}{
+					{uint32(OMASessionTypeNetworkInitiatedDeviceConfigure), uint32(1)},
+				})},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"PendingNetworkInitiatedSessions": dbus.MakeVariant([][]interface{}{})},
+				[]string{},
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	sessions, err := m.WatchOMAPendingSessions(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch OMA pending sessions: %v", err)
+	}
+
+	var got [][]OMAPendingSession
+	for s := range sessions {
+		got = append(got, s)
+	}
+
+	want := [][]OMAPendingSession{
+		{{
+			Type: OMASessionTypeNetworkInitiatedDeviceConfigure,
+			ID:   1,
+		}},
+		{},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected OMA pending sessions (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,78 @@
+package modemmanager
+
+import "fmt"
+
+// An OperatorCode is a 3GPP network operator's PLMN identifier, consisting of
+// a Mobile Country Code (MCC) and Mobile Network Code (MNC).
+type OperatorCode struct {
+	MCC string
+	MNC string
+}
+
+// ParseOperatorCode parses a numeric operator code such as "310410" into its
+// 3 digit MCC and 2 or 3 digit MNC.
+func ParseOperatorCode(s string) (OperatorCode, error) {
+	if len(s) != 5 && len(s) != 6 {
+		return OperatorCode{}, fmt.Errorf("invalid operator code length: %q", s)
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return OperatorCode{}, fmt.Errorf("invalid operator code digits: %q", s)
+		}
+	}
+
+	return OperatorCode{
+		MCC: s[:3],
+		MNC: s[3:],
+	}, nil
+}
+
+// String returns the numeric form of an OperatorCode, such as "310410".
+func (c OperatorCode) String() string { return c.MCC + c.MNC }
+
+// An OperatorResolver resolves an OperatorCode to an operator's display name,
+// such as by using a local PLMN database. If the OperatorCode is unknown, ok
+// must be false.
+type OperatorResolver interface {
+	ResolveOperator(code OperatorCode) (name string, ok bool)
+}
+
+// An OperatorResolverFunc adapts a function to an OperatorResolver.
+type OperatorResolverFunc func(code OperatorCode) (name string, ok bool)
+
+// ResolveOperator implements OperatorResolver.
+func (fn OperatorResolverFunc) ResolveOperator(code OperatorCode) (string, bool) {
+	return fn(code)
+}
+
+// ResolveOperator resolves the display name for the operator identified by
+// the numeric operator code using r, such as for Modem3GPP.OperatorCode or
+// PreferredNetwork.OperatorCode. If r is nil, code cannot be parsed, or r does
+// not know of the operator, fallback is returned. A Client applies its
+// OperatorResolver in the same way to each operator name it reports.
+func ResolveOperator(r OperatorResolver, code, fallback string) string {
+	if r == nil {
+		return fallback
+	}
+
+	c, err := ParseOperatorCode(code)
+	if err != nil {
+		return fallback
+	}
+
+	if name, ok := r.ResolveOperator(c); ok {
+		return name
+	}
+
+	return fallback
+}
+
+// operatorName resolves an operator name using the Client's OperatorResolver.
+func (c *Client) operatorName(code, fallback string) string {
+	if c == nil {
+		return fallback
+	}
+
+	return ResolveOperator(c.OperatorResolver, code, fallback)
+}
@@ -0,0 +1,224 @@
+package modemmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestParseOperatorCode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want OperatorCode
+		ok   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "short",
+			s:    "3104",
+		},
+		{
+			name: "long",
+			s:    "3104101",
+		},
+		{
+			name: "not numeric",
+			s:    "310a10",
+		},
+		{
+			name: "2 digit MNC",
+			s:    "26201",
+			want: OperatorCode{MCC: "262", MNC: "01"},
+			ok:   true,
+		},
+		{
+			name: "3 digit MNC",
+			s:    "310410",
+			want: OperatorCode{MCC: "310", MNC: "410"},
+			ok:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseOperatorCode(tt.s)
+			if tt.ok && err != nil {
+				t.Fatalf("failed to parse operator code: %v", err)
+			}
+			if !tt.ok {
+				if err == nil {
+					t.Fatal("expected an error, but none occurred")
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, c); diff != "" {
+				t.Fatalf("unexpected OperatorCode (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.s, c.String()); diff != "" {
+				t.Fatalf("unexpected OperatorCode string (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestResolveOperator(t *testing.T) {
+	r := OperatorResolverFunc(func(c OperatorCode) (string, bool) {
+		if c == (OperatorCode{MCC: "310", MNC: "410"}) {
+			return "AT&T", true
+		}
+
+		return "", false
+	})
+
+	tests := []struct {
+		name string
+		r    OperatorResolver
+		code string
+		want string
+	}{
+		{
+			name: "nil resolver",
+			code: "310410",
+			want: "fallback",
+		},
+		{
+			name: "invalid code",
+			r:    r,
+			code: "foo",
+			want: "fallback",
+		},
+		{
+			name: "unknown",
+			r:    r,
+			code: "310260",
+			want: "fallback",
+		},
+		{
+			name: "OK",
+			r:    r,
+			code: "310410",
+			want: "AT&T",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ResolveOperator(tt.r, tt.code, "fallback")); diff != "" {
+				t.Fatalf("unexpected operator name (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestClientOperatorResolver(t *testing.T) {
+	// Only AT&T is known to the resolver, so T-Mobile keeps the name reported
+	// by ModemManager.
+	c := &Client{
+		OperatorResolver: OperatorResolverFunc(func(c OperatorCode) (string, bool) {
+			if c == (OperatorCode{MCC: "310", MNC: "410"}) {
+				return "AT&T Mobility", true
+			}
+
+			return "", false
+		}),
+		call: func(_ context.Context, _ string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			return dbus.Store([]interface{}{map[string]dbus.Variant{
+				"m3gpp-operator-code": dbus.MakeVariant("310410"),
+				"m3gpp-operator-name": dbus.MakeVariant("AT&T"),
+			}}, out)
+		},
+		getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+			switch op {
+			case "/org/freedesktop/ModemManager1/Modem/0":
+				return map[string]dbus.Variant{
+					"OperatorCode": dbus.MakeVariant("310410"),
+					"OperatorName": dbus.MakeVariant("AT&T"),
+					"NetworkRejection": dbus.MakeVariant(map[string]dbus.Variant{
+						"operator-id":   dbus.MakeVariant("310260"),
+						"operator-name": dbus.MakeVariant("T-Mobile"),
+					}),
+				}, nil
+			case "/org/freedesktop/ModemManager1/SIM/0":
+				return map[string]dbus.Variant{
+					"OperatorIdentifier": dbus.MakeVariant("310410"),
+					"OperatorName":       dbus.MakeVariant("AT&T"),
+					"PreferredNetworks": dbus.MakeVariant([][]interface{}{
+						{"310410", uint32(AccessTechnologyLTE)},
+						{"310260", uint32(AccessTechnologyLTE)},
+					}),
+				}, nil
+			default:
+				t.Fatalf("unexpected object path: %q", op)
+				return nil, nil
+			}
+		},
+	}
+
+	m := &Modem{
+		c:   c,
+		sim: "/org/freedesktop/ModemManager1/SIM/0",
+	}
+
+	ctx := context.Background()
+
+	g, err := m.ThreeGPP(ctx)
+	if err != nil {
+		t.Fatalf("failed to get 3GPP information: %v", err)
+	}
+
+	wantG := &Modem3GPP{
+		OperatorCode: "310410",
+		OperatorName: "AT&T Mobility",
+		NetworkRejection: &NetworkRejection{
+			OperatorID:   "310260",
+			OperatorName: "T-Mobile",
+		},
+	}
+
+	if diff := cmp.Diff(wantG, g); diff != "" {
+		t.Fatalf("unexpected 3GPP information (-want +got):\n%s", diff)
+	}
+
+	s, err := m.GetStatus(ctx)
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+
+	if diff := cmp.Diff("AT&T Mobility", s.OperatorName); diff != "" {
+		t.Fatalf("unexpected status operator name (-want +got):\n%s", diff)
+	}
+
+	sim, err := m.SIM(ctx)
+	if err != nil {
+		t.Fatalf("failed to get SIM: %v", err)
+	}
+
+	wantSIM := &SIM{
+		OperatorIdentifier: "310410",
+		OperatorName:       "AT&T Mobility",
+		PreferredNetworks: []PreferredNetwork{
+			{
+				OperatorCode:     "310410",
+				AccessTechnology: AccessTechnologyLTE,
+				OperatorName:     "AT&T Mobility",
+			},
+			{
+				OperatorCode:     "310260",
+				AccessTechnology: AccessTechnologyLTE,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(wantSIM, sim, cmpopts.IgnoreUnexported(SIM{})); diff != "" {
+		t.Fatalf("unexpected SIM (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,119 @@
+package modemmanager
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+)
+
+// A PCO is a raw Protocol Configuration Options information element received
+// by a Modem from a 3GPP network.
+type PCO struct {
+	SessionID int
+	Complete  bool
+	Data      []byte
+}
+
+// A PCOContainer is a single protocol or container entry within a PCO, as
+// described in 3GPP TS 24.008 section 10.5.6.3.
+type PCOContainer struct {
+	ID   uint16
+	Data []byte
+}
+
+// Well-known PCOContainer IDs sent from a network to a modem.
+const (
+	PCOContainerDNSIPv6 uint16 = 0x0003
+	PCOContainerDNSIPv4 uint16 = 0x000d
+	PCOContainerIPv4MTU uint16 = 0x0010
+)
+
+// PCOConfig is the decoded configuration carried by a PCO.
+type PCOConfig struct {
+	DNS        []net.IP
+	MTU        int
+	Containers []PCOContainer
+}
+
+// iePCO is the information element identifier which may prefix PCO data.
+const iePCO = 0x27
+
+// Containers decodes all of the protocol and container entries within the PCO.
+func (p PCO) Containers() ([]PCOContainer, error) {
+	b := p.Data
+
+	// ModemManager may include the PCO information element identifier and
+	// length prior to the configuration protocol octet.
+	if len(b) >= 2 && b[0] == iePCO {
+		if int(b[1]) > len(b)-2 {
+			return nil, errors.New("PCO information element length exceeds data")
+		}
+
+		b = b[2 : 2+int(b[1])]
+	}
+
+	if len(b) == 0 {
+		return nil, errors.New("PCO data is empty")
+	}
+
+	// Skip the configuration protocol octet.
+	b = b[1:]
+
+	var cs []PCOContainer
+	for len(b) > 0 {
+		if len(b) < 3 {
+			return nil, errors.New("PCO container header is truncated")
+		}
+
+		id, l := binary.BigEndian.Uint16(b[0:2]), int(b[2])
+		b = b[3:]
+		if l > len(b) {
+			return nil, fmt.Errorf("PCO container 0x%04x length exceeds data", id)
+		}
+
+		cs = append(cs, PCOContainer{
+			ID:   id,
+			Data: b[:l],
+		})
+		b = b[l:]
+	}
+
+	return cs, nil
+}
+
+// Config decodes the PCO's common DNS server and MTU containers. All decoded
+// containers, including unrecognized ones, are also returned in the
+// PCOConfig's Containers field.
+func (p PCO) Config() (*PCOConfig, error) {
+	cs, err := p.Containers()
+	if err != nil {
+		return nil, err
+	}
+
+	pc := PCOConfig{Containers: cs}
+	for _, c := range cs {
+		switch c.ID {
+		case PCOContainerDNSIPv4, PCOContainerDNSIPv6:
+			// Some networks send empty containers when no servers are
+			// available.
+			if len(c.Data) == 0 {
+				continue
+			}
+
+			if len(c.Data) != net.IPv4len && len(c.Data) != net.IPv6len {
+				return nil, fmt.Errorf("invalid PCO DNS server length: %d", len(c.Data))
+			}
+
+			pc.DNS = append(pc.DNS, net.IP(c.Data))
+		case PCOContainerIPv4MTU:
+			if len(c.Data) != 2 {
+				return nil, fmt.Errorf("invalid PCO MTU length: %d", len(c.Data))
+			}
+
+			pc.MTU = int(binary.BigEndian.Uint16(c.Data))
+		}
+	}
+
+	return &pc, nil
+}
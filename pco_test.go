@@ -0,0 +1,91 @@
+package modemmanager
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPCOConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want *PCOConfig
+		ok   bool
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "bad IE length",
+			data: []byte{0x27, 0xff, 0x80},
+		},
+		{
+			name: "truncated header",
+			data: []byte{0x80, 0x00, 0x0d},
+		},
+		{
+			name: "truncated container",
+			data: []byte{0x80, 0x00, 0x0d, 0x04, 0x08, 0x08},
+		},
+		{
+			name: "bad DNS",
+			data: []byte{0x80, 0x00, 0x0d, 0x01, 0x08},
+		},
+		{
+			name: "bad MTU",
+			data: []byte{0x80, 0x00, 0x10, 0x01, 0x05},
+		},
+		{
+			name: "no containers",
+			data: []byte{0x80},
+			want: &PCOConfig{},
+			ok:   true,
+		},
+		{
+			name: "OK",
+			data: []byte{
+				// IEI and length.
+				0x27, 0x17,
+				// Configuration protocol.
+				0x80,
+				// IPv4 DNS.
+				0x00, 0x0d, 0x04, 0x08, 0x08, 0x08, 0x08,
+				// Empty IPv4 DNS.
+				0x00, 0x0d, 0x00,
+				// IPv4 MTU.
+				0x00, 0x10, 0x02, 0x05, 0xdc,
+				// Operator specific.
+				0xff, 0x00, 0x04, 0x13, 0x01, 0x84, 0x01,
+			},
+			want: &PCOConfig{
+				DNS: []net.IP{net.IPv4(8, 8, 8, 8).To4()},
+				MTU: 1500,
+				Containers: []PCOContainer{
+					{ID: PCOContainerDNSIPv4, Data: []byte{0x08, 0x08, 0x08, 0x08}},
+					{ID: PCOContainerDNSIPv4, Data: []byte{}},
+					{ID: PCOContainerIPv4MTU, Data: []byte{0x05, 0xdc}},
+					{ID: 0xff00, Data: []byte{0x13, 0x01, 0x84, 0x01}},
+				},
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pc, err := PCO{Data: tt.data}.Config()
+			if tt.ok && err != nil {
+				t.Fatalf("failed to decode PCO: %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+
+			if diff := cmp.Diff(tt.want, pc); diff != "" {
+				t.Fatalf("unexpected PCOConfig (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// ProfileIDUnknown is the Profile ID used for a profile which is not yet
+// stored on a Modem.
+const ProfileIDUnknown = -1
+
+// A Profile is a connection profile stored on a Modem, such as an APN
+// configuration. An ID of ProfileIDUnknown and other zero values are unset and
+// left to the modem's defaults. Because 0 is a valid profile ID, new profiles
+// must set ID to ProfileIDUnknown.
+type Profile struct {
+	ID          int
+	APN         string
+	AllowedAuth BearerAllowedAuth
+	IPType      BearerIPFamily
+	Name        string
+	Password    string
+	User        string
+}
+
+// ListProfiles lists the connection profiles stored on the Modem. This method
+// requires ModemManager 1.18 or newer.
+func (m *Modem) ListProfiles(ctx context.Context) ([]*Profile, error) {
+	var out []map[string]dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "ProfileManager", "List"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&out,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	ps := make([]*Profile, 0, len(out))
+	for _, o := range out {
+		p, err := parseProfile(o)
+		if err != nil {
+			return nil, err
+		}
+
+		ps = append(ps, p)
+	}
+
+	return ps, nil
+}
+
+// SetProfile creates or updates a connection profile stored on the Modem and
+// returns the profile as stored by the Modem. If p.ID is ProfileIDUnknown, a
+// new profile is created. This method requires ModemManager 1.18 or newer.
+func (m *Modem) SetProfile(ctx context.Context, p Profile) (*Profile, error) {
+	if err := validateAPN(p.APN); err != nil {
+		return nil, err
+	}
+
+	var out map[string]dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "ProfileManager", "Set"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&out,
+		p.properties(),
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	return parseProfile(out)
+}
+
+// DeleteProfile deletes the connection profile identified by id from the
+// Modem. If no such profile exists, an error compatible with 'errors.Is(err,
+// os.ErrNotExist)' is returned. This method requires ModemManager 1.18 or
+// newer.
+func (m *Modem) DeleteProfile(ctx context.Context, id int) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "ProfileManager", "Delete"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		map[string]dbus.Variant{"profile-id": dbus.MakeVariant(int32(id))},
+	)
+	if err != nil {
+		return toNotExist(toPermission(err), notFoundError)
+	}
+
+	return nil
+}
+
+// parseProfile parses a Profile from a properties map.
+func parseProfile(ps map[string]dbus.Variant) (*Profile, error) {
+	p := Profile{ID: ProfileIDUnknown}
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "allowed-auth":
+			p.AllowedAuth = BearerAllowedAuth(vp.Int())
+		case "apn":
+			p.APN = vp.String()
+		case "ip-type":
+			p.IPType = BearerIPFamily(vp.Int())
+		case "password":
+			p.Password = vp.String()
+		case "profile-id":
+			p.ID = vp.Int()
+		case "profile-name":
+			p.Name = vp.String()
+		case "user":
+			p.User = vp.String()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &p, nil
+}
+
+// properties packs a Profile into a properties map, omitting any unset values.
+func (p Profile) properties() map[string]dbus.Variant {
+	ps := make(map[string]dbus.Variant)
+	if p.AllowedAuth != BearerAllowedAuthUnknown {
+		ps["allowed-auth"] = dbus.MakeVariant(uint32(p.AllowedAuth))
+	}
+	if p.APN != "" {
+		ps["apn"] = dbus.MakeVariant(p.APN)
+	}
+	if p.IPType != BearerIPFamilyNone {
+		ps["ip-type"] = dbus.MakeVariant(uint32(p.IPType))
+	}
+	if p.Password != "" {
+		ps["password"] = dbus.MakeVariant(p.Password)
+	}
+	if p.ID != ProfileIDUnknown {
+		ps["profile-id"] = dbus.MakeVariant(int32(p.ID))
+	}
+	if p.Name != "" {
+		ps["profile-name"] = dbus.MakeVariant(p.Name)
+	}
+	if p.User != "" {
+		ps["user"] = dbus.MakeVariant(p.User)
+	}
+
+	return ps
+}
@@ -0,0 +1,150 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemListProfiles(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.ProfileManager.List", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{[]map[string]dbus.Variant{
+				{
+					"profile-id":   dbus.MakeVariant(int32(1)),
+					"profile-name": dbus.MakeVariant("default"),
+					"apn":          dbus.MakeVariant("broadband"),
+					"ip-type":      dbus.MakeVariant(uint32(BearerIPFamilyIPv4v6)),
+				},
+				{
+					"profile-id":   dbus.MakeVariant(int32(2)),
+					"apn":          dbus.MakeVariant("ims"),
+					"allowed-auth": dbus.MakeVariant(uint32(BearerAllowedAuthPAP)),
+					"user":         dbus.MakeVariant("user"),
+					"password":     dbus.MakeVariant("pass"),
+				},
+			}}, out)
+		}},
+	}
+
+	ps, err := m.ListProfiles(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list profiles: %v", err)
+	}
+
+	want := []*Profile{
+		{
+			ID:     1,
+			APN:    "broadband",
+			IPType: BearerIPFamilyIPv4v6,
+			Name:   "default",
+		},
+		{
+			ID:          2,
+			APN:         "ims",
+			AllowedAuth: BearerAllowedAuthPAP,
+			Password:    "pass",
+			User:        "user",
+		},
+	}
+
+	if diff := cmp.Diff(want, ps); diff != "" {
+		t.Fatalf("unexpected Profiles (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetProfile(t *testing.T) {
+	tests := []struct {
+		name string
+		p    Profile
+		args map[string]dbus.Variant
+		want *Profile
+	}{
+		{
+			name: "new",
+			p:    Profile{ID: ProfileIDUnknown, APN: "broadband"},
+			// A new profile has no ID.
+			args: map[string]dbus.Variant{
+				"apn": dbus.MakeVariant("broadband"),
+			},
+			want: &Profile{ID: 3, APN: "broadband"},
+		},
+		{
+			name: "update ID 0",
+			p:    Profile{ID: 0, APN: "broadband"},
+			args: map[string]dbus.Variant{
+				"apn":        dbus.MakeVariant("broadband"),
+				"profile-id": dbus.MakeVariant(int32(0)),
+			},
+			want: &Profile{ID: 0, APN: "broadband"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, args ...interface{}) error {
+					if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.ProfileManager.Set", method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff([]interface{}{tt.args}, args, cmp.Comparer(variantEqual)); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Store([]interface{}{map[string]dbus.Variant{
+						"profile-id": dbus.MakeVariant(int32(tt.want.ID)),
+						"apn":        dbus.MakeVariant("broadband"),
+					}}, out)
+				}},
+			}
+
+			p, err := m.SetProfile(context.Background(), tt.p)
+			if err != nil {
+				t.Fatalf("failed to set profile: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, p); diff != "" {
+				t.Fatalf("unexpected Profile (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModemDeleteProfileNotExist(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.ProfileManager.Delete", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			// Profile ID 0 is valid and must always be sent.
+			want := []interface{}{map[string]dbus.Variant{
+				"profile-id": dbus.MakeVariant(int32(0)),
+			}}
+
+			if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: notFoundError}
+		}},
+	}
+
+	err := m.DeleteProfile(context.Background(), 0)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected not exist error, but got: %v", err)
+	}
+}
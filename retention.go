@@ -0,0 +1,133 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// A RetentionPolicy automatically deletes SMS messages from a Modem, so that
+// the Modem's or SIM's limited message storage is not exhausted on busy
+// numbers. Only messages which have been handed to the application, as
+// reported by Handled, are deleted. Zero values disable the corresponding
+// limit.
+type RetentionPolicy struct {
+	// Keep is the maximum number of messages to keep on the Modem. The
+	// oldest messages are deleted first.
+	Keep int
+
+	// MaxAge is the maximum age of a received message, based on its
+	// Timestamp.
+	MaxAge time.Duration
+
+	// DeleteHandled deletes each message once it is handed to the
+	// application.
+	DeleteHandled bool
+
+	// mu guards handled, the indices of messages handed to the application.
+	mu      sync.Mutex
+	handled map[int]bool
+
+	// now is swappable for tests.
+	now func() time.Time
+}
+
+// Handled applies the policy after s is handed to the application: s is
+// deleted if DeleteHandled is set, and then the Modem's remaining messages are
+// pruned.
+func (p *RetentionPolicy) Handled(ctx context.Context, m *Modem, s *SMS) error {
+	if p.DeleteHandled {
+		if err := m.DeleteMessage(ctx, s); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	} else {
+		p.markHandled(s)
+	}
+
+	_, err := p.Prune(ctx, m)
+	return err
+}
+
+// Prune deletes the Modem's handled messages which exceed the policy's Keep
+// and MaxAge limits, and returns the deleted messages. Messages which have not
+// been handed to the application still count towards Keep, but are never
+// deleted, nor are messages which are still being received or sent.
+func (p *RetentionPolicy) Prune(ctx context.Context, m *Modem) ([]*SMS, error) {
+	if p.Keep <= 0 && p.MaxAge <= 0 {
+		return nil, nil
+	}
+
+	ss, err := m.Messages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// ModemManager assigns increasing indices to new messages, so the
+	// handled messages are ordered from oldest to newest.
+	var (
+		stored  int
+		handled []*SMS
+	)
+
+	p.mu.Lock()
+	for _, s := range ss {
+		if s.State == SMSStateReceiving || s.State == SMSStateSending {
+			continue
+		}
+
+		stored++
+		if p.handled[s.Index] {
+			handled = append(handled, s)
+		}
+	}
+	p.mu.Unlock()
+
+	sort.Slice(handled, func(i, j int) bool {
+		return handled[i].Index < handled[j].Index
+	})
+
+	now := time.Now
+	if p.now != nil {
+		now = p.now
+	}
+
+	var del []*SMS
+	for _, s := range handled {
+		var (
+			excess  = p.Keep > 0 && stored > p.Keep
+			expired = p.MaxAge > 0 && !s.Timestamp.IsZero() && now().Sub(s.Timestamp) > p.MaxAge
+		)
+		if !excess && !expired {
+			continue
+		}
+
+		if err := m.DeleteMessage(ctx, s); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return del, err
+		}
+
+		p.mu.Lock()
+		delete(p.handled, s.Index)
+		p.mu.Unlock()
+
+		stored--
+		del = append(del, s)
+	}
+
+	return del, nil
+}
+
+// markHandled records that s was handed to the application, so that it may
+// be pruned.
+func (p *RetentionPolicy) markHandled(s *SMS) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.handled == nil {
+		p.handled = make(map[int]bool)
+	}
+
+	p.handled[s.Index] = true
+}
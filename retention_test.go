@@ -0,0 +1,195 @@
+package modemmanager
+
+import (
+	"context"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRetentionPolicyPrune(t *testing.T) {
+	now := time.Date(2021, time.March, 4, 12, 0, 0, 0, time.UTC)
+
+	// Unless otherwise noted, all of the received messages were handed to the
+	// application.
+	all := []int{0, 1, 2, 3}
+
+	tests := []struct {
+		name    string
+		p       *RetentionPolicy
+		handled []int
+		want    []int
+	}{
+		{
+			name:    "no limits",
+			p:       &RetentionPolicy{},
+			handled: all,
+		},
+		{
+			name:    "keep",
+			p:       &RetentionPolicy{Keep: 2},
+			handled: all,
+			want:    []int{0, 1},
+		},
+		{
+			name:    "max age",
+			p:       &RetentionPolicy{MaxAge: 20 * time.Hour},
+			handled: all,
+			want:    []int{0},
+		},
+		{
+			name:    "keep and max age",
+			p:       &RetentionPolicy{Keep: 3, MaxAge: 12 * time.Hour},
+			handled: all,
+			want:    []int{0, 1},
+		},
+		{
+			name: "keep undelivered",
+			p:    &RetentionPolicy{Keep: 1},
+			// Message 3 was not delivered yet and must survive, even though
+			// the limit is exceeded.
+			handled: []int{0, 1, 2},
+			want:    []int{0, 1, 2},
+		},
+		{
+			name:    "max age undelivered",
+			p:       &RetentionPolicy{MaxAge: 12 * time.Hour},
+			handled: []int{1, 3},
+			want:    []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, deleted := testRetentionModem(t, now)
+			tt.p.now = func() time.Time { return now }
+			for _, idx := range tt.handled {
+				tt.p.markHandled(&SMS{Index: idx})
+			}
+
+			ss, err := tt.p.Prune(context.Background(), m)
+			if err != nil {
+				t.Fatalf("failed to prune: %v", err)
+			}
+
+			var got []int
+			for _, s := range ss {
+				got = append(got, s.Index)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Fatalf("unexpected pruned messages (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.want, deleted()); diff != "" {
+				t.Fatalf("unexpected deleted messages (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicyHandled(t *testing.T) {
+	m, deleted := testRetentionModem(t, time.Now())
+
+	p := &RetentionPolicy{DeleteHandled: true}
+	if err := p.Handled(context.Background(), m, &SMS{Index: 3}); err != nil {
+		t.Fatalf("failed to handle message: %v", err)
+	}
+
+	if diff := cmp.Diff([]int{3}, deleted()); diff != "" {
+		t.Fatalf("unexpected deleted messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestRetentionPolicyHandledKeep(t *testing.T) {
+	m, deleted := testRetentionModem(t, time.Now())
+
+	// Only the handled messages are pruned, so the newest messages which
+	// were not yet delivered survive even though only 1 message may be kept.
+	p := &RetentionPolicy{Keep: 1}
+	for _, idx := range []int{0, 1} {
+		if err := p.Handled(context.Background(), m, &SMS{Index: idx}); err != nil {
+			t.Fatalf("failed to handle message: %v", err)
+		}
+	}
+
+	if diff := cmp.Diff([]int{0, 1}, deleted()); diff != "" {
+		t.Fatalf("unexpected deleted messages (-want +got):\n%s", diff)
+	}
+}
+
+// testRetentionModem returns a Modem with canned messages for RetentionPolicy
+// tests, and a function which reports the indices of deleted messages.
+func testRetentionModem(t *testing.T, now time.Time) (*Modem, func() []int) {
+	t.Helper()
+
+	var (
+		mu      sync.Mutex
+		deleted []int
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, args ...interface{}) error {
+				switch method {
+				case "org.freedesktop.ModemManager1.Modem.Messaging.List":
+					return dbus.Store([]interface{}{[]dbus.ObjectPath{
+						"/org/freedesktop/ModemManager1/SMS/3",
+						"/org/freedesktop/ModemManager1/SMS/0",
+						"/org/freedesktop/ModemManager1/SMS/2",
+						"/org/freedesktop/ModemManager1/SMS/1",
+						"/org/freedesktop/ModemManager1/SMS/4",
+					}}, out)
+				case "org.freedesktop.ModemManager1.Modem.Messaging.Delete":
+					op := args[0].(dbus.ObjectPath)
+					idx, err := strconv.Atoi(path.Base(string(op)))
+					if err != nil {
+						t.Fatalf("failed to parse index: %v", err)
+					}
+
+					mu.Lock()
+					defer mu.Unlock()
+					deleted = append(deleted, idx)
+					return nil
+				default:
+					t.Fatalf("unexpected method: %q", method)
+					return nil
+				}
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				// Messages 0 through 3 are received 6 hours apart starting
+				// a day ago, and message 4 is still being received.
+				idx, err := strconv.Atoi(path.Base(string(op)))
+				if err != nil {
+					t.Fatalf("failed to parse index: %v", err)
+				}
+
+				state := SMSStateReceived
+				if idx == 4 {
+					state = SMSStateReceiving
+				}
+
+				ts := now.Add(-24 * time.Hour).Add(time.Duration(idx) * 6 * time.Hour)
+
+				return map[string]dbus.Variant{
+					"State":     dbus.MakeVariant(uint32(state)),
+					"Timestamp": dbus.MakeVariant(ts.Format(time.RFC3339)),
+				}, nil
+			},
+		},
+	}
+
+	return m, func() []int {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sort.Ints(deleted)
+		return deleted
+	}
+}
@@ -0,0 +1,82 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// SAR contains the dynamic Specific Absorption Rate (SAR) configuration of a
+// Modem.
+type SAR struct {
+	// Enabled reports whether dynamic SAR is enabled.
+	Enabled bool
+
+	// PowerLevel is the index of the currently applied SAR power level.
+	PowerLevel int
+}
+
+// SAR fetches the dynamic SAR configuration of the Modem. This method requires
+// ModemManager 1.20 or newer.
+func (m *Modem) SAR(ctx context.Context) (*SAR, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Sar"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var s SAR
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "State":
+			s.Enabled = vp.Bool()
+		case "PowerLevel":
+			s.PowerLevel = vp.Int()
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &s, nil
+}
+
+// EnableSAR enables or disables dynamic SAR on the Modem. This method requires
+// ModemManager 1.20 or newer.
+func (m *Modem) EnableSAR(ctx context.Context, enable bool) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Sar", "Enable"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		enable,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// SetSARPowerLevel sets the index of the SAR power level applied by the Modem.
+// The meaning of each level is defined by the Modem's manufacturer. This
+// method requires ModemManager 1.20 or newer.
+func (m *Modem) SetSARPowerLevel(ctx context.Context, level int) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Sar", "SetPowerLevel"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		uint32(level),
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
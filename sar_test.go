@@ -0,0 +1,91 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemSAR(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Sar", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"State":      dbus.MakeVariant(true),
+				"PowerLevel": dbus.MakeVariant(uint32(2)),
+			}, nil
+		}},
+	}
+
+	s, err := m.SAR(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get SAR: %v", err)
+	}
+
+	if diff := cmp.Diff(&SAR{Enabled: true, PowerLevel: 2}, s); diff != "" {
+		t.Fatalf("unexpected SAR (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSARMethods(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		args   []interface{}
+		fn     func(ctx context.Context, m *Modem) error
+	}{
+		{
+			name:   "enable",
+			method: "org.freedesktop.ModemManager1.Modem.Sar.Enable",
+			args:   []interface{}{true},
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.EnableSAR(ctx, true)
+			},
+		},
+		{
+			name:   "set power level",
+			method: "org.freedesktop.ModemManager1.Modem.Sar.SetPowerLevel",
+			args:   []interface{}{uint32(1)},
+			fn: func(ctx context.Context, m *Modem) error {
+				return m.SetSARPowerLevel(ctx, 1)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+					if diff := cmp.Diff(tt.method, method); diff != "" {
+						t.Fatalf("unexpected method (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					if diff := cmp.Diff(tt.args, args); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Error{Name: unauthorizedError}
+				}},
+			}
+
+			if err := tt.fn(context.Background(), m); !errors.Is(err, os.ErrPermission) {
+				t.Fatalf("expected permission error, but got: %v", err)
+			}
+		})
+	}
+}
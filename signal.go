@@ -9,12 +9,75 @@ import (
 	"github.com/godbus/dbus/v5"
 )
 
-// Signal contains cellular network extended signal quality information.
+// Signal contains cellular network extended signal quality information. Each
+// network's data is nil if the Modem did not report any data for it.
+//
+// ErrorRate fields contain the bit or frame error rate percentage reported by
+// ModemManager 1.20 or newer.
 type Signal struct {
-	Rate time.Duration
-	LTE  struct {
-		RSRP, RSRQ, RSSI, SNR float64
-	}
+	// Rate is the refresh rate configured by SignalSetup, and Taken is the
+	// time at which the Signal was fetched. See IsStale.
+	Rate  time.Duration `json:"rate"`
+	Taken time.Time     `json:"taken"`
+
+	CDMA *CDMASignal `json:"cdma,omitempty"`
+	EVDO *EVDOSignal `json:"evdo,omitempty"`
+	GSM  *GSMSignal  `json:"gsm,omitempty"`
+	LTE  *LTESignal  `json:"lte,omitempty"`
+	NR5G *NR5GSignal `json:"nr5g,omitempty"`
+	UMTS *UMTSSignal `json:"umts,omitempty"`
+}
+
+// CDMASignal contains CDMA1x signal quality information. RSSI is in dBm and
+// ECIO is in dB.
+type CDMASignal struct {
+	ECIO      float64 `json:"ecio"`
+	ErrorRate float64 `json:"error_rate"`
+	RSSI      float64 `json:"rssi"`
+}
+
+// EVDOSignal contains CDMA EV-DO signal quality information. IO and RSSI are
+// in dBm, and ECIO and SINR are in dB.
+type EVDOSignal struct {
+	ECIO      float64 `json:"ecio"`
+	ErrorRate float64 `json:"error_rate"`
+	IO        float64 `json:"io"`
+	RSSI      float64 `json:"rssi"`
+	SINR      float64 `json:"sinr"`
+}
+
+// GSMSignal contains GSM signal quality information. RSSI is in dBm.
+type GSMSignal struct {
+	ErrorRate float64 `json:"error_rate"`
+	RSSI      float64 `json:"rssi"`
+}
+
+// LTESignal contains LTE signal quality information. RSRP and RSSI are in
+// dBm, and RSRQ and SNR are in dB.
+type LTESignal struct {
+	ErrorRate float64 `json:"error_rate"`
+	RSRP      float64 `json:"rsrp"`
+	RSRQ      float64 `json:"rsrq"`
+	RSSI      float64 `json:"rssi"`
+	SNR       float64 `json:"snr"`
+}
+
+// NR5GSignal contains 5G NR signal quality information. RSRP is in dBm, and
+// RSRQ and SNR are in dB.
+type NR5GSignal struct {
+	ErrorRate float64 `json:"error_rate"`
+	RSRP      float64 `json:"rsrp"`
+	RSRQ      float64 `json:"rsrq"`
+	SNR       float64 `json:"snr"`
+}
+
+// UMTSSignal contains UMTS signal quality information. RSCP and RSSI are in
+// dBm and ECIO is in dB.
+type UMTSSignal struct {
+	ECIO      float64 `json:"ecio"`
+	ErrorRate float64 `json:"error_rate"`
+	RSCP      float64 `json:"rscp"`
+	RSSI      float64 `json:"rssi"`
 }
 
 // Signal returns cellular network extended signal quality information from the
@@ -29,12 +92,69 @@ func (m *Modem) Signal(ctx context.Context) (*Signal, error) {
 		return nil, err
 	}
 
-	return parseSignal(ps)
+	return parseSignal(ps, time.Now())
+}
+
+// WatchSignal sets the Modem's extended signal quality refresh rate using
+// SignalSetup and then watches for updated Signal data. Each Signal is a
+// complete snapshot of the Modem's signal quality information, and is
+// delivered on the returned channel, which is closed when the context is
+// canceled.
+func (m *Modem) WatchSignal(ctx context.Context, rate time.Duration) (_ <-chan *Signal, err error) {
+	op := objectPath("Modem", strconv.Itoa(m.Index))
+	iface := interfacePath("Modem", "Signal")
+
+	// Stop watching if any of the remaining setup fails.
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		if err != nil {
+			cancel()
+		}
+	}()
+
+	// Begin watching before enabling updates so none are missed.
+	changes, err := m.c.watchProperties(ctx, op, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = m.SignalSetup(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	// Only the changed properties are reported by each update, so merge them
+	// into the current properties to produce a complete Signal.
+	ps, err := m.c.getAll(ctx, op, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, changes, func(changed map[string]dbus.Variant) (*Signal, error) {
+		for k, v := range changed {
+			ps[k] = v
+		}
+
+		return parseSignal(ps, time.Now())
+	}), nil
 }
 
-// parseSignal parses a properties map into Signal data.
-func parseSignal(ps map[string]dbus.Variant) (*Signal, error) {
-	var s Signal
+// IsStale reports whether the Signal may no longer reflect the Modem's signal
+// quality, either because the Modem is not refreshing its signal data or more
+// than two refresh intervals have elapsed since the Signal was taken.
+func (s *Signal) IsStale() bool { return s.isStale(time.Now()) }
+
+// isStale implements IsStale using the input time.
+func (s *Signal) isStale(now time.Time) bool {
+	if s.Rate == 0 {
+		return true
+	}
+
+	return now.Sub(s.Taken) > 2*s.Rate
+}
+
+// parseSignal parses a properties map into Signal data taken at time now.
+func parseSignal(ps map[string]dbus.Variant, now time.Time) (*Signal, error) {
+	s := Signal{Taken: now}
 	for k, v := range ps {
 		switch v := v.Value().(type) {
 		case uint32:
@@ -43,43 +163,104 @@ func parseSignal(ps map[string]dbus.Variant) (*Signal, error) {
 				s.Rate = time.Duration(v) * time.Second
 			}
 		case map[string]dbus.Variant:
-			// Cellular network data maps.
-			// TODO: parse other cellular network data.
-			var err error
+			// Cellular network data maps, with each key of interest pointing
+			// to the field where its value is stored. The network's data is
+			// only set when at least one of those keys is present.
+			var (
+				fields map[string]*float64
+				set    func()
+			)
+
 			switch k {
+			case "Cdma":
+				var c CDMASignal
+				fields = map[string]*float64{
+					"ecio":       &c.ECIO,
+					"error-rate": &c.ErrorRate,
+					"rssi":       &c.RSSI,
+				}
+				set = func() { s.CDMA = &c }
+			case "Evdo":
+				var e EVDOSignal
+				fields = map[string]*float64{
+					"ecio":       &e.ECIO,
+					"error-rate": &e.ErrorRate,
+					"io":         &e.IO,
+					"rssi":       &e.RSSI,
+					"sinr":       &e.SINR,
+				}
+				set = func() { s.EVDO = &e }
+			case "Gsm":
+				var g GSMSignal
+				fields = map[string]*float64{
+					"error-rate": &g.ErrorRate,
+					"rssi":       &g.RSSI,
+				}
+				set = func() { s.GSM = &g }
 			case "Lte":
-				l := &s.LTE
-				err = parseLTESignal(v, &l.RSRP, &l.RSRQ, &l.RSSI, &l.SNR)
+				var l LTESignal
+				fields = map[string]*float64{
+					"error-rate": &l.ErrorRate,
+					"rsrp":       &l.RSRP,
+					"rsrq":       &l.RSRQ,
+					"rssi":       &l.RSSI,
+					"snr":        &l.SNR,
+				}
+				set = func() { s.LTE = &l }
+			case "Nr5g":
+				var n NR5GSignal
+				fields = map[string]*float64{
+					"error-rate": &n.ErrorRate,
+					"rsrp":       &n.RSRP,
+					"rsrq":       &n.RSRQ,
+					"snr":        &n.SNR,
+				}
+				set = func() { s.NR5G = &n }
+			case "Umts":
+				var u UMTSSignal
+				fields = map[string]*float64{
+					"ecio":       &u.ECIO,
+					"error-rate": &u.ErrorRate,
+					"rscp":       &u.RSCP,
+					"rssi":       &u.RSSI,
+				}
+				set = func() { s.UMTS = &u }
+			default:
+				continue
 			}
 
+			ok, err := parseSignalValues(k, v, fields)
 			if err != nil {
 				return nil, err
 			}
+			if ok {
+				set()
+			}
 		}
 	}
 
 	return &s, nil
 }
 
-// parseLTESignal parses a properties map into LTE data fields.
-func parseLTESignal(ps map[string]dbus.Variant, rsrp, rsrq, rssi, snr *float64) error {
+// parseSignalValues parses a properties map for the named cellular network
+// into the data fields pointed to by fields. Keys not present in fields are
+// ignored. It reports whether any of the keys in fields were present.
+func parseSignalValues(network string, ps map[string]dbus.Variant, fields map[string]*float64) (bool, error) {
+	var found bool
 	for k, v := range ps {
-		vp := newValueParser(v)
-		switch k {
-		case "rsrp":
-			*rsrp = vp.Float64()
-		case "rsrq":
-			*rsrq = vp.Float64()
-		case "rssi":
-			*rssi = vp.Float64()
-		case "snr":
-			*snr = vp.Float64()
+		f, ok := fields[k]
+		if !ok {
+			continue
 		}
 
+		vp := newValueParser(v)
+		*f = vp.Float64()
 		if err := vp.Err(); err != nil {
-			return fmt.Errorf("error parsing LTE signal key %q: %v", k, err)
+			return false, fmt.Errorf("error parsing %s signal key %q: %v", network, k, err)
 		}
+
+		found = true
 	}
 
-	return nil
+	return found, nil
 }
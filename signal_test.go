@@ -2,11 +2,13 @@ package modemmanager
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
 	"github.com/godbus/dbus/v5"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 )
 
 func TestModemSignal(t *testing.T) {
@@ -24,6 +26,32 @@ func TestModemSignal(t *testing.T) {
 			// Test data copied from mdlayher's modem with some tweaks.
 			return map[string]dbus.Variant{
 				"Rate": dbus.MakeVariant(uint32(10)),
+				"Cdma": dbus.MakeVariant(map[string]dbus.Variant{
+					"ecio": dbus.MakeVariant(float64(-9)),
+					"rssi": dbus.MakeVariant(float64(-80)),
+				}),
+				"Evdo": dbus.MakeVariant(map[string]dbus.Variant{
+					"ecio": dbus.MakeVariant(float64(-8)),
+					"io":   dbus.MakeVariant(float64(-70)),
+					"rssi": dbus.MakeVariant(float64(-79)),
+					"sinr": dbus.MakeVariant(float64(6)),
+				}),
+				"Gsm": dbus.MakeVariant(map[string]dbus.Variant{
+					"error-rate": dbus.MakeVariant(float64(1.5)),
+					"rssi":       dbus.MakeVariant(float64(-75)),
+				}),
+				"Nr5g": dbus.MakeVariant(map[string]dbus.Variant{
+					"error-rate": dbus.MakeVariant(float64(0.5)),
+					"rsrp":       dbus.MakeVariant(float64(-90)),
+					"rsrq":       dbus.MakeVariant(float64(-11)),
+					"snr":        dbus.MakeVariant(float64(12)),
+				}),
+				"Umts": dbus.MakeVariant(map[string]dbus.Variant{
+					"ecio":       dbus.MakeVariant(float64(-7)),
+					"rscp":       dbus.MakeVariant(float64(-95)),
+					"error-rate": dbus.MakeVariant(float64(2)),
+					"rssi":       dbus.MakeVariant(float64(-73)),
+				}),
 				"Lte": dbus.MakeVariant(map[string]dbus.Variant{
 					"rsrp": dbus.MakeVariant(float64(-117)),
 					"rsrq": dbus.MakeVariant(float64(-14)),
@@ -39,22 +67,243 @@ func TestModemSignal(t *testing.T) {
 		t.Fatalf("failed to get signal data: %v", err)
 	}
 
-	// TODO: reconsider use of anonymous structs if needed. They make tests more
-	// ugly but keep the exported API more concise.
-
 	want := &Signal{
 		Rate: 10 * time.Second,
-		LTE: struct {
-			RSRP, RSRQ, RSSI, SNR float64
-		}{
+		CDMA: &CDMASignal{
+			ECIO: -9,
+			RSSI: -80,
+		},
+		EVDO: &EVDOSignal{
+			ECIO: -8,
+			IO:   -70,
+			RSSI: -79,
+			SINR: 6,
+		},
+		GSM: &GSMSignal{
+			ErrorRate: 1.5,
+			RSSI:      -75,
+		},
+		LTE: &LTESignal{
 			RSRP: -117,
 			RSRQ: -14,
 			RSSI: -83,
 			SNR:  3,
 		},
+		NR5G: &NR5GSignal{
+			ErrorRate: 0.5,
+			RSRP:      -90,
+			RSRQ:      -11,
+			SNR:       12,
+		},
+		UMTS: &UMTSSignal{
+			ECIO:      -7,
+			ErrorRate: 2,
+			RSCP:      -95,
+			RSSI:      -73,
+		},
 	}
 
-	if diff := cmp.Diff(want, signal); diff != "" {
+	if diff := cmp.Diff(want, signal, ignoreTaken); diff != "" {
 		t.Fatalf("unexpected Signal (-want +got):\n%s", diff)
 	}
+
+	if signal.Taken.IsZero() {
+		t.Fatal("Signal taken time was not set")
+	}
+}
+
+// ignoreTaken ignores the current time set when a Signal is fetched.
+var ignoreTaken = cmpopts.IgnoreFields(Signal{}, "Taken")
+
+func Test_parseSignalError(t *testing.T) {
+	_, err := parseSignal(map[string]dbus.Variant{
+		"Umts": dbus.MakeVariant(map[string]dbus.Variant{
+			"rscp": dbus.MakeVariant("foo"),
+		}),
+	}, time.Now())
+	if err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+}
+
+func TestModemSignalNoData(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+			// ModemManager reports empty maps for networks with no data.
+			return map[string]dbus.Variant{
+				"Rate": dbus.MakeVariant(uint32(0)),
+				"Gsm":  dbus.MakeVariant(map[string]dbus.Variant{}),
+				"Lte": dbus.MakeVariant(map[string]dbus.Variant{
+					"rsrp": dbus.MakeVariant(float64(-117)),
+				}),
+				"Umts": dbus.MakeVariant(map[string]dbus.Variant{
+					"foo": dbus.MakeVariant(float64(1)),
+				}),
+			}, nil
+		}},
+	}
+
+	signal, err := m.Signal(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get signal data: %v", err)
+	}
+
+	want := &Signal{LTE: &LTESignal{RSRP: -117}}
+	if diff := cmp.Diff(want, signal, ignoreTaken); diff != "" {
+		t.Fatalf("unexpected Signal (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchSignal(t *testing.T) {
+	const iface = "org.freedesktop.ModemManager1.Modem.Signal"
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+				if diff := cmp.Diff(iface+".Setup", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff([]interface{}{uint32(5)}, args); diff != "" {
+					t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+				}
+
+				return nil
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff(iface, dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				return map[string]dbus.Variant{
+					"Rate": dbus.MakeVariant(uint32(5)),
+					"Lte": dbus.MakeVariant(map[string]dbus.Variant{
+						"rsrp": dbus.MakeVariant(float64(-117)),
+					}),
+				}, nil
+			},
+			watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				// The modem begins reporting 5G data, and then the LTE data
+				// changes.
+				sigs := make(chan *dbus.Signal, 2)
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"Nr5g": dbus.MakeVariant(map[string]dbus.Variant{
+						"rsrp": dbus.MakeVariant(float64(-90)),
+					})},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"Lte": dbus.MakeVariant(map[string]dbus.Variant{
+						"rsrp": dbus.MakeVariant(float64(-110)),
+					})},
+					[]string{},
+				}}
+				close(sigs)
+
+				return sigs, nil
+			},
+		},
+	}
+
+	signals, err := m.WatchSignal(context.Background(), 5*time.Second)
+	if err != nil {
+		t.Fatalf("failed to watch signal: %v", err)
+	}
+
+	var got []*Signal
+	for s := range signals {
+		got = append(got, s)
+	}
+
+	want := []*Signal{
+		{
+			Rate: 5 * time.Second,
+			LTE:  &LTESignal{RSRP: -117},
+			NR5G: &NR5GSignal{RSRP: -90},
+		},
+		{
+			Rate: 5 * time.Second,
+			LTE:  &LTESignal{RSRP: -110},
+			NR5G: &NR5GSignal{RSRP: -90},
+		},
+	}
+
+	if diff := cmp.Diff(want, got, ignoreTaken); diff != "" {
+		t.Fatalf("unexpected Signals (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchSignalSetupError(t *testing.T) {
+	done := make(chan struct{})
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, _ string, _ dbus.ObjectPath, _ interface{}, _ ...interface{}) error {
+				return errors.New("setup failed")
+			},
+			watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				// The watch must be stopped when setup fails.
+				sigs := make(chan *dbus.Signal)
+				go func() {
+					<-ctx.Done()
+					close(sigs)
+					close(done)
+				}()
+
+				return sigs, nil
+			},
+		},
+	}
+
+	if _, err := m.WatchSignal(context.Background(), 5*time.Second); err == nil {
+		t.Fatal("expected an error, but none occurred")
+	}
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch was not stopped")
+	}
+}
+
+func TestSignalIsStale(t *testing.T) {
+	taken := time.Date(2020, time.July, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		s     Signal
+		now   time.Time
+		stale bool
+	}{
+		{
+			name:  "refresh disabled",
+			s:     Signal{Taken: taken},
+			now:   taken,
+			stale: true,
+		},
+		{
+			name: "fresh",
+			s:    Signal{Rate: 10 * time.Second, Taken: taken},
+			now:  taken.Add(20 * time.Second),
+		},
+		{
+			name:  "stale",
+			s:     Signal{Rate: 10 * time.Second, Taken: taken},
+			now:   taken.Add(21 * time.Second),
+			stale: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.stale, tt.s.isStale(tt.now)); diff != "" {
+				t.Fatalf("unexpected staleness (-want +got):\n%s", diff)
+			}
+		})
+	}
 }
@@ -0,0 +1,204 @@
+package modemmanager
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// signalTeardownTimeout bounds the time a SignalPoller spends disabling
+// polling once it stops.
+const signalTeardownTimeout = 10 * time.Second
+
+// A SignalPoller manages a Modem's extended signal quality refresh rate on
+// behalf of any number of subscribers. The Modem is polled at the Fast rate
+// while its signal is weak or changing and at the Slow rate once its signal
+// has been stable for StableUpdates updates. When the last subscriber
+// unsubscribes, polling is disabled to save power.
+//
+// A signal is considered to be changing when its number of bars, as reported
+// by Signal.Bars, changes between updates.
+type SignalPoller struct {
+	// Fast and Slow are the refresh rates used while the signal is weak or
+	// changing, and while it is stable, respectively.
+	Fast, Slow time.Duration
+
+	// MinBars is the minimum number of bars for a signal to be considered
+	// stable, so weak signals are always polled at the Fast rate.
+	MinBars int
+
+	// StableUpdates is the number of consecutive updates without a change
+	// after which the Slow rate is used.
+	StableUpdates int
+
+	m *Modem
+
+	// runMu serializes starting and stopping the polling goroutine, while
+	// subsMu protects the subscriber channels it delivers to.
+	runMu sync.Mutex
+	stop  func()
+	done  chan struct{}
+
+	subsMu sync.Mutex
+	subs   map[chan *Signal]struct{}
+}
+
+// NewSignalPoller creates a SignalPoller for the Modem with default settings,
+// which may be modified before the first call to Subscribe.
+func NewSignalPoller(m *Modem) *SignalPoller {
+	return &SignalPoller{
+		Fast:          2 * time.Second,
+		Slow:          30 * time.Second,
+		MinBars:       2,
+		StableUpdates: 5,
+
+		m:    m,
+		subs: make(map[chan *Signal]struct{}),
+	}
+}
+
+// Subscribe begins delivering Signal updates on the returned channel, starting
+// to poll the Modem if necessary. The subscription ends and the channel is
+// closed when ctx is canceled, or when the Modem's signal updates end, such
+// as when the Modem is removed. Updates are dropped in favor of newer ones if
+// the subscriber does not keep up.
+func (p *SignalPoller) Subscribe(ctx context.Context) (<-chan *Signal, error) {
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	if p.stop == nil {
+		// The polling goroutine outlives the first subscriber's context, so
+		// it is only stopped by unsubscribe.
+		pctx, cancel := context.WithCancel(context.Background())
+		signals, err := p.m.WatchSignal(pctx, p.Fast)
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+
+		p.stop = cancel
+		p.done = make(chan struct{})
+		go p.run(pctx, signals, p.done)
+	}
+
+	ch := make(chan *Signal, 1)
+	p.subsMu.Lock()
+	p.subs[ch] = struct{}{}
+	p.subsMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		p.unsubscribe(ch)
+	}()
+
+	return ch, nil
+}
+
+// unsubscribe removes a subscriber and stops polling if no subscribers remain.
+func (p *SignalPoller) unsubscribe(ch chan *Signal) {
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	p.subsMu.Lock()
+	_, ok := p.subs[ch]
+	if ok {
+		delete(p.subs, ch)
+		close(ch)
+	}
+	n := len(p.subs)
+	p.subsMu.Unlock()
+
+	// The subscriber may already have been removed by reset.
+	if !ok || n > 0 || p.stop == nil {
+		return
+	}
+
+	// Wait for polling to be disabled so a new subscriber cannot race with
+	// the teardown.
+	p.stop()
+	<-p.done
+	p.stop, p.done = nil, nil
+}
+
+// run adjusts the refresh rate for and delivers each Signal until ctx is
+// canceled or signals is closed, and then disables polling.
+func (p *SignalPoller) run(ctx context.Context, signals <-chan *Signal, done chan struct{}) {
+	defer func() {
+		// ctx may already be canceled, so use a fresh context for the
+		// teardown.
+		tctx, cancel := context.WithTimeout(context.Background(), signalTeardownTimeout)
+		defer cancel()
+		_ = p.m.SignalSetup(tctx, 0)
+
+		close(done)
+		p.reset(done)
+	}()
+
+	var (
+		rate   = p.Fast
+		prev   *Signal
+		stable int
+	)
+
+	for s := range signals {
+		bars := s.Bars()
+		if prev != nil && bars == prev.Bars() && bars >= p.MinBars {
+			stable++
+		} else {
+			stable = 0
+		}
+		prev = s
+
+		want := p.Fast
+		if stable >= p.StableUpdates {
+			want = p.Slow
+		}
+
+		// Retry on the next update if the rate could not be changed.
+		if want != rate && p.m.SignalSetup(ctx, want) == nil {
+			rate = want
+		}
+
+		p.broadcast(s)
+	}
+}
+
+// reset clears the polling state and closes the remaining subscribers if the
+// run goroutine which closed done stopped on its own, so that a later call to
+// Subscribe starts polling again.
+func (p *SignalPoller) reset(done chan struct{}) {
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	if p.done != done {
+		// Stopped by unsubscribe.
+		return
+	}
+
+	p.stop()
+	p.stop, p.done = nil, nil
+
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+
+	for ch := range p.subs {
+		delete(p.subs, ch)
+		close(ch)
+	}
+}
+
+// broadcast delivers s to each subscriber, replacing any update the
+// subscriber has not yet received.
+func (p *SignalPoller) broadcast(s *Signal) {
+	p.subsMu.Lock()
+	defer p.subsMu.Unlock()
+
+	for ch := range p.subs {
+		select {
+		case <-ch:
+		default:
+		}
+
+		ch <- s
+	}
+}
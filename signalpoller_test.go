@@ -0,0 +1,201 @@
+package modemmanager
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSignalPoller(t *testing.T) {
+	const iface = "org.freedesktop.ModemManager1.Modem.Signal"
+
+	var (
+		mu    sync.Mutex
+		rates []time.Duration
+
+		sigs = make(chan *dbus.Signal)
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+				if diff := cmp.Diff(iface+".Setup", method); diff != "" {
+					t.Errorf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				mu.Lock()
+				defer mu.Unlock()
+				rates = append(rates, time.Duration(args[0].(uint32))*time.Second)
+				return nil
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{}, nil
+			},
+			watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				out := make(chan *dbus.Signal)
+				go func() {
+					defer close(out)
+					for {
+						select {
+						case <-ctx.Done():
+							return
+						case s := <-sigs:
+							out <- s
+						}
+					}
+				}()
+
+				return out, nil
+			},
+		},
+	}
+
+	p := NewSignalPoller(m)
+	p.Fast = 1 * time.Second
+	p.Slow = 10 * time.Second
+	p.StableUpdates = 2
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals, err := p.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	// Deliver each update and wait for the subscriber to receive it, which
+	// guarantees the refresh rate has been adjusted.
+	update := func(rsrp float64) {
+		sigs <- &dbus.Signal{Body: []interface{}{
+			iface,
+			map[string]dbus.Variant{"Lte": dbus.MakeVariant(map[string]dbus.Variant{
+				"rsrp": dbus.MakeVariant(rsrp),
+			})},
+			[]string{},
+		}}
+
+		s := <-signals
+		if diff := cmp.Diff(rsrp, s.LTE.RSRP); diff != "" {
+			t.Fatalf("unexpected RSRP (-want +got):\n%s", diff)
+		}
+	}
+
+	// A strong stable signal slows polling, and then a weak signal speeds it
+	// back up.
+	update(-80)
+	update(-81)
+	update(-82)
+	update(-112)
+
+	// Canceling the only subscription disables polling and closes the channel.
+	cancel()
+	if _, ok := <-signals; ok {
+		t.Fatal("expected closed signals channel")
+	}
+
+	// Wait for the teardown, which completes asynchronously.
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	want := []time.Duration{1 * time.Second, 10 * time.Second, 1 * time.Second, 0}
+	if diff := cmp.Diff(want, rates); diff != "" {
+		t.Fatalf("unexpected refresh rates (-want +got):\n%s", diff)
+	}
+}
+
+func TestSignalPollerWatchClosed(t *testing.T) {
+	var (
+		mu        sync.Mutex
+		watches   int
+		deadlines []bool
+
+		sigs = make(chan *dbus.Signal)
+	)
+
+	m := &Modem{
+		c: &Client{
+			call: func(ctx context.Context, _ string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+				if args[0].(uint32) != 0 {
+					return nil
+				}
+
+				// Disabling polling must not block forever.
+				_, ok := ctx.Deadline()
+
+				mu.Lock()
+				defer mu.Unlock()
+				deadlines = append(deadlines, ok)
+				return nil
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{}, nil
+			},
+			watch: func(ctx context.Context, _ dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				watches++
+
+				// The first watch ends on its own, as if the Modem was
+				// removed.
+				if watches == 1 {
+					return sigs, nil
+				}
+
+				return testSignals(ctx), nil
+			},
+		},
+	}
+
+	p := NewSignalPoller(m)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals, err := p.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("failed to subscribe: %v", err)
+	}
+
+	close(sigs)
+	if _, ok := <-signals; ok {
+		t.Fatal("expected closed signals channel")
+	}
+
+	// A new subscriber starts polling again, and the first subscriber's
+	// cancelation is a no-op.
+	signals, err = p.Subscribe(ctx)
+	if err != nil {
+		t.Fatalf("failed to subscribe again: %v", err)
+	}
+
+	cancel()
+	if _, ok := <-signals; ok {
+		t.Fatal("expected closed signals channel")
+	}
+
+	// Wait for the teardown, which completes asynchronously.
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	if p.stop != nil {
+		t.Fatal("polling was not stopped")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if diff := cmp.Diff(2, watches); diff != "" {
+		t.Fatalf("unexpected number of watches (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff([]bool{true, true}, deadlines); diff != "" {
+		t.Fatalf("unexpected teardown deadlines (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,201 @@
+package modemmanager
+
+import "math"
+
+// Signal strength thresholds in dBm for 1 through 4 bars, matching those used
+// by Android's CellSignalStrength implementations.
+var (
+	barsCDMA = [4]float64{-100, -95, -85, -75}
+	barsEVDO = [4]float64{-105, -90, -75, -65}
+	barsGSM  = [4]float64{-107, -103, -97, -89}
+	barsLTE  = [4]float64{-115, -105, -95, -85}
+	barsNR5G = [4]float64{-110, -90, -80, -65}
+	barsUMTS = [4]float64{-115, -105, -95, -85}
+
+	// RSRQ thresholds in dB.
+	barsLTERSRQ  = [4]float64{-19, -17, -14, -12}
+	barsNR5GRSRQ = [4]float64{-31, -19, -7, 6}
+)
+
+// Bars returns the highest number of signal strength bars, from 0 to 4, of
+// any network in the Signal. If s contains no data, Bars returns 0.
+func (s *Signal) Bars() int {
+	var n int
+	for _, b := range []interface{ Bars() int }{
+		s.CDMA, s.EVDO, s.GSM, s.LTE, s.NR5G, s.UMTS,
+	} {
+		if b := b.Bars(); b > n {
+			n = b
+		}
+	}
+
+	return n
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the RSSI.
+func (s *CDMASignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return bars(s.RSSI, barsCDMA)
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the RSSI.
+func (s *CDMASignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return quality(s.RSSI, -113, -51)
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the RSSI.
+func (s *EVDOSignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return bars(s.RSSI, barsEVDO)
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the RSSI.
+func (s *EVDOSignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return quality(s.RSSI, -113, -51)
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the RSSI.
+func (s *GSMSignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return bars(s.RSSI, barsGSM)
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the RSSI.
+// The range matches the -113 to -51 dBm range of the AT+CSQ command used by
+// ModemManager to compute Modem signal quality.
+func (s *GSMSignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return quality(s.RSSI, -113, -51)
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the worse
+// of the RSRP and RSRQ, as Android does when RSRQ is enabled. A zero RSRQ is
+// treated as not reported and only the RSRP is used. RSSI is not used, since it
+// includes interference and noise from the entire channel.
+func (s *LTESignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return worse(bars(s.RSRP, barsLTE), s.RSRQ, func() int {
+		return bars(s.RSRQ, barsLTERSRQ)
+	})
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the worse
+// of the RSRP within the -140 to -44 dBm range and the RSRQ within the -19.5 to
+// -3 dB range defined by 3GPP TS 36.133. As with Bars, a zero RSRQ is treated
+// as not reported.
+func (s *LTESignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return worse(quality(s.RSRP, -140, -44), s.RSRQ, func() int {
+		return quality(s.RSRQ, -19.5, -3)
+	})
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the worse
+// of the RSRP and RSRQ. A zero RSRQ is treated as not reported and only the
+// RSRP is used.
+func (s *NR5GSignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return worse(bars(s.RSRP, barsNR5G), s.RSRQ, func() int {
+		return bars(s.RSRQ, barsNR5GRSRQ)
+	})
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the worse
+// of the RSRP within the -156 to -31 dBm range and the RSRQ within the -43 to
+// 20 dB range defined by 3GPP TS 38.133. As with Bars, a zero RSRQ is treated
+// as not reported.
+func (s *NR5GSignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return worse(quality(s.RSRP, -156, -31), s.RSRQ, func() int {
+		return quality(s.RSRQ, -43, 20)
+	})
+}
+
+// Bars returns the number of signal strength bars, from 0 to 4, for the RSCP.
+func (s *UMTSSignal) Bars() int {
+	if s == nil {
+		return 0
+	}
+
+	return bars(s.RSCP, barsUMTS)
+}
+
+// Quality returns the signal quality percentage, from 0 to 100, for the RSCP
+// within the -120 to -25 dBm range defined by 3GPP TS 25.133.
+func (s *UMTSSignal) Quality() int {
+	if s == nil {
+		return 0
+	}
+
+	return quality(s.RSCP, -120, -25)
+}
+
+// bars returns the number of thresholds met or exceeded by v.
+func bars(v float64, thresholds [4]float64) int {
+	var n int
+	for _, t := range thresholds {
+		if v >= t {
+			n++
+		}
+	}
+
+	return n
+}
+
+// worse returns the lower of n and the value of fn, or n if rsrq is zero and
+// therefore was not reported.
+func worse(n int, rsrq float64, fn func() int) int {
+	if rsrq == 0 {
+		return n
+	}
+
+	if m := fn(); m < n {
+		return m
+	}
+
+	return n
+}
+
+// quality linearly maps v within the range min to max to a percentage.
+func quality(v, min, max float64) int {
+	switch {
+	case v <= min:
+		return 0
+	case v >= max:
+		return 100
+	default:
+		return int(math.Round((v - min) / (max - min) * 100))
+	}
+}
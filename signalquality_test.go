@@ -0,0 +1,123 @@
+package modemmanager
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSignalQuality(t *testing.T) {
+	type rat interface {
+		Bars() int
+		Quality() int
+	}
+
+	tests := []struct {
+		name    string
+		s       rat
+		bars    int
+		quality int
+	}{
+		{
+			name: "nil LTE",
+			s:    (*LTESignal)(nil),
+		},
+		{
+			name: "LTE none",
+			s:    &LTESignal{RSRP: -140},
+		},
+		{
+			name:    "LTE poor",
+			s:       &LTESignal{RSRP: -115},
+			bars:    1,
+			quality: 26,
+		},
+		{
+			name:    "LTE great",
+			s:       &LTESignal{RSRP: -80},
+			bars:    4,
+			quality: 63,
+		},
+		{
+			name:    "LTE maximum",
+			s:       &LTESignal{RSRP: -30},
+			bars:    4,
+			quality: 100,
+		},
+		{
+			name:    "LTE great RSRP, poor RSRQ",
+			s:       &LTESignal{RSRP: -80, RSRQ: -18},
+			bars:    1,
+			quality: 9,
+		},
+		{
+			name:    "LTE good RSRP, great RSRQ",
+			s:       &LTESignal{RSRP: -100, RSRQ: -6},
+			bars:    2,
+			quality: 42,
+		},
+		{
+			name:    "NR5G good",
+			s:       &NR5GSignal{RSRP: -80},
+			bars:    3,
+			quality: 61,
+		},
+		{
+			name:    "NR5G good RSRP, moderate RSRQ",
+			s:       &NR5GSignal{RSRP: -80, RSRQ: -15},
+			bars:    2,
+			quality: 44,
+		},
+		{
+			name:    "UMTS moderate",
+			s:       &UMTSSignal{RSCP: -100},
+			bars:    2,
+			quality: 21,
+		},
+		{
+			name:    "GSM good",
+			s:       &GSMSignal{RSSI: -97},
+			bars:    3,
+			quality: 26,
+		},
+		{
+			name:    "CDMA great",
+			s:       &CDMASignal{RSSI: -75},
+			bars:    4,
+			quality: 61,
+		},
+		{
+			name:    "EVDO poor",
+			s:       &EVDOSignal{RSSI: -100},
+			bars:    1,
+			quality: 21,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.bars, tt.s.Bars()); diff != "" {
+				t.Fatalf("unexpected bars (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.quality, tt.s.Quality()); diff != "" {
+				t.Fatalf("unexpected quality (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSignalBars(t *testing.T) {
+	s := &Signal{
+		LTE:  &LTESignal{RSRP: -110},
+		NR5G: &NR5GSignal{RSRP: -70},
+	}
+
+	if diff := cmp.Diff(3, s.Bars()); diff != "" {
+		t.Fatalf("unexpected bars (-want +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(0, (&Signal{}).Bars()); diff != "" {
+		t.Fatalf("unexpected bars (-want +got):\n%s", diff)
+	}
+}
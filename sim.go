@@ -0,0 +1,437 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Errors which may be returned when unlocking or managing the PIN of a SIM.
+// These errors are wrapped in a *PINError.
+var (
+	// ErrIncorrectPassword indicates that an incorrect SIM PIN or PUK was
+	// provided.
+	ErrIncorrectPassword = errors.New("incorrect SIM PIN or PUK")
+
+	// ErrPUKRequired indicates that the SIM has been locked due to too many
+	// incorrect PIN attempts and a PUK is required to unlock it.
+	ErrPUKRequired = errors.New("SIM PUK required")
+)
+
+// A PINError is returned when a SIM PIN or PUK operation fails due to an
+// incorrect code or a locked SIM.
+type PINError struct {
+	// Err indicates why the operation failed: ErrIncorrectPassword or
+	// ErrPUKRequired.
+	Err error
+
+	// Retries contains the number of remaining unlock attempts for each type
+	// of Lock, refreshed from the modem after the failure. Retries is nil if
+	// the counts could not be fetched.
+	Retries map[Lock]int
+
+	// The original error, typically an *Error describing the SIM or Modem
+	// operation which failed.
+	err error
+}
+
+// Error implements error.
+func (e *PINError) Error() string {
+	if errors.Is(e.err, e.Err) {
+		// The original error already describes the category.
+		return e.err.Error()
+	}
+
+	return fmt.Sprintf("%v: %v", e.Err, e.err)
+}
+
+// Is implements errors.Is, so that 'errors.Is(err, ErrIncorrectPassword)' and
+// similar comparisons work as expected.
+func (e *PINError) Is(target error) bool { return target == e.Err }
+
+// Unwrap implements errors unwrapping, so that the original error, such as an
+// *Error, is available using errors.As.
+func (e *PINError) Unwrap() error { return e.err }
+
+// A SIM is a SIM card used by a Modem.
+//
+// Calling methods on a SIM requires elevated privileges. If permission is
+// denied by D-Bus, an error compatible with 'errors.Is(err, os.ErrPermission)'
+// is returned when methods are called. If an incorrect PIN or PUK is provided,
+// a *PINError compatible with 'errors.Is(err, ErrIncorrectPassword)' is
+// returned. Malformed PINs and PUKs are rejected before they are sent to the
+// SIM, so they do not use up its unlock attempts.
+type SIM struct {
+	Index              int
+	Active             bool
+	EmergencyNumbers   []string
+	ESIMStatus         ESIMStatus
+	GID1, GID2         []byte
+	Identifier         string
+	IMSI               string
+	OperatorIdentifier string
+	OperatorName       string
+	PreferredNetworks  []PreferredNetwork
+	Removability       SIMRemovability
+	Type               SIMType
+
+	c     *Client
+	modem dbus.ObjectPath
+}
+
+// A SIMType is the type of a SIM.
+type SIMType int
+
+// Possible SIMType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSimType.
+const (
+	SIMTypeUnknown SIMType = iota
+	SIMTypePhysical
+	SIMTypeESIM
+)
+
+// An ESIMStatus indicates whether an eSIM has profiles installed.
+type ESIMStatus int
+
+// Possible ESIMStatus values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSimEsimStatus.
+const (
+	ESIMStatusUnknown ESIMStatus = iota
+	ESIMStatusNoProfiles
+	ESIMStatusWithProfiles
+)
+
+// A SIMRemovability indicates whether a SIM can be removed from a modem.
+type SIMRemovability int
+
+// Possible SIMRemovability values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSimRemovability.
+const (
+	SIMRemovabilityUnknown SIMRemovability = iota
+	SIMRemovabilityRemovable
+	SIMRemovabilityNotRemovable
+)
+
+// A PreferredNetwork is an entry in a SIM's list of preferred networks.
+type PreferredNetwork struct {
+	OperatorCode     string
+	AccessTechnology AccessTechnology
+
+	// OperatorName is resolved from OperatorCode by Client.OperatorResolver,
+	// and is empty if the operator is unknown. It is not stored on the SIM.
+	OperatorName string
+}
+
+// A SIMChange is an event which occurs when a modem's SIM or primary SIM slot
+// changes, such as when a SIM is inserted or removed, or the primary SIM slot
+// is switched.
+type SIMChange struct {
+	// PrimarySIMSlot is the modem's primary SIM slot number, or 0 if the
+	// modem does not support multiple SIM slots.
+	PrimarySIMSlot int
+
+	// SIM is the SIM currently used by the modem, or nil if no SIM is
+	// present.
+	SIM *SIM
+}
+
+// SIM fetches the SIM currently used by the Modem. If the Modem has no SIM, an
+// error compatible with 'errors.Is(err, os.ErrNotExist)' is returned.
+func (m *Modem) SIM(ctx context.Context) (*SIM, error) {
+	return m.c.sim(ctx, objectPath("Modem", strconv.Itoa(m.Index)), m.sim)
+}
+
+// WatchSIM watches for changes to the Modem's SIM and primary SIM slot. Each
+// change is delivered with the SIM currently used by the Modem on the returned
+// channel, which is closed when the context is canceled. Property updates
+// which leave both the SIM and primary SIM slot unchanged are not delivered.
+func (m *Modem) WatchSIM(ctx context.Context) (<-chan SIMChange, error) {
+	changes, err := m.c.watchProperties(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Track the SIM state locally; the Modem itself is not updated.
+	var (
+		sim  = m.sim
+		slot = m.PrimarySIMSlot
+	)
+
+	return forward(ctx, changes, func(ps map[string]dbus.Variant) (SIMChange, error) {
+		var (
+			newSIM  = sim
+			newSlot = slot
+		)
+
+		for k, v := range ps {
+			vp := newValueParser(v)
+			switch k {
+			case "PrimarySimSlot":
+				newSlot = vp.Int()
+			case "Sim":
+				newSIM = vp.ObjectPath()
+			default:
+				continue
+			}
+
+			if err := vp.Err(); err != nil {
+				return SIMChange{}, fmt.Errorf("error parsing %q: %v", k, err)
+			}
+		}
+		if newSIM == sim && newSlot == slot {
+			return SIMChange{}, errors.New("no SIM changes")
+		}
+		sim, slot = newSIM, newSlot
+
+		s, err := m.c.sim(ctx, objectPath("Modem", strconv.Itoa(m.Index)), sim)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return SIMChange{}, err
+		}
+
+		return SIMChange{
+			PrimarySIMSlot: slot,
+			SIM:            s,
+		}, nil
+	}), nil
+}
+
+// SendPin sends the PIN to unlock the SIM.
+func (s *SIM) SendPin(ctx context.Context, pin string) error {
+	if err := validatePIN(pin); err != nil {
+		return err
+	}
+
+	err := s.c.call(
+		ctx,
+		interfacePath("Sim", "SendPin"),
+		objectPath("SIM", strconv.Itoa(s.Index)),
+		nil,
+		pin,
+	)
+	if err != nil {
+		return s.c.toPINError(ctx, s.modem, err)
+	}
+
+	return nil
+}
+
+// SendPuk sends the PUK and a new PIN to unlock the SIM after too many
+// incorrect PIN attempts.
+func (s *SIM) SendPuk(ctx context.Context, puk, newPin string) error {
+	if err := validatePUK(puk); err != nil {
+		return err
+	}
+	if err := validatePIN(newPin); err != nil {
+		return err
+	}
+
+	err := s.c.call(
+		ctx,
+		interfacePath("Sim", "SendPuk"),
+		objectPath("SIM", strconv.Itoa(s.Index)),
+		nil,
+		puk, newPin,
+	)
+	if err != nil {
+		return s.c.toPINError(ctx, s.modem, err)
+	}
+
+	return nil
+}
+
+// EnablePin enables or disables the SIM's PIN lock. The current PIN must be
+// provided in either case.
+func (s *SIM) EnablePin(ctx context.Context, pin string, enabled bool) error {
+	if err := validatePIN(pin); err != nil {
+		return err
+	}
+
+	err := s.c.call(
+		ctx,
+		interfacePath("Sim", "EnablePin"),
+		objectPath("SIM", strconv.Itoa(s.Index)),
+		nil,
+		pin, enabled,
+	)
+	if err != nil {
+		return s.c.toPINError(ctx, s.modem, err)
+	}
+
+	return nil
+}
+
+// ChangePin changes the SIM's PIN from old to new.
+func (s *SIM) ChangePin(ctx context.Context, old, new string) error {
+	if err := validatePIN(old); err != nil {
+		return err
+	}
+	if err := validatePIN(new); err != nil {
+		return err
+	}
+
+	err := s.c.call(
+		ctx,
+		interfacePath("Sim", "ChangePin"),
+		objectPath("SIM", strconv.Itoa(s.Index)),
+		nil,
+		old, new,
+	)
+	if err != nil {
+		return s.c.toPINError(ctx, s.modem, err)
+	}
+
+	return nil
+}
+
+// SetPreferredNetworks replaces the SIM's list of preferred networks. This
+// method requires ModemManager 1.18 or newer.
+func (s *SIM) SetPreferredNetworks(ctx context.Context, networks []PreferredNetwork) error {
+	// Pack each network as a D-Bus (su) struct.
+	type network struct {
+		OperatorCode     string
+		AccessTechnology uint32
+	}
+
+	ns := make([]network, 0, len(networks))
+	for _, n := range networks {
+		ns = append(ns, network{
+			OperatorCode:     n.OperatorCode,
+			AccessTechnology: uint32(n.AccessTechnology),
+		})
+	}
+
+	err := s.c.call(
+		ctx,
+		interfacePath("Sim", "SetPreferredNetworks"),
+		objectPath("SIM", strconv.Itoa(s.Index)),
+		nil,
+		ns,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// sim fetches a SIM by its object path and notes the object path of the modem
+// which uses it.
+func (c *Client) sim(ctx context.Context, modem, op dbus.ObjectPath) (*SIM, error) {
+	// ModemManager uses an empty object path to indicate that no SIM is
+	// present.
+	if op == "" || op == "/" {
+		return nil, fmt.Errorf("no SIM present: %w", os.ErrNotExist)
+	}
+
+	ps, err := c.getAll(ctx, op, interfacePath("Sim"))
+	if err != nil {
+		// Unknown method indicates that the SIM doesn't exist.
+		return nil, toNotExist(err, unknownMethodError)
+	}
+
+	// Note the SIM's index in the struct by fetching that index from the last
+	// element of the D-Bus object path.
+	idx, err := strconv.Atoi(path.Base(string(op)))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SIM{
+		Index: idx,
+		c:     c,
+		modem: modem,
+	}
+
+	if err := s.parse(ps); err != nil {
+		return nil, err
+	}
+
+	s.OperatorName = c.operatorName(s.OperatorIdentifier, s.OperatorName)
+	for i, n := range s.PreferredNetworks {
+		s.PreferredNetworks[i].OperatorName = c.operatorName(n.OperatorCode, "")
+	}
+
+	return s, nil
+}
+
+// parse parses a properties map into the SIM's fields.
+func (s *SIM) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "Active":
+			s.Active = vp.Bool()
+		case "EmergencyNumbers":
+			s.EmergencyNumbers = vp.Strings()
+		case "EsimStatus":
+			s.ESIMStatus = ESIMStatus(vp.Int())
+		case "Gid1":
+			s.GID1 = vp.Bytes()
+		case "Gid2":
+			s.GID2 = vp.Bytes()
+		case "Imsi":
+			s.IMSI = vp.String()
+		case "OperatorIdentifier":
+			s.OperatorIdentifier = vp.String()
+		case "OperatorName":
+			s.OperatorName = vp.String()
+		case "PreferredNetworks":
+			s.PreferredNetworks = vp.PreferredNetworks()
+		case "Removability":
+			s.Removability = SIMRemovability(vp.Int())
+		case "SimIdentifier":
+			s.Identifier = vp.String()
+		case "SimType":
+			s.Type = SIMType(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
+
+// toPINError converts a D-Bus PIN, PUK, or control key error to a *PINError
+// containing ErrIncorrectPassword or ErrPUKRequired and the remaining unlock
+// attempts reported by the modem. Any other error is converted by
+// toPermission.
+func (c *Client) toPINError(ctx context.Context, modem dbus.ObjectPath, err error) error {
+	var derr dbus.Error
+	if !errors.As(err, &derr) {
+		return err
+	}
+
+	perr := &PINError{err: err}
+	switch derr.Name {
+	case incorrectPasswordError:
+		perr.Err = ErrIncorrectPassword
+	case simPUKError:
+		perr.Err = ErrPUKRequired
+	default:
+		return toPermission(err)
+	}
+
+	// The retry counts are only available from the modem. A failure to fetch
+	// them is not fatal since the PIN error is more important to the caller.
+	if modem != "" {
+		if v, err := c.get(ctx, modem, interfacePath("Modem"), "UnlockRetries"); err == nil {
+			vp := newValueParser(v)
+			if retries := vp.UnlockRetries(); vp.Err() == nil {
+				perr.Retries = retries
+			}
+		}
+	}
+
+	return perr
+}
@@ -0,0 +1,396 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemSIMNotFound(t *testing.T) {
+	// ModemManager reports the root object path when no SIM is present.
+	m := &Modem{
+		c:   &Client{},
+		sim: "/",
+	}
+
+	_, err := m.SIM(context.Background())
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected is not exist error, but got: %v", err)
+	}
+
+	t.Logf("err: %v", err)
+}
+
+func TestModemSIMOK(t *testing.T) {
+	m := &Modem{
+		// Verify all of the expected inputs before returning canned properties.
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"Active":             dbus.MakeVariant(true),
+				"EmergencyNumbers":   dbus.MakeVariant([]string{"911", "112"}),
+				"EsimStatus":         dbus.MakeVariant(uint32(ESIMStatusWithProfiles)),
+				"Gid1":               dbus.MakeVariant([]byte{0xba, 0x01}),
+				"Gid2":               dbus.MakeVariant([]byte{}),
+				"Imsi":               dbus.MakeVariant("310410123456789"),
+				"OperatorIdentifier": dbus.MakeVariant("310410"),
+				"OperatorName":       dbus.MakeVariant("AT&T"),
+				"PreferredNetworks": dbus.MakeVariant([][]interface{}{
+					{"310410", uint32(AccessTechnologyLTE)},
+					{"310260", uint32(AccessTechnologyLTE | AccessTechnologyUMTS)},
+				}),
+				"Removability":  dbus.MakeVariant(uint32(SIMRemovabilityNotRemovable)),
+				"SimIdentifier": dbus.MakeVariant("89014103211118510720"),
+				"SimType":       dbus.MakeVariant(uint32(SIMTypeESIM)),
+			}, nil
+		}},
+		sim: "/org/freedesktop/ModemManager1/SIM/0",
+	}
+
+	sim, err := m.SIM(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get SIM: %v", err)
+	}
+
+	want := &SIM{
+		Index:              0,
+		Active:             true,
+		EmergencyNumbers:   []string{"911", "112"},
+		ESIMStatus:         ESIMStatusWithProfiles,
+		GID1:               []byte{0xba, 0x01},
+		GID2:               []byte{},
+		Identifier:         "89014103211118510720",
+		IMSI:               "310410123456789",
+		OperatorIdentifier: "310410",
+		OperatorName:       "AT&T",
+		PreferredNetworks: []PreferredNetwork{
+			{
+				OperatorCode:     "310410",
+				AccessTechnology: AccessTechnologyLTE,
+			},
+			{
+				OperatorCode:     "310260",
+				AccessTechnology: AccessTechnologyLTE | AccessTechnologyUMTS,
+			},
+		},
+		Removability: SIMRemovabilityNotRemovable,
+		Type:         SIMTypeESIM,
+	}
+
+	if diff := cmp.Diff(want, sim, cmpopts.IgnoreUnexported(SIM{})); diff != "" {
+		t.Fatalf("unexpected SIM (-want +got):\n%s", diff)
+	}
+}
+
+func TestSIMSendPinErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		is   error
+	}{
+		{
+			name: "permission denied",
+			err:  dbus.Error{Name: unauthorizedError},
+			is:   os.ErrPermission,
+		},
+		{
+			name: "incorrect password",
+			err:  dbus.Error{Name: incorrectPasswordError},
+			is:   ErrIncorrectPassword,
+		},
+		{
+			name: "PUK required",
+			err:  dbus.Error{Name: simPUKError},
+			is:   ErrPUKRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SIM{
+				c: &Client{call: func(_ context.Context, _ string, _ dbus.ObjectPath, _ interface{}, _ ...interface{}) error {
+					return tt.err
+				}},
+			}
+
+			err := s.SendPin(context.Background(), "1234")
+			if !errors.Is(err, tt.is) {
+				t.Fatalf("expected %v, but got: %v", tt.is, err)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
+
+func TestSIMSendPinInvalid(t *testing.T) {
+	s := &SIM{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, _ ...interface{}) error {
+			t.Fatalf("unexpected method call: %q", method)
+			return nil
+		}},
+	}
+
+	// A malformed PIN must not be sent to the SIM.
+	for _, pin := range []string{"", "123", "123456789", "12a4"} {
+		if err := s.SendPin(context.Background(), pin); err == nil {
+			t.Fatalf("expected an error for PIN %q, but none occurred", pin)
+		}
+	}
+}
+
+func TestSIMSendPuk(t *testing.T) {
+	s := &SIM{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim.SendPuk", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(nil, out); diff != "" {
+				t.Fatalf("unexpected out value (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"12345678", "1234"}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			// No return value.
+			return nil
+		}},
+	}
+
+	if err := s.SendPuk(context.Background(), "12345678", "1234"); err != nil {
+		t.Fatalf("failed to send PUK: %v", err)
+	}
+}
+
+func TestSIMEnablePin(t *testing.T) {
+	s := &SIM{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim.EnablePin", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"1234", false}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	if err := s.EnablePin(context.Background(), "1234", false); err != nil {
+		t.Fatalf("failed to disable PIN: %v", err)
+	}
+}
+
+func TestSIMChangePinIncorrectPassword(t *testing.T) {
+	s := &SIM{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim.ChangePin", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"0000", "1234"}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: incorrectPasswordError}
+		}},
+	}
+
+	err := s.ChangePin(context.Background(), "0000", "1234")
+	if !errors.Is(err, ErrIncorrectPassword) {
+		t.Fatalf("expected incorrect password error, but got: %v", err)
+	}
+}
+
+func TestSIMSetPreferredNetworks(t *testing.T) {
+	s := &SIM{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sim.SetPreferredNetworks", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			// Verify the arguments using their D-Bus signature rather than
+			// the internal Go types used to pack them.
+			if diff := cmp.Diff("a(su)", dbus.SignatureOf(args...).String()); diff != "" {
+				t.Fatalf("unexpected arguments signature (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	err := s.SetPreferredNetworks(context.Background(), []PreferredNetwork{{
+		OperatorCode:     "310410",
+		AccessTechnology: AccessTechnologyLTE,
+	}})
+	if err != nil {
+		t.Fatalf("failed to set preferred networks: %v", err)
+	}
+}
+
+func TestModemWatchSIM(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				const iface = "org.freedesktop.ModemManager1.Modem"
+
+				// The SIM and slot are reported unchanged, the SIM is removed,
+				// unrelated properties change, and then the secondary slot
+				// becomes primary with a new SIM.
+				sigs := make(chan *dbus.Signal, 5)
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{
+						"PrimarySimSlot": dbus.MakeVariant(uint32(1)),
+						"Sim":            dbus.MakeVariant(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/0")),
+					},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"Sim": dbus.MakeVariant(dbus.ObjectPath("/"))},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"SimSlots": dbus.MakeVariant([]dbus.ObjectPath{"/", "/"})},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{"State": dbus.MakeVariant(int32(StateEnabled))},
+					[]string{},
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					iface,
+					map[string]dbus.Variant{
+						"PrimarySimSlot": dbus.MakeVariant(uint32(2)),
+						"Sim":            dbus.MakeVariant(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/1")),
+					},
+					[]string{},
+				}}
+				close(sigs)
+
+				return sigs, nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SIM/1"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				return map[string]dbus.Variant{
+					"SimIdentifier": dbus.MakeVariant("89014103211118510720"),
+				}, nil
+			},
+		},
+		PrimarySIMSlot: 1,
+		sim:            "/org/freedesktop/ModemManager1/SIM/0",
+	}
+
+	changes, err := m.WatchSIM(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch SIM: %v", err)
+	}
+
+	var got []SIMChange
+	for c := range changes {
+		got = append(got, c)
+	}
+
+	want := []SIMChange{
+		{PrimarySIMSlot: 1},
+		{
+			PrimarySIMSlot: 2,
+			SIM: &SIM{
+				Index:      1,
+				Identifier: "89014103211118510720",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(SIM{})); diff != "" {
+		t.Fatalf("unexpected SIM changes (-want +got):\n%s", diff)
+	}
+}
+
+func TestSIMSendPinRetries(t *testing.T) {
+	s := &SIM{
+		c: &Client{
+			call: func(_ context.Context, _ string, _ dbus.ObjectPath, _ interface{}, _ ...interface{}) error {
+				return dbus.Error{Name: incorrectPasswordError}
+			},
+			get: func(_ context.Context, op dbus.ObjectPath, dInterface, prop string) (dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("UnlockRetries", prop); diff != "" {
+					t.Fatalf("unexpected property (-want +got):\n%s", diff)
+				}
+
+				return dbus.MakeVariant(map[uint32]uint32{
+					uint32(LockSIMPIN): 2,
+					uint32(LockSIMPUK): 10,
+				}), nil
+			},
+		},
+		modem: "/org/freedesktop/ModemManager1/Modem/0",
+	}
+
+	err := s.SendPin(context.Background(), "0000")
+
+	var perr *PINError
+	if !errors.As(err, &perr) {
+		t.Fatalf("expected *PINError, but got: %v", err)
+	}
+
+	if !errors.Is(err, ErrIncorrectPassword) {
+		t.Fatalf("expected incorrect password error, but got: %v", err)
+	}
+
+	want := map[Lock]int{
+		LockSIMPIN: 2,
+		LockSIMPUK: 10,
+	}
+
+	if diff := cmp.Diff(want, perr.Retries); diff != "" {
+		t.Fatalf("unexpected retries (-want +got):\n%s", diff)
+	}
+
+	t.Logf("err: %v", err)
+}
@@ -0,0 +1,139 @@
+package modemmanager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// A Status is a summary of a Modem's state and network registration, fetched
+// in a single operation.
+type Status struct {
+	State              State
+	AccessTechnologies AccessTechnology
+	CurrentBands       []Band
+
+	// SignalQuality is the signal quality percentage. SignalRecent reports
+	// whether it was recently taken.
+	SignalQuality int
+	SignalRecent  bool
+
+	// 3GPP network registration, if the Modem supports 3GPP networks.
+	RegistrationState RegistrationState3GPP
+	OperatorCode      string
+	OperatorName      string
+}
+
+// A Band is a radio frequency band used by a Modem. Band values correspond to
+// those of MMModemBand:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModemBand.
+type Band uint32
+
+// Notable Band values. Specific bands are not enumerated.
+const (
+	BandUnknown Band = 0
+	BandAny     Band = 256
+)
+
+// GetStatus fetches a summary of the Modem's state, signal quality, and
+// network registration. It is cheaper than fetching the same information
+// from each of the Modem's interfaces.
+func (m *Modem) GetStatus(ctx context.Context) (*Status, error) {
+	var out map[string]dbus.Variant
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Simple", "GetStatus"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&out,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	s, err := parseStatus(out)
+	if err != nil {
+		return nil, err
+	}
+
+	s.OperatorName = m.c.operatorName(s.OperatorCode, s.OperatorName)
+	return s, nil
+}
+
+// Connect connects the Modem to the network using the input BearerProperties,
+// performing any steps needed to do so such as enabling the Modem and
+// registering with a network. It returns the connected Bearer.
+func (m *Modem) Connect(ctx context.Context, p BearerProperties) (*Bearer, error) {
+	if err := validateAPN(p.APN); err != nil {
+		return nil, err
+	}
+
+	var op dbus.ObjectPath
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Simple", "Connect"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&op,
+		p.properties(),
+	)
+	if err != nil {
+		return nil, toTimeout(toPermission(err))
+	}
+
+	return m.c.bearer(ctx, op)
+}
+
+// Disconnect disconnects the input Bearer, or all of the Modem's Bearers if b
+// is nil.
+func (m *Modem) Disconnect(ctx context.Context, b *Bearer) error {
+	op := dbus.ObjectPath("/")
+	if b != nil {
+		op = objectPath("Bearer", strconv.Itoa(b.Index))
+	}
+
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Simple", "Disconnect"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		op,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// parseStatus parses a Status from a properties map.
+func parseStatus(ps map[string]dbus.Variant) (*Status, error) {
+	var s Status
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "access-technologies":
+			s.AccessTechnologies = AccessTechnology(vp.Int())
+		case "current-bands":
+			for _, b := range vp.Uint32s() {
+				s.CurrentBands = append(s.CurrentBands, Band(b))
+			}
+		case "m3gpp-operator-code":
+			s.OperatorCode = vp.String()
+		case "m3gpp-operator-name":
+			s.OperatorName = vp.String()
+		case "m3gpp-registration-state":
+			s.RegistrationState = RegistrationState3GPP(vp.Int())
+		case "signal-quality":
+			s.SignalQuality, s.SignalRecent = vp.SignalQuality()
+		case "state":
+			s.State = State(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &s, nil
+}
@@ -0,0 +1,101 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestModemGetStatus(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Simple.GetStatus", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{map[string]dbus.Variant{
+				"access-technologies":      dbus.MakeVariant(uint32(AccessTechnologyLTE)),
+				"current-bands":            dbus.MakeVariant([]uint32{32, 43}),
+				"m3gpp-operator-code":      dbus.MakeVariant("310410"),
+				"m3gpp-operator-name":      dbus.MakeVariant("AT&T"),
+				"m3gpp-registration-state": dbus.MakeVariant(uint32(RegistrationState3GPPHome)),
+				"signal-quality":           dbus.MakeVariant([]interface{}{uint32(67), true}),
+				"state":                    dbus.MakeVariant(int32(StateConnected)),
+			}}, out)
+		}},
+	}
+
+	s, err := m.GetStatus(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get status: %v", err)
+	}
+
+	want := &Status{
+		State:              StateConnected,
+		AccessTechnologies: AccessTechnologyLTE,
+		CurrentBands:       []Band{32, 43},
+		SignalQuality:      67,
+		SignalRecent:       true,
+		RegistrationState:  RegistrationState3GPPHome,
+		OperatorCode:       "310410",
+		OperatorName:       "AT&T",
+	}
+
+	if diff := cmp.Diff(want, s); diff != "" {
+		t.Fatalf("unexpected Status (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemGetStatusErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		ps         map[string]dbus.Variant
+		err        error
+		permission bool
+	}{
+		{
+			name:       "permission denied",
+			err:        dbus.Error{Name: unauthorizedError},
+			permission: true,
+		},
+		{
+			name: "bad state",
+			ps: map[string]dbus.Variant{
+				"state": dbus.MakeVariant("foo"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, _ string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+					if tt.err != nil {
+						return tt.err
+					}
+
+					return dbus.Store([]interface{}{tt.ps}, out)
+				}},
+			}
+
+			_, err := m.GetStatus(context.Background())
+			if err == nil {
+				t.Fatal("expected an error, but none occurred")
+			}
+
+			if diff := cmp.Diff(tt.permission, errors.Is(err, os.ErrPermission)); diff != "" {
+				t.Fatalf("unexpected permission error (-want +got):\n%s", diff)
+			}
+
+			t.Logf("err: %v", err)
+		})
+	}
+}
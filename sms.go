@@ -0,0 +1,435 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// An SMS is a short message stored on or sent by a Modem.
+//
+// Class is the SMS message class from 0 to 3, and is only valid when HasClass
+// is true. Class 0 (flash) messages are intended to be displayed immediately
+// and typically must not be persisted; see IsFlash.
+type SMS struct {
+	Index                 int                  `json:"index"`
+	Class                 int                  `json:"class"`
+	HasClass              bool                 `json:"has_class"`
+	Data                  []byte               `json:"data,omitempty"`
+	DeliveryReportRequest bool                 `json:"delivery_report_request"`
+	DeliveryState         SMSDeliveryState     `json:"delivery_state"`
+	DischargeTimestamp    time.Time            `json:"discharge_timestamp"`
+	MessageReference      int                  `json:"message_reference"`
+	Number                string               `json:"number"`
+	PDUType               SMSPDUType           `json:"pdu_type"`
+	SMSC                  string               `json:"smsc"`
+	State                 SMSState             `json:"state"`
+	Storage               SMSStorage           `json:"storage"`
+	TeleserviceID         SMSCDMATeleserviceID `json:"teleservice_id"`
+	Text                  string               `json:"text"`
+	Timestamp             time.Time            `json:"timestamp"`
+	Validity              time.Duration        `json:"validity"`
+	ValidityType          SMSValidityType      `json:"validity_type"`
+
+	c *Client
+}
+
+// Messaging contains the SMS storage configuration for a Modem.
+type Messaging struct {
+	DefaultStorage    SMSStorage
+	SupportedStorages []SMSStorage
+}
+
+// An SMSState is the state of an SMS.
+type SMSState int
+
+// Possible SMSState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsState.
+const (
+	SMSStateUnknown SMSState = iota
+	SMSStateStored
+	SMSStateReceiving
+	SMSStateReceived
+	SMSStateSending
+	SMSStateSent
+)
+
+// An SMSPDUType is the PDU type of an SMS.
+type SMSPDUType int
+
+// Possible SMSPDUType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsPduType.
+const (
+	SMSPDUTypeUnknown                     SMSPDUType = 0
+	SMSPDUTypeDeliver                     SMSPDUType = 1
+	SMSPDUTypeSubmit                      SMSPDUType = 2
+	SMSPDUTypeStatusReport                SMSPDUType = 3
+	SMSPDUTypeCDMADeliver                 SMSPDUType = 32
+	SMSPDUTypeCDMASubmit                  SMSPDUType = 33
+	SMSPDUTypeCDMACancellation            SMSPDUType = 34
+	SMSPDUTypeCDMADeliveryAcknowledgement SMSPDUType = 35
+	SMSPDUTypeCDMAUserAcknowledgement     SMSPDUType = 36
+	SMSPDUTypeCDMAReadAcknowledgement     SMSPDUType = 37
+)
+
+// An SMSStorage is a storage location for SMS messages.
+type SMSStorage int
+
+// Possible SMSStorage values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsStorage.
+const (
+	SMSStorageUnknown SMSStorage = iota
+	SMSStorageSM
+	SMSStorageME
+	SMSStorageMT
+	SMSStorageSR
+	SMSStorageBM
+	SMSStorageTA
+)
+
+// Messaging fetches the SMS storage configuration for the Modem.
+func (m *Modem) Messaging(ctx context.Context) (*Messaging, error) {
+	ps, err := m.c.getAll(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Messaging"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var ms Messaging
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "DefaultStorage":
+			ms.DefaultStorage = SMSStorage(vp.Int())
+		case "SupportedStorages":
+			for _, s := range vp.Uint32s() {
+				ms.SupportedStorages = append(ms.SupportedStorages, SMSStorage(s))
+			}
+		}
+
+		if err := vp.Err(); err != nil {
+			return nil, fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return &ms, nil
+}
+
+// SetDefaultStorage sets the storage used by the Modem for received and newly
+// created SMS messages, such as SMSStorageSM for the SIM or SMSStorageME for
+// the modem itself.
+func (m *Modem) SetDefaultStorage(ctx context.Context, s SMSStorage) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Messaging", "SetDefaultStorage"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		uint32(s),
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// Messages lists the SMS messages which are received by, or created on, the
+// Modem.
+func (m *Modem) Messages(ctx context.Context) ([]*SMS, error) {
+	var ops []dbus.ObjectPath
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Messaging", "List"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&ops,
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	ss := make([]*SMS, 0, len(ops))
+	for _, op := range ops {
+		s, err := m.c.sms(ctx, op)
+		if err != nil {
+			return nil, err
+		}
+
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
+
+// An SMSValidityType is the format of an SMS's validity period.
+type SMSValidityType int
+
+// Possible SMSValidityType values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsValidityType.
+const (
+	SMSValidityTypeUnknown SMSValidityType = iota
+	SMSValidityTypeRelative
+	SMSValidityTypeAbsolute
+	SMSValidityTypeEnhanced
+)
+
+// An SMSCDMATeleserviceID is the teleservice of a CDMA SMS.
+type SMSCDMATeleserviceID int
+
+// Possible SMSCDMATeleserviceID values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMSmsCdmaTeleserviceId.
+const (
+	SMSCDMATeleserviceIDUnknown SMSCDMATeleserviceID = 0x0000
+	SMSCDMATeleserviceIDCMT91   SMSCDMATeleserviceID = 0x1000
+	SMSCDMATeleserviceIDWPT     SMSCDMATeleserviceID = 0x1001
+	SMSCDMATeleserviceIDWMT     SMSCDMATeleserviceID = 0x1002
+	SMSCDMATeleserviceIDVMN     SMSCDMATeleserviceID = 0x1003
+	SMSCDMATeleserviceIDWAP     SMSCDMATeleserviceID = 0x1004
+	SMSCDMATeleserviceIDWEMT    SMSCDMATeleserviceID = 0x1005
+	SMSCDMATeleserviceIDSCPT    SMSCDMATeleserviceID = 0x1006
+	SMSCDMATeleserviceIDCATPT   SMSCDMATeleserviceID = 0x1007
+)
+
+// SMSProperties are the properties used to create an SMS. Zero values are
+// unset and left to the modem's defaults.
+//
+// Either Text or Data must be set, but not both. Data is sent as an 8-bit
+// binary message, such as for machine-to-machine protocols carried over SMS.
+// If Flash is set, the SMS is sent as a class 0 (flash) message.
+type SMSProperties struct {
+	Data                  []byte
+	DeliveryReportRequest bool
+	Flash                 bool
+	Number                string
+	Text                  string
+}
+
+// A MessageAdded is an SMS which was added to a Modem. Received is true if the
+// SMS was received from the network, or false if it was created locally.
+type MessageAdded struct {
+	Received bool
+	SMS      *SMS
+}
+
+// WatchMessages watches for SMS messages which are received by, or created
+// on, the Modem. Each message is delivered on the returned channel, which is
+// closed when the context is canceled.
+func (m *Modem) WatchMessages(ctx context.Context) (<-chan MessageAdded, error) {
+	sigs, err := m.c.watch(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Messaging"),
+		"Added",
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, sigs, func(s *dbus.Signal) (MessageAdded, error) {
+		var (
+			op       dbus.ObjectPath
+			received bool
+		)
+
+		if err := dbus.Store(s.Body, &op, &received); err != nil {
+			return MessageAdded{}, fmt.Errorf("error parsing added message: %v", err)
+		}
+
+		// The message may be deleted before it can be fetched, in which case
+		// it is skipped.
+		sms, err := m.c.sms(ctx, op)
+		if err != nil {
+			return MessageAdded{}, err
+		}
+
+		return MessageAdded{
+			Received: received,
+			SMS:      sms,
+		}, nil
+	}), nil
+}
+
+// CreateMessage creates an SMS on the Modem which can be sent using SMS.Send.
+func (m *Modem) CreateMessage(ctx context.Context, p SMSProperties) (*SMS, error) {
+	if (p.Text == "") == (len(p.Data) == 0) {
+		return nil, errors.New("exactly one of SMS text or data must be set")
+	}
+	if p.Number != "" {
+		if err := validateNumber(p.Number); err != nil {
+			return nil, err
+		}
+	}
+
+	var op dbus.ObjectPath
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Messaging", "Create"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		&op,
+		p.properties(),
+	)
+	if err != nil {
+		return nil, toPermission(err)
+	}
+
+	return m.c.sms(ctx, op)
+}
+
+// DeleteMessage deletes an SMS from the Modem. If the SMS no longer exists, an
+// error compatible with 'errors.Is(err, os.ErrNotExist)' is returned.
+func (m *Modem) DeleteMessage(ctx context.Context, s *SMS) error {
+	err := m.c.call(
+		ctx,
+		interfacePath("Modem", "Messaging", "Delete"),
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		nil,
+		objectPath("SMS", strconv.Itoa(s.Index)),
+	)
+	if err != nil {
+		return toNotExist(toPermission(err), notFoundError)
+	}
+
+	return nil
+}
+
+// Send sends the SMS. Once sent, the SMS's fields are updated with its
+// current properties, such as its State and MessageReference.
+//
+// Sending may take a long time. If the operation times out, an error
+// compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (s *SMS) Send(ctx context.Context) error {
+	op := objectPath("SMS", strconv.Itoa(s.Index))
+	if err := s.c.call(ctx, interfacePath("Sms", "Send"), op, nil); err != nil {
+		return toTimeout(toPermission(err))
+	}
+
+	ps, err := s.c.getAll(ctx, op, interfacePath("Sms"))
+	if err != nil {
+		return err
+	}
+
+	return s.parse(ps)
+}
+
+// sms fetches an SMS by its object path.
+func (c *Client) sms(ctx context.Context, op dbus.ObjectPath) (*SMS, error) {
+	ps, err := c.getAll(ctx, op, interfacePath("Sms"))
+	if err != nil {
+		return nil, err
+	}
+
+	// Note the SMS's index in the struct by fetching that index from the last
+	// element of the D-Bus object path.
+	idx, err := strconv.Atoi(path.Base(string(op)))
+	if err != nil {
+		return nil, err
+	}
+
+	s := &SMS{
+		Index: idx,
+		c:     c,
+	}
+
+	if err := s.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// IsFlash reports whether the SMS is a class 0 (flash) message.
+func (s *SMS) IsFlash() bool { return s.HasClass && s.Class == 0 }
+
+// IsBinary reports whether the SMS carries 8-bit binary Data rather than Text.
+func (s *SMS) IsBinary() bool { return len(s.Data) > 0 && s.Text == "" }
+
+// Property fetches a raw D-Bus property by name from the input D-Bus interface
+// on the SMS's object, such as "org.freedesktop.ModemManager1.Sms". It can be
+// used to access properties which are not yet exposed by this package.
+func (s *SMS) Property(ctx context.Context, iface, name string) (dbus.Variant, error) {
+	return s.c.get(ctx, objectPath("SMS", strconv.Itoa(s.Index)), iface, name)
+}
+
+// AllProperties fetches all raw D-Bus properties from the input D-Bus interface
+// on the SMS's object. It can be used to access properties which are not yet
+// exposed by this package.
+func (s *SMS) AllProperties(ctx context.Context, iface string) (map[string]dbus.Variant, error) {
+	return s.c.getAll(ctx, objectPath("SMS", strconv.Itoa(s.Index)), iface)
+}
+
+// parse parses a properties map into the SMS's fields.
+func (s *SMS) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "Class":
+			// ModemManager reports -1 for an SMS with no class.
+			s.Class = vp.Int()
+			s.HasClass = s.Class >= 0
+		case "Data":
+			s.Data = vp.Bytes()
+		case "DeliveryReportRequest":
+			s.DeliveryReportRequest = vp.Bool()
+		case "DeliveryState":
+			s.DeliveryState = SMSDeliveryState(vp.Int())
+		case "DischargeTimestamp":
+			s.DischargeTimestamp = vp.Time()
+		case "MessageReference":
+			s.MessageReference = vp.Int()
+		case "Number":
+			s.Number = vp.String()
+		case "PduType":
+			s.PDUType = SMSPDUType(vp.Int())
+		case "SMSC":
+			s.SMSC = vp.String()
+		case "State":
+			s.State = SMSState(vp.Int())
+		case "Storage":
+			s.Storage = SMSStorage(vp.Int())
+		case "TeleserviceId":
+			s.TeleserviceID = SMSCDMATeleserviceID(vp.Int())
+		case "Text":
+			s.Text = vp.String()
+		case "Timestamp":
+			s.Timestamp = vp.Time()
+		case "Validity":
+			s.ValidityType, s.Validity = vp.Validity()
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
+
+// properties packs SMSProperties into a properties map, omitting any unset
+// values.
+func (p SMSProperties) properties() map[string]dbus.Variant {
+	ps := make(map[string]dbus.Variant)
+	if len(p.Data) > 0 {
+		ps["data"] = dbus.MakeVariant(p.Data)
+	}
+	if p.DeliveryReportRequest {
+		ps["delivery-report-request"] = dbus.MakeVariant(true)
+	}
+	if p.Flash {
+		ps["class"] = dbus.MakeVariant(int32(0))
+	}
+	if p.Number != "" {
+		ps["number"] = dbus.MakeVariant(p.Number)
+	}
+	if p.Text != "" {
+		ps["text"] = dbus.MakeVariant(p.Text)
+	}
+
+	return ps
+}
@@ -0,0 +1,458 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemMessages(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging.List", method); diff != "" {
+					t.Fatalf("unexpected method (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				return dbus.Store([]interface{}{[]dbus.ObjectPath{
+					"/org/freedesktop/ModemManager1/SMS/0",
+					"/org/freedesktop/ModemManager1/SMS/1",
+				}}, out)
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Sms", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				switch op {
+				case "/org/freedesktop/ModemManager1/SMS/0":
+					// Test data copied from mmcli output with some tweaks.
+					return map[string]dbus.Variant{
+						"Class":                 dbus.MakeVariant(int32(-1)),
+						"Data":                  dbus.MakeVariant([]byte{}),
+						"DeliveryReportRequest": dbus.MakeVariant(false),
+						"DischargeTimestamp":    dbus.MakeVariant(""),
+						"MessageReference":      dbus.MakeVariant(uint32(0)),
+						"Number":                dbus.MakeVariant("+15555551234"),
+						"PduType":               dbus.MakeVariant(uint32(SMSPDUTypeDeliver)),
+						"SMSC":                  dbus.MakeVariant("+15555550000"),
+						"State":                 dbus.MakeVariant(uint32(SMSStateReceived)),
+						"Storage":               dbus.MakeVariant(uint32(SMSStorageME)),
+						"TeleserviceId":         dbus.MakeVariant(uint32(SMSCDMATeleserviceIDUnknown)),
+						"Text":                  dbus.MakeVariant("hello"),
+						"Timestamp":             dbus.MakeVariant("2021-03-04T12:34:56-05"),
+						"Validity": dbus.MakeVariant([]interface{}{
+							uint32(SMSValidityTypeRelative),
+							dbus.MakeVariant(uint32(1440)),
+						}),
+					}, nil
+				case "/org/freedesktop/ModemManager1/SMS/1":
+					return map[string]dbus.Variant{
+						"Number": dbus.MakeVariant("+15555555678"),
+						"Text":   dbus.MakeVariant("world"),
+					}, nil
+				default:
+					t.Fatalf("unexpected object path: %q", op)
+					return nil, nil
+				}
+			},
+		},
+	}
+
+	ss, err := m.Messages(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list messages: %v", err)
+	}
+
+	want := []*SMS{
+		{
+			Index:        0,
+			Class:        -1,
+			Data:         []byte{},
+			Number:       "+15555551234",
+			PDUType:      SMSPDUTypeDeliver,
+			SMSC:         "+15555550000",
+			State:        SMSStateReceived,
+			Storage:      SMSStorageME,
+			Text:         "hello",
+			Timestamp:    time.Date(2021, time.March, 4, 12, 34, 56, 0, time.FixedZone("", -5*60*60)),
+			Validity:     24 * time.Hour,
+			ValidityType: SMSValidityTypeRelative,
+		},
+		{
+			Index:  1,
+			Number: "+15555555678",
+			Text:   "world",
+		},
+	}
+
+	if diff := cmp.Diff(want, ss, cmpopts.IgnoreUnexported(SMS{})); diff != "" {
+		t.Fatalf("unexpected SMS messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestSMSAllProperties(t *testing.T) {
+	s := &SMS{
+		Index: 1,
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/1"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Sms", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"Text": dbus.MakeVariant("hello"),
+			}, nil
+		}},
+	}
+
+	ps, err := s.AllProperties(context.Background(), "org.freedesktop.ModemManager1.Sms")
+	if err != nil {
+		t.Fatalf("failed to get all properties: %v", err)
+	}
+
+	if diff := cmp.Diff("hello", ps["Text"].Value()); diff != "" {
+		t.Fatalf("unexpected Text property (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemWatchMessages(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			watch: func(_ context.Context, op dbus.ObjectPath, dInterface, member string) (<-chan *dbus.Signal, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging", dInterface); diff != "" {
+					t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("Added", member); diff != "" {
+					t.Fatalf("unexpected member (-want +got):\n%s", diff)
+				}
+
+				// Deliver a malformed signal and a message which no longer
+				// exists, both of which should be skipped, followed by a
+				// received and a locally created message.
+				sigs := make(chan *dbus.Signal, 4)
+				sigs <- &dbus.Signal{Body: []interface{}{"foo"}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/9"),
+					true,
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/0"),
+					true,
+				}}
+				sigs <- &dbus.Signal{Body: []interface{}{
+					dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/1"),
+					false,
+				}}
+				close(sigs)
+
+				return sigs, nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				switch op {
+				case "/org/freedesktop/ModemManager1/SMS/0":
+					return map[string]dbus.Variant{
+						"State": dbus.MakeVariant(uint32(SMSStateReceived)),
+						"Text":  dbus.MakeVariant("hello"),
+					}, nil
+				case "/org/freedesktop/ModemManager1/SMS/1":
+					return map[string]dbus.Variant{
+						"State": dbus.MakeVariant(uint32(SMSStateStored)),
+						"Text":  dbus.MakeVariant("world"),
+					}, nil
+				default:
+					return nil, dbus.Error{Name: unknownMethodError}
+				}
+			},
+		},
+	}
+
+	msgs, err := m.WatchMessages(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch messages: %v", err)
+	}
+
+	var got []MessageAdded
+	for m := range msgs {
+		got = append(got, m)
+	}
+
+	want := []MessageAdded{
+		{
+			Received: true,
+			SMS: &SMS{
+				Index: 0,
+				State: SMSStateReceived,
+				Text:  "hello",
+			},
+		},
+		{
+			SMS: &SMS{
+				Index: 1,
+				State: SMSStateStored,
+				Text:  "world",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreUnexported(SMS{})); diff != "" {
+		t.Fatalf("unexpected messages (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemCreateMessageSend(t *testing.T) {
+	var sent bool
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+				switch method {
+				case "org.freedesktop.ModemManager1.Modem.Messaging.Create":
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					want := []interface{}{map[string]dbus.Variant{
+						"class":                   dbus.MakeVariant(int32(0)),
+						"delivery-report-request": dbus.MakeVariant(true),
+						"number":                  dbus.MakeVariant("+15555551234"),
+						"text":                    dbus.MakeVariant("hello"),
+					}}
+
+					if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+						t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+					}
+
+					return dbus.Store([]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/2")}, out)
+				case "org.freedesktop.ModemManager1.Sms.Send":
+					if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/2"), op); diff != "" {
+						t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+					}
+
+					sent = true
+					return nil
+				default:
+					t.Fatalf("unexpected method: %q", method)
+					return nil
+				}
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/2"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if !sent {
+					return map[string]dbus.Variant{
+						"State": dbus.MakeVariant(uint32(SMSStateStored)),
+					}, nil
+				}
+
+				return map[string]dbus.Variant{
+					"MessageReference": dbus.MakeVariant(uint32(7)),
+					"State":            dbus.MakeVariant(uint32(SMSStateSent)),
+				}, nil
+			},
+		},
+	}
+
+	s, err := m.CreateMessage(context.Background(), SMSProperties{
+		DeliveryReportRequest: true,
+		Flash:                 true,
+		Number:                "+15555551234",
+		Text:                  "hello",
+	})
+	if err != nil {
+		t.Fatalf("failed to create message: %v", err)
+	}
+
+	if diff := cmp.Diff(SMSStateStored, s.State); diff != "" {
+		t.Fatalf("unexpected created state (-want +got):\n%s", diff)
+	}
+
+	if err := s.Send(context.Background()); err != nil {
+		t.Fatalf("failed to send message: %v", err)
+	}
+
+	want := &SMS{
+		Index:            2,
+		MessageReference: 7,
+		State:            SMSStateSent,
+	}
+
+	if diff := cmp.Diff(want, s, cmpopts.IgnoreUnexported(SMS{})); diff != "" {
+		t.Fatalf("unexpected SMS (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemMessaging(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"DefaultStorage": dbus.MakeVariant(uint32(SMSStorageME)),
+				"Messages":       dbus.MakeVariant([]dbus.ObjectPath{}),
+				"SupportedStorages": dbus.MakeVariant([]uint32{
+					uint32(SMSStorageSM),
+					uint32(SMSStorageME),
+				}),
+			}, nil
+		}},
+	}
+
+	ms, err := m.Messaging(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get messaging: %v", err)
+	}
+
+	want := &Messaging{
+		DefaultStorage:    SMSStorageME,
+		SupportedStorages: []SMSStorage{SMSStorageSM, SMSStorageME},
+	}
+
+	if diff := cmp.Diff(want, ms); diff != "" {
+		t.Fatalf("unexpected Messaging (-want +got):\n%s", diff)
+	}
+}
+
+func TestModemSetDefaultStorage(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging.SetDefaultStorage", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{uint32(SMSStorageSM)}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return nil
+		}},
+	}
+
+	if err := m.SetDefaultStorage(context.Background(), SMSStorageSM); err != nil {
+		t.Fatalf("failed to set default storage: %v", err)
+	}
+}
+
+func TestModemCreateMessageData(t *testing.T) {
+	m := &Modem{
+		c: &Client{
+			call: func(_ context.Context, _ string, _ dbus.ObjectPath, out interface{}, args ...interface{}) error {
+				want := []interface{}{map[string]dbus.Variant{
+					"data":   dbus.MakeVariant([]byte{0xde, 0xad, 0xbe, 0xef}),
+					"number": dbus.MakeVariant("+15555551234"),
+				}}
+
+				if diff := cmp.Diff(want, args, cmp.Comparer(variantEqual)); diff != "" {
+					t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+				}
+
+				return dbus.Store([]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/0")}, out)
+			},
+			getAll: func(_ context.Context, _ dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				return map[string]dbus.Variant{
+					"Data": dbus.MakeVariant([]byte{0xde, 0xad, 0xbe, 0xef}),
+					"Text": dbus.MakeVariant(""),
+				}, nil
+			},
+		},
+	}
+
+	// Text and data are mutually exclusive.
+	for _, p := range []SMSProperties{
+		{Number: "+15555551234"},
+		{Number: "+15555551234", Text: "hello", Data: []byte{0xff}},
+	} {
+		if _, err := m.CreateMessage(context.Background(), p); err == nil {
+			t.Fatalf("expected an error for %+v, but none occurred", p)
+		}
+	}
+
+	s, err := m.CreateMessage(context.Background(), SMSProperties{
+		Data:   []byte{0xde, 0xad, 0xbe, 0xef},
+		Number: "+15555551234",
+	})
+	if err != nil {
+		t.Fatalf("failed to create message: %v", err)
+	}
+
+	if !s.IsBinary() {
+		t.Fatal("expected binary SMS")
+	}
+}
+
+func TestSMSIsFlash(t *testing.T) {
+	tests := []struct {
+		name  string
+		class dbus.Variant
+		want  bool
+	}{
+		{name: "not reported"},
+		{name: "no class", class: dbus.MakeVariant(int32(-1))},
+		{name: "class 0", class: dbus.MakeVariant(int32(0)), want: true},
+		{name: "class 1", class: dbus.MakeVariant(int32(1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := map[string]dbus.Variant{"Text": dbus.MakeVariant("hello")}
+			if tt.class.Value() != nil {
+				ps["Class"] = tt.class
+			}
+
+			var s SMS
+			if err := s.parse(ps); err != nil {
+				t.Fatalf("failed to parse SMS: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, s.IsFlash()); diff != "" {
+				t.Fatalf("unexpected flash (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestModemDeleteMessageNotExist(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Messaging.Delete", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{dbus.ObjectPath("/org/freedesktop/ModemManager1/SMS/1")}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: notFoundError}
+		}},
+	}
+
+	err := m.DeleteMessage(context.Background(), &SMS{Index: 1})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected not exist error, but got: %v", err)
+	}
+}
@@ -1,9 +1,183 @@
-// Code generated by "stringer -type=BearerIPMethod,PortType,PowerState,State -output strings.go"; DO NOT EDIT.
+// Code generated by "stringer -type=AccessTechnology,AssistanceDataType,Attachment,BearerAllowedAuth,BearerIPFamily,BearerIPMethod,BearerType,CDMAActivationError,CDMAActivationState,CallDirection,CallState,CallStateReason,CellBroadcastState,CellType,ConnectStep,ConnectionStatus,DRXCycle,DeliveryStatus,ESIMStatus,ErrorDomain,FacilityLock,FirmwareImageType,LocationSource,Lock,MICOMode,NetworkError,OMAFeature,OMASessionState,OMASessionStateFailedReason,OMASessionType,PacketServiceState,PortType,PowerState,RecoveryAction,RegistrationState3GPP,SIMRemovability,SIMType,SMSCDMATeleserviceID,SMSDeliveryState,SMSPDUType,SMSState,SMSStorage,SMSValidityType,State,StateChangeReason,USSDState -output strings.go"; DO NOT EDIT.
 
 package modemmanager
 
 import "strconv"
 
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[AccessTechnologyUnknown-0]
+	_ = x[AccessTechnologyPOTS-1]
+	_ = x[AccessTechnologyGSM-2]
+	_ = x[AccessTechnologyGSMCompact-4]
+	_ = x[AccessTechnologyGPRS-8]
+	_ = x[AccessTechnologyEDGE-16]
+	_ = x[AccessTechnologyUMTS-32]
+	_ = x[AccessTechnologyHSDPA-64]
+	_ = x[AccessTechnologyHSUPA-128]
+	_ = x[AccessTechnologyHSPA-256]
+	_ = x[AccessTechnologyHSPAPlus-512]
+	_ = x[AccessTechnology1xRTT-1024]
+	_ = x[AccessTechnologyEVDO0-2048]
+	_ = x[AccessTechnologyEVDOA-4096]
+	_ = x[AccessTechnologyEVDOB-8192]
+	_ = x[AccessTechnologyLTE-16384]
+	_ = x[AccessTechnologyNR5G-32768]
+	_ = x[AccessTechnologyLTECatM-65536]
+	_ = x[AccessTechnologyLTENBIoT-131072]
+	_ = x[AccessTechnologyAny-4294967295]
+}
+
+const _AccessTechnology_name = "AccessTechnologyUnknownAccessTechnologyPOTSAccessTechnologyGSMAccessTechnologyGSMCompactAccessTechnologyGPRSAccessTechnologyEDGEAccessTechnologyUMTSAccessTechnologyHSDPAAccessTechnologyHSUPAAccessTechnologyHSPAAccessTechnologyHSPAPlusAccessTechnology1xRTTAccessTechnologyEVDO0AccessTechnologyEVDOAAccessTechnologyEVDOBAccessTechnologyLTEAccessTechnologyNR5GAccessTechnologyLTECatMAccessTechnologyLTENBIoTAccessTechnologyAny"
+
+var _AccessTechnology_map = map[AccessTechnology]string{
+	0:          _AccessTechnology_name[0:23],
+	1:          _AccessTechnology_name[23:43],
+	2:          _AccessTechnology_name[43:62],
+	4:          _AccessTechnology_name[62:88],
+	8:          _AccessTechnology_name[88:108],
+	16:         _AccessTechnology_name[108:128],
+	32:         _AccessTechnology_name[128:148],
+	64:         _AccessTechnology_name[148:169],
+	128:        _AccessTechnology_name[169:190],
+	256:        _AccessTechnology_name[190:210],
+	512:        _AccessTechnology_name[210:234],
+	1024:       _AccessTechnology_name[234:255],
+	2048:       _AccessTechnology_name[255:276],
+	4096:       _AccessTechnology_name[276:297],
+	8192:       _AccessTechnology_name[297:318],
+	16384:      _AccessTechnology_name[318:337],
+	32768:      _AccessTechnology_name[337:357],
+	65536:      _AccessTechnology_name[357:380],
+	131072:     _AccessTechnology_name[380:404],
+	4294967295: _AccessTechnology_name[404:423],
+}
+
+func (i AccessTechnology) String() string {
+	if str, ok := _AccessTechnology_map[i]; ok {
+		return str
+	}
+	return "AccessTechnology(" + strconv.FormatInt(int64(i), 10) + ")"
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[AssistanceDataTypeNone-0]
+	_ = x[AssistanceDataTypeXTRA-1]
+}
+
+const _AssistanceDataType_name = "AssistanceDataTypeNoneAssistanceDataTypeXTRA"
+
+var _AssistanceDataType_index = [...]uint8{0, 22, 44}
+
+func (i AssistanceDataType) String() string {
+	if i >= AssistanceDataType(len(_AssistanceDataType_index)-1) {
+		return "AssistanceDataType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _AssistanceDataType_name[_AssistanceDataType_index[i]:_AssistanceDataType_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[AttachmentNone-0]
+	_ = x[AttachmentLegacy-1]
+	_ = x[AttachmentLTE-2]
+	_ = x[AttachmentNR5GNSA-3]
+	_ = x[AttachmentNR5GSA-4]
+}
+
+const _Attachment_name = "AttachmentNoneAttachmentLegacyAttachmentLTEAttachmentNR5GNSAAttachmentNR5GSA"
+
+var _Attachment_index = [...]uint8{0, 14, 30, 43, 60, 76}
+
+func (i Attachment) String() string {
+	if i < 0 || i >= Attachment(len(_Attachment_index)-1) {
+		return "Attachment(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Attachment_name[_Attachment_index[i]:_Attachment_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[BearerAllowedAuthUnknown-0]
+	_ = x[BearerAllowedAuthNone-1]
+	_ = x[BearerAllowedAuthPAP-2]
+	_ = x[BearerAllowedAuthCHAP-4]
+	_ = x[BearerAllowedAuthMSCHAP-8]
+	_ = x[BearerAllowedAuthMSCHAPv2-16]
+	_ = x[BearerAllowedAuthEAP-32]
+}
+
+const (
+	_BearerAllowedAuth_name_0 = "BearerAllowedAuthUnknownBearerAllowedAuthNoneBearerAllowedAuthPAP"
+	_BearerAllowedAuth_name_1 = "BearerAllowedAuthCHAP"
+	_BearerAllowedAuth_name_2 = "BearerAllowedAuthMSCHAP"
+	_BearerAllowedAuth_name_3 = "BearerAllowedAuthMSCHAPv2"
+	_BearerAllowedAuth_name_4 = "BearerAllowedAuthEAP"
+)
+
+var (
+	_BearerAllowedAuth_index_0 = [...]uint8{0, 24, 45, 65}
+)
+
+func (i BearerAllowedAuth) String() string {
+	switch {
+	case i <= 2:
+		return _BearerAllowedAuth_name_0[_BearerAllowedAuth_index_0[i]:_BearerAllowedAuth_index_0[i+1]]
+	case i == 4:
+		return _BearerAllowedAuth_name_1
+	case i == 8:
+		return _BearerAllowedAuth_name_2
+	case i == 16:
+		return _BearerAllowedAuth_name_3
+	case i == 32:
+		return _BearerAllowedAuth_name_4
+	default:
+		return "BearerAllowedAuth(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[BearerIPFamilyNone-0]
+	_ = x[BearerIPFamilyIPv4-1]
+	_ = x[BearerIPFamilyIPv6-2]
+	_ = x[BearerIPFamilyIPv4v6-4]
+	_ = x[BearerIPFamilyNonIP-8]
+	_ = x[BearerIPFamilyAny-4294967287]
+}
+
+const (
+	_BearerIPFamily_name_0 = "BearerIPFamilyNoneBearerIPFamilyIPv4BearerIPFamilyIPv6"
+	_BearerIPFamily_name_1 = "BearerIPFamilyIPv4v6"
+	_BearerIPFamily_name_2 = "BearerIPFamilyNonIP"
+	_BearerIPFamily_name_3 = "BearerIPFamilyAny"
+)
+
+var (
+	_BearerIPFamily_index_0 = [...]uint8{0, 18, 36, 54}
+)
+
+func (i BearerIPFamily) String() string {
+	switch {
+	case i <= 2:
+		return _BearerIPFamily_name_0[_BearerIPFamily_index_0[i]:_BearerIPFamily_index_0[i+1]]
+	case i == 4:
+		return _BearerIPFamily_name_1
+	case i == 8:
+		return _BearerIPFamily_name_2
+	case i == 4294967287:
+		return _BearerIPFamily_name_3
+	default:
+		return "BearerIPFamily(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
@@ -24,6 +198,745 @@ func (i BearerIPMethod) String() string {
 	}
 	return _BearerIPMethod_name[_BearerIPMethod_index[i]:_BearerIPMethod_index[i+1]]
 }
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[BearerTypeUnknown-0]
+	_ = x[BearerTypeDefault-1]
+	_ = x[BearerTypeDefaultAttach-2]
+	_ = x[BearerTypeDedicated-3]
+}
+
+const _BearerType_name = "BearerTypeUnknownBearerTypeDefaultBearerTypeDefaultAttachBearerTypeDedicated"
+
+var _BearerType_index = [...]uint8{0, 17, 34, 57, 76}
+
+func (i BearerType) String() string {
+	if i < 0 || i >= BearerType(len(_BearerType_index)-1) {
+		return "BearerType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _BearerType_name[_BearerType_index[i]:_BearerType_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CDMAActivationErrorNone-0]
+	_ = x[CDMAActivationErrorUnknown-1]
+	_ = x[CDMAActivationErrorRoaming-2]
+	_ = x[CDMAActivationErrorWrongRadioInterface-3]
+	_ = x[CDMAActivationErrorCouldNotConnect-4]
+	_ = x[CDMAActivationErrorSecurityAuthenticationFailed-5]
+	_ = x[CDMAActivationErrorProvisioningFailed-6]
+	_ = x[CDMAActivationErrorNoSignal-7]
+	_ = x[CDMAActivationErrorTimedOut-8]
+	_ = x[CDMAActivationErrorStartFailed-9]
+}
+
+const _CDMAActivationError_name = "CDMAActivationErrorNoneCDMAActivationErrorUnknownCDMAActivationErrorRoamingCDMAActivationErrorWrongRadioInterfaceCDMAActivationErrorCouldNotConnectCDMAActivationErrorSecurityAuthenticationFailedCDMAActivationErrorProvisioningFailedCDMAActivationErrorNoSignalCDMAActivationErrorTimedOutCDMAActivationErrorStartFailed"
+
+var _CDMAActivationError_index = [...]uint16{0, 23, 49, 75, 113, 147, 194, 231, 258, 285, 315}
+
+func (i CDMAActivationError) String() string {
+	if i < 0 || i >= CDMAActivationError(len(_CDMAActivationError_index)-1) {
+		return "CDMAActivationError(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CDMAActivationError_name[_CDMAActivationError_index[i]:_CDMAActivationError_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CDMAActivationStateUnknown-0]
+	_ = x[CDMAActivationStateNotActivated-1]
+	_ = x[CDMAActivationStateActivating-2]
+	_ = x[CDMAActivationStatePartiallyActivated-3]
+	_ = x[CDMAActivationStateActivated-4]
+}
+
+const _CDMAActivationState_name = "CDMAActivationStateUnknownCDMAActivationStateNotActivatedCDMAActivationStateActivatingCDMAActivationStatePartiallyActivatedCDMAActivationStateActivated"
+
+var _CDMAActivationState_index = [...]uint8{0, 26, 57, 86, 123, 151}
+
+func (i CDMAActivationState) String() string {
+	if i < 0 || i >= CDMAActivationState(len(_CDMAActivationState_index)-1) {
+		return "CDMAActivationState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CDMAActivationState_name[_CDMAActivationState_index[i]:_CDMAActivationState_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CallDirectionUnknown-0]
+	_ = x[CallDirectionIncoming-1]
+	_ = x[CallDirectionOutgoing-2]
+}
+
+const _CallDirection_name = "CallDirectionUnknownCallDirectionIncomingCallDirectionOutgoing"
+
+var _CallDirection_index = [...]uint8{0, 20, 41, 62}
+
+func (i CallDirection) String() string {
+	if i < 0 || i >= CallDirection(len(_CallDirection_index)-1) {
+		return "CallDirection(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CallDirection_name[_CallDirection_index[i]:_CallDirection_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CallStateUnknown-0]
+	_ = x[CallStateDialing-1]
+	_ = x[CallStateRingingOut-2]
+	_ = x[CallStateRingingIn-3]
+	_ = x[CallStateActive-4]
+	_ = x[CallStateHeld-5]
+	_ = x[CallStateWaiting-6]
+	_ = x[CallStateTerminated-7]
+}
+
+const _CallState_name = "CallStateUnknownCallStateDialingCallStateRingingOutCallStateRingingInCallStateActiveCallStateHeldCallStateWaitingCallStateTerminated"
+
+var _CallState_index = [...]uint8{0, 16, 32, 51, 69, 84, 97, 113, 132}
+
+func (i CallState) String() string {
+	if i < 0 || i >= CallState(len(_CallState_index)-1) {
+		return "CallState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CallState_name[_CallState_index[i]:_CallState_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CallStateReasonUnknown-0]
+	_ = x[CallStateReasonOutgoingStarted-1]
+	_ = x[CallStateReasonIncomingNew-2]
+	_ = x[CallStateReasonAccepted-3]
+	_ = x[CallStateReasonTerminated-4]
+	_ = x[CallStateReasonRefusedOrBusy-5]
+	_ = x[CallStateReasonError-6]
+	_ = x[CallStateReasonAudioSetupFailed-7]
+	_ = x[CallStateReasonTransferred-8]
+	_ = x[CallStateReasonDeflected-9]
+}
+
+const _CallStateReason_name = "CallStateReasonUnknownCallStateReasonOutgoingStartedCallStateReasonIncomingNewCallStateReasonAcceptedCallStateReasonTerminatedCallStateReasonRefusedOrBusyCallStateReasonErrorCallStateReasonAudioSetupFailedCallStateReasonTransferredCallStateReasonDeflected"
+
+var _CallStateReason_index = [...]uint8{0, 22, 52, 78, 101, 126, 154, 174, 205, 231, 255}
+
+func (i CallStateReason) String() string {
+	if i < 0 || i >= CallStateReason(len(_CallStateReason_index)-1) {
+		return "CallStateReason(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CallStateReason_name[_CallStateReason_index[i]:_CallStateReason_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CellBroadcastStateUnknown-0]
+	_ = x[CellBroadcastStateReceiving-1]
+	_ = x[CellBroadcastStateReceived-2]
+}
+
+const _CellBroadcastState_name = "CellBroadcastStateUnknownCellBroadcastStateReceivingCellBroadcastStateReceived"
+
+var _CellBroadcastState_index = [...]uint8{0, 25, 52, 78}
+
+func (i CellBroadcastState) String() string {
+	if i < 0 || i >= CellBroadcastState(len(_CellBroadcastState_index)-1) {
+		return "CellBroadcastState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CellBroadcastState_name[_CellBroadcastState_index[i]:_CellBroadcastState_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[CellTypeUnknown-0]
+	_ = x[CellTypeCDMA-1]
+	_ = x[CellTypeGSM-2]
+	_ = x[CellTypeUMTS-3]
+	_ = x[CellTypeTDSCDMA-4]
+	_ = x[CellTypeLTE-5]
+	_ = x[CellTypeNR5G-6]
+}
+
+const _CellType_name = "CellTypeUnknownCellTypeCDMACellTypeGSMCellTypeUMTSCellTypeTDSCDMACellTypeLTECellTypeNR5G"
+
+var _CellType_index = [...]uint8{0, 15, 27, 38, 50, 65, 76, 88}
+
+func (i CellType) String() string {
+	if i < 0 || i >= CellType(len(_CellType_index)-1) {
+		return "CellType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _CellType_name[_CellType_index[i]:_CellType_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ConnectStepUnknown-0]
+	_ = x[ConnectStepUnlock-1]
+	_ = x[ConnectStepEnable-2]
+	_ = x[ConnectStepRegister-3]
+	_ = x[ConnectStepConnect-4]
+	_ = x[ConnectStepWaitConnected-5]
+}
+
+const _ConnectStep_name = "ConnectStepUnknownConnectStepUnlockConnectStepEnableConnectStepRegisterConnectStepConnectConnectStepWaitConnected"
+
+var _ConnectStep_index = [...]uint8{0, 18, 35, 52, 71, 89, 113}
+
+func (i ConnectStep) String() string {
+	if i < 0 || i >= ConnectStep(len(_ConnectStep_index)-1) {
+		return "ConnectStep(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ConnectStep_name[_ConnectStep_index[i]:_ConnectStep_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ConnectionStatusUnknown-0]
+	_ = x[ConnectionStatusConnecting-1]
+	_ = x[ConnectionStatusConnected-2]
+	_ = x[ConnectionStatusDisconnected-3]
+	_ = x[ConnectionStatusFailed-4]
+}
+
+const _ConnectionStatus_name = "ConnectionStatusUnknownConnectionStatusConnectingConnectionStatusConnectedConnectionStatusDisconnectedConnectionStatusFailed"
+
+var _ConnectionStatus_index = [...]uint8{0, 23, 49, 74, 102, 124}
+
+func (i ConnectionStatus) String() string {
+	if i < 0 || i >= ConnectionStatus(len(_ConnectionStatus_index)-1) {
+		return "ConnectionStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ConnectionStatus_name[_ConnectionStatus_index[i]:_ConnectionStatus_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DRXCycleUnknown-0]
+	_ = x[DRXCycleUnsupported-1]
+	_ = x[DRXCycle32-2]
+	_ = x[DRXCycle64-3]
+	_ = x[DRXCycle128-4]
+	_ = x[DRXCycle256-5]
+}
+
+const _DRXCycle_name = "DRXCycleUnknownDRXCycleUnsupportedDRXCycle32DRXCycle64DRXCycle128DRXCycle256"
+
+var _DRXCycle_index = [...]uint8{0, 15, 34, 44, 54, 65, 76}
+
+func (i DRXCycle) String() string {
+	if i < 0 || i >= DRXCycle(len(_DRXCycle_index)-1) {
+		return "DRXCycle(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _DRXCycle_name[_DRXCycle_index[i]:_DRXCycle_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[DeliveryStatusPending-0]
+	_ = x[DeliveryStatusDelivered-1]
+	_ = x[DeliveryStatusFailed-2]
+}
+
+const _DeliveryStatus_name = "DeliveryStatusPendingDeliveryStatusDeliveredDeliveryStatusFailed"
+
+var _DeliveryStatus_index = [...]uint8{0, 21, 44, 64}
+
+func (i DeliveryStatus) String() string {
+	if i < 0 || i >= DeliveryStatus(len(_DeliveryStatus_index)-1) {
+		return "DeliveryStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _DeliveryStatus_name[_DeliveryStatus_index[i]:_DeliveryStatus_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ESIMStatusUnknown-0]
+	_ = x[ESIMStatusNoProfiles-1]
+	_ = x[ESIMStatusWithProfiles-2]
+}
+
+const _ESIMStatus_name = "ESIMStatusUnknownESIMStatusNoProfilesESIMStatusWithProfiles"
+
+var _ESIMStatus_index = [...]uint8{0, 17, 37, 59}
+
+func (i ESIMStatus) String() string {
+	if i < 0 || i >= ESIMStatus(len(_ESIMStatus_index)-1) {
+		return "ESIMStatus(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ESIMStatus_name[_ESIMStatus_index[i]:_ESIMStatus_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[ErrorDomainUnknown-0]
+	_ = x[ErrorDomainCore-1]
+	_ = x[ErrorDomainMobileEquipment-2]
+	_ = x[ErrorDomainConnection-3]
+	_ = x[ErrorDomainSerial-4]
+	_ = x[ErrorDomainMessage-5]
+	_ = x[ErrorDomainCDMAActivation-6]
+}
+
+const _ErrorDomain_name = "ErrorDomainUnknownErrorDomainCoreErrorDomainMobileEquipmentErrorDomainConnectionErrorDomainSerialErrorDomainMessageErrorDomainCDMAActivation"
+
+var _ErrorDomain_index = [...]uint8{0, 18, 33, 59, 80, 97, 115, 140}
+
+func (i ErrorDomain) String() string {
+	if i < 0 || i >= ErrorDomain(len(_ErrorDomain_index)-1) {
+		return "ErrorDomain(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _ErrorDomain_name[_ErrorDomain_index[i]:_ErrorDomain_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[FacilityLockNone-0]
+	_ = x[FacilityLockSIM-1]
+	_ = x[FacilityLockFixedDialing-2]
+	_ = x[FacilityLockPHSIM-4]
+	_ = x[FacilityLockPHFSIM-8]
+	_ = x[FacilityLockNetPers-16]
+	_ = x[FacilityLockNetSubPers-32]
+	_ = x[FacilityLockProviderPers-64]
+	_ = x[FacilityLockCorpPers-128]
+}
+
+const (
+	_FacilityLock_name_0 = "FacilityLockNoneFacilityLockSIMFacilityLockFixedDialing"
+	_FacilityLock_name_1 = "FacilityLockPHSIM"
+	_FacilityLock_name_2 = "FacilityLockPHFSIM"
+	_FacilityLock_name_3 = "FacilityLockNetPers"
+	_FacilityLock_name_4 = "FacilityLockNetSubPers"
+	_FacilityLock_name_5 = "FacilityLockProviderPers"
+	_FacilityLock_name_6 = "FacilityLockCorpPers"
+)
+
+var (
+	_FacilityLock_index_0 = [...]uint8{0, 16, 31, 55}
+)
+
+func (i FacilityLock) String() string {
+	switch {
+	case i <= 2:
+		return _FacilityLock_name_0[_FacilityLock_index_0[i]:_FacilityLock_index_0[i+1]]
+	case i == 4:
+		return _FacilityLock_name_1
+	case i == 8:
+		return _FacilityLock_name_2
+	case i == 16:
+		return _FacilityLock_name_3
+	case i == 32:
+		return _FacilityLock_name_4
+	case i == 64:
+		return _FacilityLock_name_5
+	case i == 128:
+		return _FacilityLock_name_6
+	default:
+		return "FacilityLock(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[FirmwareImageTypeUnknown-0]
+	_ = x[FirmwareImageTypeGeneric-1]
+	_ = x[FirmwareImageTypeGobi-2]
+}
+
+const _FirmwareImageType_name = "FirmwareImageTypeUnknownFirmwareImageTypeGenericFirmwareImageTypeGobi"
+
+var _FirmwareImageType_index = [...]uint8{0, 24, 48, 69}
+
+func (i FirmwareImageType) String() string {
+	if i < 0 || i >= FirmwareImageType(len(_FirmwareImageType_index)-1) {
+		return "FirmwareImageType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _FirmwareImageType_name[_FirmwareImageType_index[i]:_FirmwareImageType_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[LocationSourceNone-0]
+	_ = x[LocationSource3GPPLACCI-1]
+	_ = x[LocationSourceGPSRaw-2]
+	_ = x[LocationSourceGPSNMEA-4]
+	_ = x[LocationSourceCDMABS-8]
+	_ = x[LocationSourceGPSUnmanaged-16]
+	_ = x[LocationSourceAGPSMSA-32]
+	_ = x[LocationSourceAGPSMSB-64]
+}
+
+const (
+	_LocationSource_name_0 = "LocationSourceNoneLocationSource3GPPLACCILocationSourceGPSRaw"
+	_LocationSource_name_1 = "LocationSourceGPSNMEA"
+	_LocationSource_name_2 = "LocationSourceCDMABS"
+	_LocationSource_name_3 = "LocationSourceGPSUnmanaged"
+	_LocationSource_name_4 = "LocationSourceAGPSMSA"
+	_LocationSource_name_5 = "LocationSourceAGPSMSB"
+)
+
+var (
+	_LocationSource_index_0 = [...]uint8{0, 18, 41, 61}
+)
+
+func (i LocationSource) String() string {
+	switch {
+	case i <= 2:
+		return _LocationSource_name_0[_LocationSource_index_0[i]:_LocationSource_index_0[i+1]]
+	case i == 4:
+		return _LocationSource_name_1
+	case i == 8:
+		return _LocationSource_name_2
+	case i == 16:
+		return _LocationSource_name_3
+	case i == 32:
+		return _LocationSource_name_4
+	case i == 64:
+		return _LocationSource_name_5
+	default:
+		return "LocationSource(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[LockUnknown-0]
+	_ = x[LockNone-1]
+	_ = x[LockSIMPIN-2]
+	_ = x[LockSIMPIN2-3]
+	_ = x[LockSIMPUK-4]
+	_ = x[LockSIMPUK2-5]
+	_ = x[LockPHSPPIN-6]
+	_ = x[LockPHSPPUK-7]
+	_ = x[LockPHNetPIN-8]
+	_ = x[LockPHNetPUK-9]
+	_ = x[LockPHSIMPIN-10]
+	_ = x[LockPHCorpPIN-11]
+	_ = x[LockPHCorpPUK-12]
+	_ = x[LockPHFSIMPIN-13]
+	_ = x[LockPHFSIMPUK-14]
+	_ = x[LockPHNetSubPIN-15]
+	_ = x[LockPHNetSubPUK-16]
+}
+
+const _Lock_name = "LockUnknownLockNoneLockSIMPINLockSIMPIN2LockSIMPUKLockSIMPUK2LockPHSPPINLockPHSPPUKLockPHNetPINLockPHNetPUKLockPHSIMPINLockPHCorpPINLockPHCorpPUKLockPHFSIMPINLockPHFSIMPUKLockPHNetSubPINLockPHNetSubPUK"
+
+var _Lock_index = [...]uint8{0, 11, 19, 29, 40, 50, 61, 72, 83, 95, 107, 119, 132, 145, 158, 171, 186, 201}
+
+func (i Lock) String() string {
+	if i < 0 || i >= Lock(len(_Lock_index)-1) {
+		return "Lock(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _Lock_name[_Lock_index[i]:_Lock_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[MICOModeUnknown-0]
+	_ = x[MICOModeUnsupported-1]
+	_ = x[MICOModeDisabled-2]
+	_ = x[MICOModeEnabled-3]
+}
+
+const _MICOMode_name = "MICOModeUnknownMICOModeUnsupportedMICOModeDisabledMICOModeEnabled"
+
+var _MICOMode_index = [...]uint8{0, 15, 34, 50, 65}
+
+func (i MICOMode) String() string {
+	if i < 0 || i >= MICOMode(len(_MICOMode_index)-1) {
+		return "MICOMode(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _MICOMode_name[_MICOMode_index[i]:_MICOMode_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[NetworkErrorNone-0]
+	_ = x[NetworkErrorIMSIUnknownInHLR-2]
+	_ = x[NetworkErrorIllegalMS-3]
+	_ = x[NetworkErrorIMSIUnknownInVLR-4]
+	_ = x[NetworkErrorIMEINotAccepted-5]
+	_ = x[NetworkErrorIllegalME-6]
+	_ = x[NetworkErrorGPRSNotAllowed-7]
+	_ = x[NetworkErrorGPRSAndNonGPRSNotAllowed-8]
+	_ = x[NetworkErrorMSIdentityNotDerivedByNetwork-9]
+	_ = x[NetworkErrorImplicitlyDetached-10]
+	_ = x[NetworkErrorPLMNNotAllowed-11]
+	_ = x[NetworkErrorLocationAreaNotAllowed-12]
+	_ = x[NetworkErrorRoamingNotAllowedInLocationArea-13]
+	_ = x[NetworkErrorGPRSNotAllowedInPLMN-14]
+	_ = x[NetworkErrorNoCellsInLocationArea-15]
+	_ = x[NetworkErrorMSCTemporarilyNotReachable-16]
+	_ = x[NetworkErrorNetworkFailure-17]
+	_ = x[NetworkErrorCSDomainNotAvailable-18]
+	_ = x[NetworkErrorESMFailure-19]
+	_ = x[NetworkErrorMACFailure-20]
+	_ = x[NetworkErrorSynchFailure-21]
+	_ = x[NetworkErrorCongestion-22]
+	_ = x[NetworkErrorGSMAuthenticationUnacceptable-23]
+	_ = x[NetworkErrorNotAuthorizedForCSG-25]
+	_ = x[NetworkErrorInsufficientResources-26]
+	_ = x[NetworkErrorMissingOrUnknownAPN-27]
+	_ = x[NetworkErrorUnknownPDPAddressOrType-28]
+	_ = x[NetworkErrorUserAuthenticationFailed-29]
+	_ = x[NetworkErrorActivationRejectedByGGSNOrGW-30]
+	_ = x[NetworkErrorActivationRejectedUnspecified-31]
+	_ = x[NetworkErrorServiceOptionNotSupported-32]
+	_ = x[NetworkErrorRequestedServiceOptionNotSubscribed-33]
+	_ = x[NetworkErrorServiceOptionTemporarilyOutOfOrder-34]
+	_ = x[NetworkErrorNoPDPContextActivated-40]
+	_ = x[NetworkErrorSemanticErrorInTheTFTOperation-41]
+	_ = x[NetworkErrorSyntacticalErrorInTheTFTOperation-42]
+	_ = x[NetworkErrorUnknownPDPContext-43]
+	_ = x[NetworkErrorSemanticErrorsInPacketFilter-44]
+	_ = x[NetworkErrorSyntacticalErrorInPacketFilter-45]
+	_ = x[NetworkErrorPDPContextWithoutTFTAlreadyActivated-46]
+	_ = x[NetworkErrorMulticastGroupMembershipTimeout-47]
+	_ = x[NetworkErrorRequestRejectedBCMViolation-48]
+	_ = x[NetworkErrorLastPDNDisconnectionNotAllowed-49]
+	_ = x[NetworkErrorPDPTypeIPv4OnlyAllowed-50]
+	_ = x[NetworkErrorPDPTypeIPv6OnlyAllowed-51]
+	_ = x[NetworkErrorMaximumNumberOfPDPContextsReached-65]
+	_ = x[NetworkErrorRequestedAPNNotSupportedInCurrentRATAndPLMN-66]
+	_ = x[NetworkErrorInvalidTransactionIdentifierValue-81]
+	_ = x[NetworkErrorSemanticallyIncorrectMessage-95]
+	_ = x[NetworkErrorInvalidMandatoryInformation-96]
+	_ = x[NetworkErrorMessageTypeNonExistent-97]
+	_ = x[NetworkErrorMessageTypeNotCompatible-98]
+	_ = x[NetworkErrorIENonExistent-99]
+	_ = x[NetworkErrorConditionalIEError-100]
+	_ = x[NetworkErrorMessageNotCompatible-101]
+	_ = x[NetworkErrorProtocolErrorUnspecified-111]
+	_ = x[NetworkErrorAPNRestrictionValueIncompatible-112]
+	_ = x[NetworkErrorMultipleAccessesToPLMNNotAllowed-113]
+}
+
+const (
+	_NetworkError_name_0 = "NetworkErrorNone"
+	_NetworkError_name_1 = "NetworkErrorIMSIUnknownInHLRNetworkErrorIllegalMSNetworkErrorIMSIUnknownInVLRNetworkErrorIMEINotAcceptedNetworkErrorIllegalMENetworkErrorGPRSNotAllowedNetworkErrorGPRSAndNonGPRSNotAllowedNetworkErrorMSIdentityNotDerivedByNetworkNetworkErrorImplicitlyDetachedNetworkErrorPLMNNotAllowedNetworkErrorLocationAreaNotAllowedNetworkErrorRoamingNotAllowedInLocationAreaNetworkErrorGPRSNotAllowedInPLMNNetworkErrorNoCellsInLocationAreaNetworkErrorMSCTemporarilyNotReachableNetworkErrorNetworkFailureNetworkErrorCSDomainNotAvailableNetworkErrorESMFailureNetworkErrorMACFailureNetworkErrorSynchFailureNetworkErrorCongestionNetworkErrorGSMAuthenticationUnacceptable"
+	_NetworkError_name_2 = "NetworkErrorNotAuthorizedForCSGNetworkErrorInsufficientResourcesNetworkErrorMissingOrUnknownAPNNetworkErrorUnknownPDPAddressOrTypeNetworkErrorUserAuthenticationFailedNetworkErrorActivationRejectedByGGSNOrGWNetworkErrorActivationRejectedUnspecifiedNetworkErrorServiceOptionNotSupportedNetworkErrorRequestedServiceOptionNotSubscribedNetworkErrorServiceOptionTemporarilyOutOfOrder"
+	_NetworkError_name_3 = "NetworkErrorNoPDPContextActivatedNetworkErrorSemanticErrorInTheTFTOperationNetworkErrorSyntacticalErrorInTheTFTOperationNetworkErrorUnknownPDPContextNetworkErrorSemanticErrorsInPacketFilterNetworkErrorSyntacticalErrorInPacketFilterNetworkErrorPDPContextWithoutTFTAlreadyActivatedNetworkErrorMulticastGroupMembershipTimeoutNetworkErrorRequestRejectedBCMViolationNetworkErrorLastPDNDisconnectionNotAllowedNetworkErrorPDPTypeIPv4OnlyAllowedNetworkErrorPDPTypeIPv6OnlyAllowed"
+	_NetworkError_name_4 = "NetworkErrorMaximumNumberOfPDPContextsReachedNetworkErrorRequestedAPNNotSupportedInCurrentRATAndPLMN"
+	_NetworkError_name_5 = "NetworkErrorInvalidTransactionIdentifierValue"
+	_NetworkError_name_6 = "NetworkErrorSemanticallyIncorrectMessageNetworkErrorInvalidMandatoryInformationNetworkErrorMessageTypeNonExistentNetworkErrorMessageTypeNotCompatibleNetworkErrorIENonExistentNetworkErrorConditionalIEErrorNetworkErrorMessageNotCompatible"
+	_NetworkError_name_7 = "NetworkErrorProtocolErrorUnspecifiedNetworkErrorAPNRestrictionValueIncompatibleNetworkErrorMultipleAccessesToPLMNNotAllowed"
+)
+
+var (
+	_NetworkError_index_1 = [...]uint16{0, 28, 49, 77, 104, 125, 151, 187, 228, 258, 284, 318, 361, 393, 426, 464, 490, 522, 544, 566, 590, 612, 653}
+	_NetworkError_index_2 = [...]uint16{0, 31, 64, 95, 130, 166, 206, 247, 284, 331, 377}
+	_NetworkError_index_3 = [...]uint16{0, 33, 75, 120, 149, 189, 231, 279, 322, 361, 403, 437, 471}
+	_NetworkError_index_4 = [...]uint8{0, 45, 100}
+	_NetworkError_index_6 = [...]uint8{0, 40, 79, 113, 149, 174, 204, 236}
+	_NetworkError_index_7 = [...]uint8{0, 36, 79, 123}
+)
+
+func (i NetworkError) String() string {
+	switch {
+	case i == 0:
+		return _NetworkError_name_0
+	case 2 <= i && i <= 23:
+		i -= 2
+		return _NetworkError_name_1[_NetworkError_index_1[i]:_NetworkError_index_1[i+1]]
+	case 25 <= i && i <= 34:
+		i -= 25
+		return _NetworkError_name_2[_NetworkError_index_2[i]:_NetworkError_index_2[i+1]]
+	case 40 <= i && i <= 51:
+		i -= 40
+		return _NetworkError_name_3[_NetworkError_index_3[i]:_NetworkError_index_3[i+1]]
+	case 65 <= i && i <= 66:
+		i -= 65
+		return _NetworkError_name_4[_NetworkError_index_4[i]:_NetworkError_index_4[i+1]]
+	case i == 81:
+		return _NetworkError_name_5
+	case 95 <= i && i <= 101:
+		i -= 95
+		return _NetworkError_name_6[_NetworkError_index_6[i]:_NetworkError_index_6[i+1]]
+	case 111 <= i && i <= 113:
+		i -= 111
+		return _NetworkError_name_7[_NetworkError_index_7[i]:_NetworkError_index_7[i+1]]
+	default:
+		return "NetworkError(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[OMAFeatureNone-0]
+	_ = x[OMAFeatureDeviceProvisioning-1]
+	_ = x[OMAFeaturePRLUpdate-2]
+	_ = x[OMAFeatureHandsFreeActivation-4]
+}
+
+const (
+	_OMAFeature_name_0 = "OMAFeatureNoneOMAFeatureDeviceProvisioningOMAFeaturePRLUpdate"
+	_OMAFeature_name_1 = "OMAFeatureHandsFreeActivation"
+)
+
+var (
+	_OMAFeature_index_0 = [...]uint8{0, 14, 42, 61}
+)
+
+func (i OMAFeature) String() string {
+	switch {
+	case i <= 2:
+		return _OMAFeature_name_0[_OMAFeature_index_0[i]:_OMAFeature_index_0[i+1]]
+	case i == 4:
+		return _OMAFeature_name_1
+	default:
+		return "OMAFeature(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[OMASessionStateFailed - -1]
+	_ = x[OMASessionStateUnknown-0]
+	_ = x[OMASessionStateStarted-1]
+	_ = x[OMASessionStateRetrying-2]
+	_ = x[OMASessionStateConnecting-3]
+	_ = x[OMASessionStateConnected-4]
+	_ = x[OMASessionStateAuthenticated-5]
+	_ = x[OMASessionStateMDNDownloaded-10]
+	_ = x[OMASessionStateMSIDDownloaded-11]
+	_ = x[OMASessionStatePRLDownloaded-12]
+	_ = x[OMASessionStateMIPProfileDownloaded-13]
+	_ = x[OMASessionStateCompleted-20]
+}
+
+const (
+	_OMASessionState_name_0 = "OMASessionStateFailedOMASessionStateUnknownOMASessionStateStartedOMASessionStateRetryingOMASessionStateConnectingOMASessionStateConnectedOMASessionStateAuthenticated"
+	_OMASessionState_name_1 = "OMASessionStateMDNDownloadedOMASessionStateMSIDDownloadedOMASessionStatePRLDownloadedOMASessionStateMIPProfileDownloaded"
+	_OMASessionState_name_2 = "OMASessionStateCompleted"
+)
+
+var (
+	_OMASessionState_index_0 = [...]uint8{0, 21, 43, 65, 88, 113, 137, 165}
+	_OMASessionState_index_1 = [...]uint8{0, 28, 57, 85, 120}
+)
+
+func (i OMASessionState) String() string {
+	switch {
+	case -1 <= i && i <= 5:
+		i -= -1
+		return _OMASessionState_name_0[_OMASessionState_index_0[i]:_OMASessionState_index_0[i+1]]
+	case 10 <= i && i <= 13:
+		i -= 10
+		return _OMASessionState_name_1[_OMASessionState_index_1[i]:_OMASessionState_index_1[i+1]]
+	case i == 20:
+		return _OMASessionState_name_2
+	default:
+		return "OMASessionState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[OMASessionStateFailedReasonUnknown-0]
+	_ = x[OMASessionStateFailedReasonNetworkUnavailable-1]
+	_ = x[OMASessionStateFailedReasonServerUnavailable-2]
+	_ = x[OMASessionStateFailedReasonAuthenticationFailed-3]
+	_ = x[OMASessionStateFailedReasonMaxRetryExceeded-4]
+	_ = x[OMASessionStateFailedReasonSessionCancelled-5]
+}
+
+const _OMASessionStateFailedReason_name = "OMASessionStateFailedReasonUnknownOMASessionStateFailedReasonNetworkUnavailableOMASessionStateFailedReasonServerUnavailableOMASessionStateFailedReasonAuthenticationFailedOMASessionStateFailedReasonMaxRetryExceededOMASessionStateFailedReasonSessionCancelled"
+
+var _OMASessionStateFailedReason_index = [...]uint16{0, 34, 79, 123, 170, 213, 256}
+
+func (i OMASessionStateFailedReason) String() string {
+	if i < 0 || i >= OMASessionStateFailedReason(len(_OMASessionStateFailedReason_index)-1) {
+		return "OMASessionStateFailedReason(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _OMASessionStateFailedReason_name[_OMASessionStateFailedReason_index[i]:_OMASessionStateFailedReason_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[OMASessionTypeUnknown-0]
+	_ = x[OMASessionTypeClientInitiatedDeviceConfigure-10]
+	_ = x[OMASessionTypeClientInitiatedPRLUpdate-11]
+	_ = x[OMASessionTypeClientInitiatedHandsFreeActivation-12]
+	_ = x[OMASessionTypeNetworkInitiatedDeviceConfigure-20]
+	_ = x[OMASessionTypeNetworkInitiatedPRLUpdate-21]
+	_ = x[OMASessionTypeDeviceInitiatedPRLUpdate-30]
+	_ = x[OMASessionTypeDeviceInitiatedHandsFreeActivation-31]
+}
+
+const (
+	_OMASessionType_name_0 = "OMASessionTypeUnknown"
+	_OMASessionType_name_1 = "OMASessionTypeClientInitiatedDeviceConfigureOMASessionTypeClientInitiatedPRLUpdateOMASessionTypeClientInitiatedHandsFreeActivation"
+	_OMASessionType_name_2 = "OMASessionTypeNetworkInitiatedDeviceConfigureOMASessionTypeNetworkInitiatedPRLUpdate"
+	_OMASessionType_name_3 = "OMASessionTypeDeviceInitiatedPRLUpdateOMASessionTypeDeviceInitiatedHandsFreeActivation"
+)
+
+var (
+	_OMASessionType_index_1 = [...]uint8{0, 44, 82, 130}
+	_OMASessionType_index_2 = [...]uint8{0, 45, 84}
+	_OMASessionType_index_3 = [...]uint8{0, 38, 86}
+)
+
+func (i OMASessionType) String() string {
+	switch {
+	case i == 0:
+		return _OMASessionType_name_0
+	case 10 <= i && i <= 12:
+		i -= 10
+		return _OMASessionType_name_1[_OMASessionType_index_1[i]:_OMASessionType_index_1[i+1]]
+	case 20 <= i && i <= 21:
+		i -= 20
+		return _OMASessionType_name_2[_OMASessionType_index_2[i]:_OMASessionType_index_2[i+1]]
+	case 30 <= i && i <= 31:
+		i -= 30
+		return _OMASessionType_name_3[_OMASessionType_index_3[i]:_OMASessionType_index_3[i+1]]
+	default:
+		return "OMASessionType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[PacketServiceStateUnknown-0]
+	_ = x[PacketServiceStateDetached-1]
+	_ = x[PacketServiceStateAttached-2]
+}
+
+const _PacketServiceState_name = "PacketServiceStateUnknownPacketServiceStateDetachedPacketServiceStateAttached"
+
+var _PacketServiceState_index = [...]uint8{0, 25, 51, 77}
+
+func (i PacketServiceState) String() string {
+	if i < 0 || i >= PacketServiceState(len(_PacketServiceState_index)-1) {
+		return "PacketServiceState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _PacketServiceState_name[_PacketServiceState_index[i]:_PacketServiceState_index[i+1]]
+}
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
@@ -69,6 +982,295 @@ func (i PowerState) String() string {
 	}
 	return _PowerState_name[_PowerState_index[i]:_PowerState_index[i+1]]
 }
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[RecoveryActionUnknown-0]
+	_ = x[RecoveryActionDisconnect-1]
+	_ = x[RecoveryActionReenable-2]
+	_ = x[RecoveryActionReset-3]
+}
+
+const _RecoveryAction_name = "RecoveryActionUnknownRecoveryActionDisconnectRecoveryActionReenableRecoveryActionReset"
+
+var _RecoveryAction_index = [...]uint8{0, 21, 45, 67, 86}
+
+func (i RecoveryAction) String() string {
+	if i < 0 || i >= RecoveryAction(len(_RecoveryAction_index)-1) {
+		return "RecoveryAction(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _RecoveryAction_name[_RecoveryAction_index[i]:_RecoveryAction_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[RegistrationState3GPPIdle-0]
+	_ = x[RegistrationState3GPPHome-1]
+	_ = x[RegistrationState3GPPSearching-2]
+	_ = x[RegistrationState3GPPDenied-3]
+	_ = x[RegistrationState3GPPUnknown-4]
+	_ = x[RegistrationState3GPPRoaming-5]
+	_ = x[RegistrationState3GPPHomeSMSOnly-6]
+	_ = x[RegistrationState3GPPRoamingSMSOnly-7]
+	_ = x[RegistrationState3GPPEmergencyOnly-8]
+	_ = x[RegistrationState3GPPHomeCSFBNotPreferred-9]
+	_ = x[RegistrationState3GPPRoamingCSFBNotPreferred-10]
+	_ = x[RegistrationState3GPPAttachedRLOS-11]
+}
+
+const _RegistrationState3GPP_name = "RegistrationState3GPPIdleRegistrationState3GPPHomeRegistrationState3GPPSearchingRegistrationState3GPPDeniedRegistrationState3GPPUnknownRegistrationState3GPPRoamingRegistrationState3GPPHomeSMSOnlyRegistrationState3GPPRoamingSMSOnlyRegistrationState3GPPEmergencyOnlyRegistrationState3GPPHomeCSFBNotPreferredRegistrationState3GPPRoamingCSFBNotPreferredRegistrationState3GPPAttachedRLOS"
+
+var _RegistrationState3GPP_index = [...]uint16{0, 25, 50, 80, 107, 135, 163, 195, 230, 264, 305, 349, 382}
+
+func (i RegistrationState3GPP) String() string {
+	if i < 0 || i >= RegistrationState3GPP(len(_RegistrationState3GPP_index)-1) {
+		return "RegistrationState3GPP(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _RegistrationState3GPP_name[_RegistrationState3GPP_index[i]:_RegistrationState3GPP_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SIMRemovabilityUnknown-0]
+	_ = x[SIMRemovabilityRemovable-1]
+	_ = x[SIMRemovabilityNotRemovable-2]
+}
+
+const _SIMRemovability_name = "SIMRemovabilityUnknownSIMRemovabilityRemovableSIMRemovabilityNotRemovable"
+
+var _SIMRemovability_index = [...]uint8{0, 22, 46, 73}
+
+func (i SIMRemovability) String() string {
+	if i < 0 || i >= SIMRemovability(len(_SIMRemovability_index)-1) {
+		return "SIMRemovability(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SIMRemovability_name[_SIMRemovability_index[i]:_SIMRemovability_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SIMTypeUnknown-0]
+	_ = x[SIMTypePhysical-1]
+	_ = x[SIMTypeESIM-2]
+}
+
+const _SIMType_name = "SIMTypeUnknownSIMTypePhysicalSIMTypeESIM"
+
+var _SIMType_index = [...]uint8{0, 14, 29, 40}
+
+func (i SIMType) String() string {
+	if i < 0 || i >= SIMType(len(_SIMType_index)-1) {
+		return "SIMType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SIMType_name[_SIMType_index[i]:_SIMType_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSCDMATeleserviceIDUnknown-0]
+	_ = x[SMSCDMATeleserviceIDCMT91-4096]
+	_ = x[SMSCDMATeleserviceIDWPT-4097]
+	_ = x[SMSCDMATeleserviceIDWMT-4098]
+	_ = x[SMSCDMATeleserviceIDVMN-4099]
+	_ = x[SMSCDMATeleserviceIDWAP-4100]
+	_ = x[SMSCDMATeleserviceIDWEMT-4101]
+	_ = x[SMSCDMATeleserviceIDSCPT-4102]
+	_ = x[SMSCDMATeleserviceIDCATPT-4103]
+}
+
+const (
+	_SMSCDMATeleserviceID_name_0 = "SMSCDMATeleserviceIDUnknown"
+	_SMSCDMATeleserviceID_name_1 = "SMSCDMATeleserviceIDCMT91SMSCDMATeleserviceIDWPTSMSCDMATeleserviceIDWMTSMSCDMATeleserviceIDVMNSMSCDMATeleserviceIDWAPSMSCDMATeleserviceIDWEMTSMSCDMATeleserviceIDSCPTSMSCDMATeleserviceIDCATPT"
+)
+
+var (
+	_SMSCDMATeleserviceID_index_1 = [...]uint8{0, 25, 48, 71, 94, 117, 141, 165, 190}
+)
+
+func (i SMSCDMATeleserviceID) String() string {
+	switch {
+	case i == 0:
+		return _SMSCDMATeleserviceID_name_0
+	case 4096 <= i && i <= 4103:
+		i -= 4096
+		return _SMSCDMATeleserviceID_name_1[_SMSCDMATeleserviceID_index_1[i]:_SMSCDMATeleserviceID_index_1[i+1]]
+	default:
+		return "SMSCDMATeleserviceID(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSDeliveryStateCompletedReceived-0]
+	_ = x[SMSDeliveryStateCompletedForwardedUnconfirmed-1]
+	_ = x[SMSDeliveryStateCompletedReplacedBySC-2]
+	_ = x[SMSDeliveryStateTemporaryErrorCongestion-32]
+	_ = x[SMSDeliveryStateTemporaryErrorSMEBusy-33]
+	_ = x[SMSDeliveryStateTemporaryErrorNoResponseFromSME-34]
+	_ = x[SMSDeliveryStateTemporaryErrorServiceRejected-35]
+	_ = x[SMSDeliveryStateTemporaryErrorQoSNotAvailable-36]
+	_ = x[SMSDeliveryStateTemporaryErrorInSME-37]
+	_ = x[SMSDeliveryStateErrorRemoteProcedure-64]
+	_ = x[SMSDeliveryStateErrorIncompatibleDestination-65]
+	_ = x[SMSDeliveryStateErrorConnectionRejectedBySME-66]
+	_ = x[SMSDeliveryStateErrorNotObtainable-67]
+	_ = x[SMSDeliveryStateErrorQoSNotAvailable-68]
+	_ = x[SMSDeliveryStateErrorNoInterworkingAvailable-69]
+	_ = x[SMSDeliveryStateErrorValidityPeriodExpired-70]
+	_ = x[SMSDeliveryStateErrorDeletedByOriginatingSME-71]
+	_ = x[SMSDeliveryStateErrorDeletedBySCAdministration-72]
+	_ = x[SMSDeliveryStateErrorMessageDoesNotExist-73]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorCongestion-96]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorSMEBusy-97]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorNoResponseFromSME-98]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorServiceRejected-99]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorQoSNotAvailable-100]
+	_ = x[SMSDeliveryStateTemporaryFatalErrorInSME-101]
+	_ = x[SMSDeliveryStateUnknown-256]
+}
+
+const (
+	_SMSDeliveryState_name_0 = "SMSDeliveryStateCompletedReceivedSMSDeliveryStateCompletedForwardedUnconfirmedSMSDeliveryStateCompletedReplacedBySC"
+	_SMSDeliveryState_name_1 = "SMSDeliveryStateTemporaryErrorCongestionSMSDeliveryStateTemporaryErrorSMEBusySMSDeliveryStateTemporaryErrorNoResponseFromSMESMSDeliveryStateTemporaryErrorServiceRejectedSMSDeliveryStateTemporaryErrorQoSNotAvailableSMSDeliveryStateTemporaryErrorInSME"
+	_SMSDeliveryState_name_2 = "SMSDeliveryStateErrorRemoteProcedureSMSDeliveryStateErrorIncompatibleDestinationSMSDeliveryStateErrorConnectionRejectedBySMESMSDeliveryStateErrorNotObtainableSMSDeliveryStateErrorQoSNotAvailableSMSDeliveryStateErrorNoInterworkingAvailableSMSDeliveryStateErrorValidityPeriodExpiredSMSDeliveryStateErrorDeletedByOriginatingSMESMSDeliveryStateErrorDeletedBySCAdministrationSMSDeliveryStateErrorMessageDoesNotExist"
+	_SMSDeliveryState_name_3 = "SMSDeliveryStateTemporaryFatalErrorCongestionSMSDeliveryStateTemporaryFatalErrorSMEBusySMSDeliveryStateTemporaryFatalErrorNoResponseFromSMESMSDeliveryStateTemporaryFatalErrorServiceRejectedSMSDeliveryStateTemporaryFatalErrorQoSNotAvailableSMSDeliveryStateTemporaryFatalErrorInSME"
+	_SMSDeliveryState_name_4 = "SMSDeliveryStateUnknown"
+)
+
+var (
+	_SMSDeliveryState_index_0 = [...]uint8{0, 33, 78, 115}
+	_SMSDeliveryState_index_1 = [...]uint8{0, 40, 77, 124, 169, 214, 249}
+	_SMSDeliveryState_index_2 = [...]uint16{0, 36, 80, 124, 158, 194, 238, 280, 324, 370, 410}
+	_SMSDeliveryState_index_3 = [...]uint16{0, 45, 87, 139, 189, 239, 279}
+)
+
+func (i SMSDeliveryState) String() string {
+	switch {
+	case 0 <= i && i <= 2:
+		return _SMSDeliveryState_name_0[_SMSDeliveryState_index_0[i]:_SMSDeliveryState_index_0[i+1]]
+	case 32 <= i && i <= 37:
+		i -= 32
+		return _SMSDeliveryState_name_1[_SMSDeliveryState_index_1[i]:_SMSDeliveryState_index_1[i+1]]
+	case 64 <= i && i <= 73:
+		i -= 64
+		return _SMSDeliveryState_name_2[_SMSDeliveryState_index_2[i]:_SMSDeliveryState_index_2[i+1]]
+	case 96 <= i && i <= 101:
+		i -= 96
+		return _SMSDeliveryState_name_3[_SMSDeliveryState_index_3[i]:_SMSDeliveryState_index_3[i+1]]
+	case i == 256:
+		return _SMSDeliveryState_name_4
+	default:
+		return "SMSDeliveryState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSPDUTypeUnknown-0]
+	_ = x[SMSPDUTypeDeliver-1]
+	_ = x[SMSPDUTypeSubmit-2]
+	_ = x[SMSPDUTypeStatusReport-3]
+	_ = x[SMSPDUTypeCDMADeliver-32]
+	_ = x[SMSPDUTypeCDMASubmit-33]
+	_ = x[SMSPDUTypeCDMACancellation-34]
+	_ = x[SMSPDUTypeCDMADeliveryAcknowledgement-35]
+	_ = x[SMSPDUTypeCDMAUserAcknowledgement-36]
+	_ = x[SMSPDUTypeCDMAReadAcknowledgement-37]
+}
+
+const (
+	_SMSPDUType_name_0 = "SMSPDUTypeUnknownSMSPDUTypeDeliverSMSPDUTypeSubmitSMSPDUTypeStatusReport"
+	_SMSPDUType_name_1 = "SMSPDUTypeCDMADeliverSMSPDUTypeCDMASubmitSMSPDUTypeCDMACancellationSMSPDUTypeCDMADeliveryAcknowledgementSMSPDUTypeCDMAUserAcknowledgementSMSPDUTypeCDMAReadAcknowledgement"
+)
+
+var (
+	_SMSPDUType_index_0 = [...]uint8{0, 17, 34, 50, 72}
+	_SMSPDUType_index_1 = [...]uint8{0, 21, 41, 67, 104, 137, 170}
+)
+
+func (i SMSPDUType) String() string {
+	switch {
+	case 0 <= i && i <= 3:
+		return _SMSPDUType_name_0[_SMSPDUType_index_0[i]:_SMSPDUType_index_0[i+1]]
+	case 32 <= i && i <= 37:
+		i -= 32
+		return _SMSPDUType_name_1[_SMSPDUType_index_1[i]:_SMSPDUType_index_1[i+1]]
+	default:
+		return "SMSPDUType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSStateUnknown-0]
+	_ = x[SMSStateStored-1]
+	_ = x[SMSStateReceiving-2]
+	_ = x[SMSStateReceived-3]
+	_ = x[SMSStateSending-4]
+	_ = x[SMSStateSent-5]
+}
+
+const _SMSState_name = "SMSStateUnknownSMSStateStoredSMSStateReceivingSMSStateReceivedSMSStateSendingSMSStateSent"
+
+var _SMSState_index = [...]uint8{0, 15, 29, 46, 62, 77, 89}
+
+func (i SMSState) String() string {
+	if i < 0 || i >= SMSState(len(_SMSState_index)-1) {
+		return "SMSState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SMSState_name[_SMSState_index[i]:_SMSState_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSStorageUnknown-0]
+	_ = x[SMSStorageSM-1]
+	_ = x[SMSStorageME-2]
+	_ = x[SMSStorageMT-3]
+	_ = x[SMSStorageSR-4]
+	_ = x[SMSStorageBM-5]
+	_ = x[SMSStorageTA-6]
+}
+
+const _SMSStorage_name = "SMSStorageUnknownSMSStorageSMSMSStorageMESMSStorageMTSMSStorageSRSMSStorageBMSMSStorageTA"
+
+var _SMSStorage_index = [...]uint8{0, 17, 29, 41, 53, 65, 77, 89}
+
+func (i SMSStorage) String() string {
+	if i < 0 || i >= SMSStorage(len(_SMSStorage_index)-1) {
+		return "SMSStorage(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SMSStorage_name[_SMSStorage_index[i]:_SMSStorage_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[SMSValidityTypeUnknown-0]
+	_ = x[SMSValidityTypeRelative-1]
+	_ = x[SMSValidityTypeAbsolute-2]
+	_ = x[SMSValidityTypeEnhanced-3]
+}
+
+const _SMSValidityType_name = "SMSValidityTypeUnknownSMSValidityTypeRelativeSMSValidityTypeAbsoluteSMSValidityTypeEnhanced"
+
+var _SMSValidityType_index = [...]uint8{0, 22, 45, 68, 91}
+
+func (i SMSValidityType) String() string {
+	if i < 0 || i >= SMSValidityType(len(_SMSValidityType_index)-1) {
+		return "SMSValidityType(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _SMSValidityType_name[_SMSValidityType_index[i]:_SMSValidityType_index[i+1]]
+}
 func _() {
 	// An "invalid array index" compiler error signifies that the constant values have changed.
 	// Re-run the stringer command to generate them again.
@@ -99,3 +1301,43 @@ func (i State) String() string {
 	}
 	return _State_name[_State_index[i]:_State_index[i+1]]
 }
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[StateChangeReasonUnknown-0]
+	_ = x[StateChangeReasonUserRequested-1]
+	_ = x[StateChangeReasonSuspend-2]
+	_ = x[StateChangeReasonFailure-3]
+}
+
+const _StateChangeReason_name = "StateChangeReasonUnknownStateChangeReasonUserRequestedStateChangeReasonSuspendStateChangeReasonFailure"
+
+var _StateChangeReason_index = [...]uint8{0, 24, 54, 78, 102}
+
+func (i StateChangeReason) String() string {
+	if i < 0 || i >= StateChangeReason(len(_StateChangeReason_index)-1) {
+		return "StateChangeReason(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _StateChangeReason_name[_StateChangeReason_index[i]:_StateChangeReason_index[i+1]]
+}
+func _() {
+	// An "invalid array index" compiler error signifies that the constant values have changed.
+	// Re-run the stringer command to generate them again.
+	var x [1]struct{}
+	_ = x[USSDStateUnknown-0]
+	_ = x[USSDStateIdle-1]
+	_ = x[USSDStateActive-2]
+	_ = x[USSDStateUserResponse-3]
+}
+
+const _USSDState_name = "USSDStateUnknownUSSDStateIdleUSSDStateActiveUSSDStateUserResponse"
+
+var _USSDState_index = [...]uint8{0, 16, 29, 44, 65}
+
+func (i USSDState) String() string {
+	if i < 0 || i >= USSDState(len(_USSDState_index)-1) {
+		return "USSDState(" + strconv.FormatInt(int64(i), 10) + ")"
+	}
+	return _USSDState_name[_USSDState_index[i]:_USSDState_index[i+1]]
+}
@@ -0,0 +1,240 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// Usage is the cumulative data usage of a Modem across all of its Bearers.
+type Usage struct {
+	RXBytes, TXBytes uint64
+	Duration         time.Duration
+}
+
+// A UsageStore persists cumulative Usage so it survives restarts of the
+// program or the Modem. Usage is keyed on the Modem's IMEI.
+type UsageStore interface {
+	// LoadUsage loads the Usage for a Modem. If no Usage was saved, an error
+	// compatible with 'errors.Is(err, os.ErrNotExist)' must be returned.
+	LoadUsage(ctx context.Context, imei string) (Usage, error)
+
+	// SaveUsage saves the Usage for a Modem.
+	SaveUsage(ctx context.Context, imei string, u Usage) error
+}
+
+// A UsageAccountant aggregates the statistics of a Modem's Bearers into
+// cumulative Usage which is preserved when Bearers reconnect or are recreated,
+// and when the Modem restarts. Its methods are safe for concurrent use.
+type UsageAccountant struct {
+	store UsageStore
+
+	mu     sync.Mutex
+	modems map[string]*modemUsage
+}
+
+// modemUsage is the accounting state for a single Modem.
+type modemUsage struct {
+	total Usage
+
+	// The most recent statistics for each Bearer.
+	bearers map[dbus.ObjectPath]*BearerStats
+}
+
+// NewUsageAccountant creates a UsageAccountant which persists Usage with the
+// input UsageStore. If store is nil, Usage is only kept in memory.
+func NewUsageAccountant(store UsageStore) *UsageAccountant {
+	return &UsageAccountant{
+		store:  store,
+		modems: make(map[string]*modemUsage),
+	}
+}
+
+// Usage returns the cumulative Usage for the Modem with the input IMEI. If the
+// Modem has not been tracked, ok is false.
+func (a *UsageAccountant) Usage(imei string) (u Usage, ok bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, ok := a.modems[imei]
+	if !ok {
+		return Usage{}, false
+	}
+
+	return state.total, true
+}
+
+// Track polls the statistics of the Modem's Bearers at the specified interval
+// and adds any new usage to the Modem's cumulative Usage, saving it to the
+// UsageStore after each change. Only one Track call may be active for a given
+// Modem at a time.
+//
+// Usage which occurred before Track is called is not counted, as it may have
+// been counted by a previous call. Usage by a Bearer since it was last polled
+// is lost when that Bearer is removed, so shorter intervals are more accurate.
+//
+// Track returns nil when the context is canceled, or an error if the Modem can
+// no longer be polled, such as when the Modem is removed after a restart. The
+// Modem may then be fetched again and passed to Track to continue accounting.
+func (a *UsageAccountant) Track(ctx context.Context, m *Modem, interval time.Duration) error {
+	imei := m.EquipmentIdentifier
+	if imei == "" {
+		return errors.New("modem has no equipment identifier")
+	}
+
+	if err := a.load(ctx, imei); err != nil {
+		return err
+	}
+
+	// The first poll establishes a baseline for the existing Bearers.
+	if err := a.poll(ctx, m, imei, true); err != nil {
+		return err
+	}
+
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if err := a.poll(ctx, m, imei, false); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return err
+		}
+	}
+}
+
+// load loads a Modem's Usage from the UsageStore if it is not already known.
+func (a *UsageAccountant) load(ctx context.Context, imei string) error {
+	a.mu.Lock()
+	_, ok := a.modems[imei]
+	a.mu.Unlock()
+	if ok {
+		return nil
+	}
+
+	var u Usage
+	if a.store != nil {
+		var err error
+		u, err = a.store.LoadUsage(ctx, imei)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if _, ok := a.modems[imei]; !ok {
+		a.modems[imei] = &modemUsage{
+			total:   u,
+			bearers: make(map[dbus.ObjectPath]*BearerStats),
+		}
+	}
+
+	return nil
+}
+
+// poll fetches the statistics of a Modem's Bearers and accounts for them. If
+// baseline is true, the statistics are recorded without being counted.
+func (a *UsageAccountant) poll(ctx context.Context, m *Modem, imei string, baseline bool) error {
+	// Fetch the current Bearers, as they may have changed since the Modem was
+	// fetched.
+	v, err := m.c.get(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem"),
+		"Bearers",
+	)
+	if err != nil {
+		return err
+	}
+
+	vp := newValueParser(v)
+	ops := vp.ObjectPaths()
+	if err := vp.Err(); err != nil {
+		return err
+	}
+
+	stats := make(map[dbus.ObjectPath]*BearerStats, len(ops))
+	for _, op := range ops {
+		b, err := m.c.bearer(ctx, op)
+		if err != nil {
+			if errors.Is(toNotExist(err, unknownMethodError), os.ErrNotExist) {
+				// The Bearer was removed since the Bearers were listed.
+				continue
+			}
+
+			return err
+		}
+		if b.Stats == nil {
+			continue
+		}
+
+		stats[op] = b.Stats
+	}
+
+	a.mu.Lock()
+	state := a.modems[imei]
+	changed := state.account(stats, baseline)
+	total := state.total
+	a.mu.Unlock()
+
+	if !changed || a.store == nil {
+		return nil
+	}
+
+	return a.store.SaveUsage(ctx, imei, total)
+}
+
+// account adds the usage from the current Bearer statistics to the total and
+// reports whether the total changed.
+func (u *modemUsage) account(stats map[dbus.ObjectPath]*BearerStats, baseline bool) bool {
+	var changed bool
+	for op, curr := range stats {
+		prev, ok := u.bearers[op]
+		u.bearers[op] = curr
+
+		var d *BearerStatsDelta
+		switch {
+		case ok:
+			d = statsDelta(prev, curr)
+		case baseline:
+			continue
+		default:
+			// A Bearer which appeared since the previous poll, so all of its
+			// usage is new.
+			d = statsDelta(&BearerStats{}, curr)
+		}
+
+		if d.RXBytes == 0 && d.TXBytes == 0 && d.Duration == 0 {
+			continue
+		}
+
+		u.total.RXBytes += d.RXBytes
+		u.total.TXBytes += d.TXBytes
+		u.total.Duration += d.Duration
+		changed = true
+	}
+
+	// Forget any Bearers which no longer exist.
+	for op := range u.bearers {
+		if _, ok := stats[op]; !ok {
+			delete(u.bearers, op)
+		}
+	}
+
+	return changed
+}
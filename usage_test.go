@@ -0,0 +1,221 @@
+package modemmanager
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestUsageAccountantTrack(t *testing.T) {
+	const (
+		b0 = dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")
+		b1 = dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/1")
+	)
+
+	stats := func(rx, tx uint64) map[string]dbus.Variant {
+		return map[string]dbus.Variant{
+			"Stats": dbus.MakeVariant(map[string]dbus.Variant{
+				"rx-bytes": dbus.MakeVariant(rx),
+				"tx-bytes": dbus.MakeVariant(tx),
+			}),
+		}
+	}
+
+	// Each poll returns the next set of Bearers: an existing Bearer is used
+	// as a baseline, transfers more data, and is then replaced by a new
+	// Bearer. The final set repeats until the test completes.
+	polls := []map[dbus.ObjectPath]map[string]dbus.Variant{
+		{b0: stats(100, 10)},
+		{b0: stats(150, 20)},
+		{b1: stats(30, 5)},
+	}
+
+	var (
+		i    int
+		curr map[dbus.ObjectPath]map[string]dbus.Variant
+	)
+
+	m := &Modem{
+		Index:               0,
+		EquipmentIdentifier: "123456789012345",
+		c: &Client{
+			get: func(_ context.Context, op dbus.ObjectPath, _, prop string) (dbus.Variant, error) {
+				if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+					t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+				}
+
+				if diff := cmp.Diff("Bearers", prop); diff != "" {
+					t.Fatalf("unexpected property (-want +got):\n%s", diff)
+				}
+
+				// Advance to the next poll each time the Bearers are listed.
+				curr = polls[i]
+				if i < len(polls)-1 {
+					i++
+				}
+
+				var ops []dbus.ObjectPath
+				for op := range curr {
+					ops = append(ops, op)
+				}
+
+				return dbus.MakeVariant(ops), nil
+			},
+			getAll: func(_ context.Context, op dbus.ObjectPath, _ string) (map[string]dbus.Variant, error) {
+				ps, ok := curr[op]
+				if !ok {
+					t.Fatalf("unexpected bearer: %q", op)
+				}
+
+				return ps, nil
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Cancel tracking after the expected number of changes are saved.
+	var saves []Usage
+	store := &testUsageStore{
+		load: func(_ context.Context, imei string) (Usage, error) {
+			if diff := cmp.Diff("123456789012345", imei); diff != "" {
+				t.Fatalf("unexpected IMEI (-want +got):\n%s", diff)
+			}
+
+			return Usage{RXBytes: 1000, TXBytes: 100}, nil
+		},
+		save: func(_ context.Context, _ string, u Usage) error {
+			saves = append(saves, u)
+			if len(saves) == 2 {
+				cancel()
+			}
+
+			return nil
+		},
+	}
+
+	a := NewUsageAccountant(store)
+	if err := a.Track(ctx, m, time.Millisecond); err != nil {
+		t.Fatalf("failed to track usage: %v", err)
+	}
+
+	want := []Usage{
+		{RXBytes: 1050, TXBytes: 110},
+		{RXBytes: 1080, TXBytes: 115},
+	}
+
+	if diff := cmp.Diff(want, saves); diff != "" {
+		t.Fatalf("unexpected saved Usage (-want +got):\n%s", diff)
+	}
+
+	u, ok := a.Usage("123456789012345")
+	if !ok {
+		t.Fatal("no usage for modem")
+	}
+
+	if diff := cmp.Diff(want[1], u); diff != "" {
+		t.Fatalf("unexpected Usage (-want +got):\n%s", diff)
+	}
+}
+
+func TestUsageAccountantTrackNoStore(t *testing.T) {
+	m := &Modem{
+		EquipmentIdentifier: "123456789012345",
+		c: &Client{
+			get: func(_ context.Context, _ dbus.ObjectPath, _, _ string) (dbus.Variant, error) {
+				return dbus.MakeVariant([]dbus.ObjectPath{}), nil
+			},
+		},
+	}
+
+	// Tracking stops immediately, but the modem's usage is now known.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	a := NewUsageAccountant(nil)
+	if err := a.Track(ctx, m, time.Second); err != nil {
+		t.Fatalf("failed to track usage: %v", err)
+	}
+
+	u, ok := a.Usage("123456789012345")
+	if !ok {
+		t.Fatal("no usage for modem")
+	}
+
+	if diff := cmp.Diff(Usage{}, u); diff != "" {
+		t.Fatalf("unexpected Usage (-want +got):\n%s", diff)
+	}
+
+	if _, ok := a.Usage("000000000000000"); ok {
+		t.Fatal("expected no usage for untracked modem")
+	}
+}
+
+func Test_modemUsageAccount(t *testing.T) {
+	u := &modemUsage{bearers: make(map[dbus.ObjectPath]*BearerStats)}
+
+	const op = dbus.ObjectPath("/org/freedesktop/ModemManager1/Bearer/0")
+	steps := []struct {
+		stats   *BearerStats
+		changed bool
+		total   Usage
+	}{
+		// Baseline.
+		{stats: &BearerStats{RXBytes: 10, Duration: time.Second}},
+		{
+			stats:   &BearerStats{RXBytes: 20, Duration: 2 * time.Second},
+			changed: true,
+			total:   Usage{RXBytes: 10, Duration: time.Second},
+		},
+		{
+			stats: &BearerStats{RXBytes: 20, Duration: 2 * time.Second},
+			total: Usage{RXBytes: 10, Duration: time.Second},
+		},
+		// Reconnect resets the counters.
+		{
+			stats:   &BearerStats{RXBytes: 5},
+			changed: true,
+			total:   Usage{RXBytes: 15, Duration: time.Second},
+		},
+	}
+
+	for i, s := range steps {
+		changed := u.account(map[dbus.ObjectPath]*BearerStats{op: s.stats}, i == 0)
+		if diff := cmp.Diff(s.changed, changed); diff != "" {
+			t.Fatalf("step %d: unexpected changed (-want +got):\n%s", i, diff)
+		}
+
+		if diff := cmp.Diff(s.total, u.total); diff != "" {
+			t.Fatalf("step %d: unexpected Usage (-want +got):\n%s", i, diff)
+		}
+	}
+
+	// A removed Bearer is forgotten.
+	u.account(nil, false)
+	if diff := cmp.Diff(0, len(u.bearers)); diff != "" {
+		t.Fatalf("unexpected number of bearers (-want +got):\n%s", diff)
+	}
+}
+
+// A testUsageStore is a UsageStore backed by functions.
+type testUsageStore struct {
+	load func(ctx context.Context, imei string) (Usage, error)
+	save func(ctx context.Context, imei string, u Usage) error
+}
+
+func (s *testUsageStore) LoadUsage(ctx context.Context, imei string) (Usage, error) {
+	if s.load == nil {
+		return Usage{}, os.ErrNotExist
+	}
+
+	return s.load(ctx, imei)
+}
+
+func (s *testUsageStore) SaveUsage(ctx context.Context, imei string, u Usage) error {
+	return s.save(ctx, imei, u)
+}
@@ -0,0 +1,166 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/godbus/dbus/v5"
+)
+
+// USSD contains the Unstructured Supplementary Service Data session state for
+// a Modem, and can be used to send USSD commands such as "*#100#" to the
+// network.
+type USSD struct {
+	NetworkNotification string
+	NetworkRequest      string
+	State               USSDState
+
+	c     *Client
+	modem dbus.ObjectPath
+}
+
+// A USSDState is the state of a USSD session.
+type USSDState int
+
+// Possible USSDState values, taken from:
+// https://www.freedesktop.org/software/ModemManager/api/latest/ModemManager-Flags-and-Enumerations.html#MMModem3gppUssdSessionState.
+const (
+	USSDStateUnknown USSDState = iota
+	USSDStateIdle
+	USSDStateActive
+	USSDStateUserResponse
+)
+
+// A USSDMessage is a network-initiated USSD message. Notification contains an
+// informational message from the network, while Request contains a request
+// from the network which must be answered using USSD.Respond.
+type USSDMessage struct {
+	Notification string
+	Request      string
+}
+
+// USSD fetches the USSD session state for the Modem.
+func (m *Modem) USSD(ctx context.Context) (*USSD, error) {
+	op := objectPath("Modem", strconv.Itoa(m.Index))
+	ps, err := m.c.getAll(ctx, op, interfacePath("Modem", "Modem3gpp", "Ussd"))
+	if err != nil {
+		return nil, err
+	}
+
+	u := &USSD{
+		c:     m.c,
+		modem: op,
+	}
+
+	if err := u.parse(ps); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// WatchUSSD watches for network-initiated USSD notifications and requests,
+// such as operator push messages or interactive USSD menus. Each message is
+// delivered on the returned channel, which is closed when the context is
+// canceled.
+func (m *Modem) WatchUSSD(ctx context.Context) (<-chan USSDMessage, error) {
+	changes, err := m.c.watchProperties(
+		ctx,
+		objectPath("Modem", strconv.Itoa(m.Index)),
+		interfacePath("Modem", "Modem3gpp", "Ussd"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return forward(ctx, changes, func(ps map[string]dbus.Variant) (USSDMessage, error) {
+		// The properties are cleared when a session ends, so only non-empty
+		// values are messages.
+		var u USSD
+		if err := u.parse(ps); err != nil {
+			return USSDMessage{}, err
+		}
+		if u.NetworkNotification == "" && u.NetworkRequest == "" {
+			return USSDMessage{}, errors.New("no USSD messages")
+		}
+
+		return USSDMessage{
+			Notification: u.NetworkNotification,
+			Request:      u.NetworkRequest,
+		}, nil
+	}), nil
+}
+
+// Initiate starts a new USSD session by sending command to the network, and
+// returns the network's reply.
+//
+// The network may take a long time to reply. If the operation times out, an
+// error compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (u *USSD) Initiate(ctx context.Context, command string) (string, error) {
+	return u.send(ctx, "Initiate", command)
+}
+
+// Respond sends response to a network request in an active USSD session, and
+// returns the network's reply.
+//
+// The network may take a long time to reply. If the operation times out, an
+// error compatible with 'errors.Is(err, os.ErrDeadlineExceeded)' is returned.
+func (u *USSD) Respond(ctx context.Context, response string) (string, error) {
+	return u.send(ctx, "Respond", response)
+}
+
+// Cancel cancels an ongoing USSD session.
+func (u *USSD) Cancel(ctx context.Context) error {
+	err := u.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "Ussd", "Cancel"),
+		u.modem,
+		nil,
+	)
+	if err != nil {
+		return toPermission(err)
+	}
+
+	return nil
+}
+
+// send calls a USSD method with a string argument and returns the network's
+// reply.
+func (u *USSD) send(ctx context.Context, method, s string) (string, error) {
+	var reply string
+	err := u.c.call(
+		ctx,
+		interfacePath("Modem", "Modem3gpp", "Ussd", method),
+		u.modem,
+		&reply,
+		s,
+	)
+	if err != nil {
+		return "", toTimeout(toPermission(err))
+	}
+
+	return reply, nil
+}
+
+// parse parses a properties map into the USSD's fields.
+func (u *USSD) parse(ps map[string]dbus.Variant) error {
+	for k, v := range ps {
+		vp := newValueParser(v)
+		switch k {
+		case "NetworkNotification":
+			u.NetworkNotification = vp.String()
+		case "NetworkRequest":
+			u.NetworkRequest = vp.String()
+		case "State":
+			u.State = USSDState(vp.Int())
+		}
+
+		if err := vp.Err(); err != nil {
+			return fmt.Errorf("error parsing %q: %v", k, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,181 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestModemUSSD(t *testing.T) {
+	m := &Modem{
+		c: &Client{getAll: func(_ context.Context, op dbus.ObjectPath, dInterface string) (map[string]dbus.Variant, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.Ussd", dInterface); diff != "" {
+				t.Fatalf("unexpected interface (-want +got):\n%s", diff)
+			}
+
+			return map[string]dbus.Variant{
+				"NetworkNotification": dbus.MakeVariant(""),
+				"NetworkRequest":      dbus.MakeVariant("1. Balance\n2. Data"),
+				"State":               dbus.MakeVariant(uint32(USSDStateUserResponse)),
+			}, nil
+		}},
+	}
+
+	u, err := m.USSD(context.Background())
+	if err != nil {
+		t.Fatalf("failed to get USSD: %v", err)
+	}
+
+	want := &USSD{
+		NetworkRequest: "1. Balance\n2. Data",
+		State:          USSDStateUserResponse,
+	}
+
+	if diff := cmp.Diff(want, u, cmpopts.IgnoreUnexported(USSD{})); diff != "" {
+		t.Fatalf("unexpected USSD (-want +got):\n%s", diff)
+	}
+}
+
+func TestUSSDInitiate(t *testing.T) {
+	u := &USSD{
+		c: &Client{call: func(_ context.Context, method string, op dbus.ObjectPath, out interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.Ussd.Initiate", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"*#100#"}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{"Your balance is $10.00"}, out)
+		}},
+		modem: "/org/freedesktop/ModemManager1/Modem/0",
+	}
+
+	reply, err := u.Initiate(context.Background(), "*#100#")
+	if err != nil {
+		t.Fatalf("failed to initiate USSD session: %v", err)
+	}
+
+	if diff := cmp.Diff("Your balance is $10.00", reply); diff != "" {
+		t.Fatalf("unexpected reply (-want +got):\n%s", diff)
+	}
+}
+
+func TestUSSDRespondTimeout(t *testing.T) {
+	u := &USSD{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.Ussd.Respond", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff([]interface{}{"1"}, args); diff != "" {
+				t.Fatalf("unexpected arguments (-want +got):\n%s", diff)
+			}
+
+			return dbus.Error{Name: noReplyError}
+		}},
+	}
+
+	_, err := u.Respond(context.Background(), "1")
+	if !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded error, but got: %v", err)
+	}
+}
+
+func TestUSSDCancel(t *testing.T) {
+	u := &USSD{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, _ interface{}, args ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Modem3gpp.Ussd.Cancel", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			if len(args) != 0 {
+				t.Fatalf("unexpected arguments: %v", args)
+			}
+
+			return nil
+		}},
+	}
+
+	if err := u.Cancel(context.Background()); err != nil {
+		t.Fatalf("failed to cancel USSD session: %v", err)
+	}
+}
+
+func TestModemWatchUSSD(t *testing.T) {
+	m := &Modem{
+		c: &Client{watch: func(_ context.Context, op dbus.ObjectPath, _, _ string) (<-chan *dbus.Signal, error) {
+			if diff := cmp.Diff(dbus.ObjectPath("/org/freedesktop/ModemManager1/Modem/0"), op); diff != "" {
+				t.Fatalf("unexpected object path (-want +got):\n%s", diff)
+			}
+
+			const iface = "org.freedesktop.ModemManager1.Modem.Modem3gpp.Ussd"
+
+			// The network sends a notification, an unrelated property
+			// changes, the session ends, and then the network sends a menu.
+			sigs := make(chan *dbus.Signal, 4)
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"NetworkNotification": dbus.MakeVariant("Welcome!")},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{"State": dbus.MakeVariant(uint32(USSDStateActive))},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{
+					"NetworkNotification": dbus.MakeVariant(""),
+					"State":               dbus.MakeVariant(uint32(USSDStateIdle)),
+				},
+				[]string{},
+			}}
+			sigs <- &dbus.Signal{Body: []interface{}{
+				iface,
+				map[string]dbus.Variant{
+					"NetworkRequest": dbus.MakeVariant("1. Balance"),
+					"State":          dbus.MakeVariant(uint32(USSDStateUserResponse)),
+				},
+				[]string{},
+			}}
+			close(sigs)
+
+			return sigs, nil
+		}},
+	}
+
+	msgs, err := m.WatchUSSD(context.Background())
+	if err != nil {
+		t.Fatalf("failed to watch USSD: %v", err)
+	}
+
+	var got []USSDMessage
+	for m := range msgs {
+		got = append(got, m)
+	}
+
+	want := []USSDMessage{
+		{Notification: "Welcome!"},
+		{Request: "1. Balance"},
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("unexpected USSD messages (-want +got):\n%s", diff)
+	}
+}
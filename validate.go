@@ -0,0 +1,87 @@
+package modemmanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// validatePIN verifies that pin is a well-formed SIM PIN. Rejecting malformed
+// PINs before calling D-Bus avoids using up the SIM's unlock attempts.
+func validatePIN(pin string) error {
+	// The PIN is secret, so it is not included in the error.
+	if !isDigits(pin) || len(pin) < 4 || len(pin) > 8 {
+		return errors.New("invalid SIM PIN: must be 4 to 8 digits")
+	}
+
+	return nil
+}
+
+// validatePUK verifies that puk is a well-formed SIM PUK.
+func validatePUK(puk string) error {
+	if !isDigits(puk) || len(puk) != 8 {
+		return errors.New("invalid SIM PUK: must be 8 digits")
+	}
+
+	return nil
+}
+
+// validateAPN verifies that apn is a well-formed access point name as
+// described by 3GPP TS 23.003. An empty APN is permitted so that the Modem may
+// choose a default.
+func validateAPN(apn string) error {
+	if apn == "" {
+		return nil
+	}
+
+	if len(apn) > 100 {
+		return fmt.Errorf("invalid APN %q: must be at most 100 characters", apn)
+	}
+
+	for _, label := range strings.Split(apn, ".") {
+		if label == "" {
+			return fmt.Errorf("invalid APN %q: empty label", apn)
+		}
+
+		for _, r := range label {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			case r == '-', r == '_':
+				// Underscores are not permitted by the specification but are
+				// used by some carriers.
+			default:
+				return fmt.Errorf("invalid APN %q: invalid character %q", apn, r)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateNumber verifies that number is a phone number which may be used to
+// send an SMS: an optional leading '+' followed by up to 20 digits. This
+// permits international E.164 numbers as well as national numbers and short
+// codes.
+func validateNumber(number string) error {
+	digits := strings.TrimPrefix(number, "+")
+	if !isDigits(digits) || len(digits) > 20 {
+		return fmt.Errorf("invalid phone number %q: must be an optional '+' followed by 1 to 20 digits", number)
+	}
+
+	return nil
+}
+
+// isDigits reports whether s is non-empty and consists only of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package modemmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate func(s string) error
+		ok, bad  []string
+	}{
+		{
+			name:     "PIN",
+			validate: validatePIN,
+			ok:       []string{"1234", "00000000"},
+			bad:      []string{"", "123", "123456789", "12 4", "abcd", "١٢٣٤"},
+		},
+		{
+			name:     "PUK",
+			validate: validatePUK,
+			ok:       []string{"12345678"},
+			bad:      []string{"", "1234", "123456789", "1234567a"},
+		},
+		{
+			name:     "APN",
+			validate: validateAPN,
+			ok: []string{
+				"",
+				"broadband",
+				"internet.example-carrier.com",
+				"fast.t-mobile.com",
+				"iot_data",
+			},
+			bad: []string{
+				".broadband",
+				"broadband.",
+				"broad..band",
+				"broad band",
+				"broadband!",
+				strings.Repeat("a", 101),
+			},
+		},
+		{
+			name:     "number",
+			validate: validateNumber,
+			ok:       []string{"+15555550100", "5555550100", "12345"},
+			bad: []string{
+				"",
+				"+",
+				"++15555550100",
+				"+1 555 555 0100",
+				"555-0100",
+				"+" + strings.Repeat("1", 21),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, s := range tt.ok {
+				if err := tt.validate(s); err != nil {
+					t.Fatalf("failed to validate %q: %v", s, err)
+				}
+			}
+
+			for _, s := range tt.bad {
+				err := tt.validate(s)
+				if err == nil {
+					t.Fatalf("expected an error for %q, but none occurred", s)
+				}
+
+				t.Logf("err: %v", err)
+			}
+		})
+	}
+}
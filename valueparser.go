@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
+	"time"
 
 	"github.com/godbus/dbus/v5"
 )
@@ -40,6 +42,21 @@ func (vp *valueParser) Bool() bool {
 	return b
 }
 
+// Bytes parses the value as a byte slice.
+func (vp *valueParser) Bytes() []byte {
+	if vp.err != nil {
+		return nil
+	}
+
+	b, ok := vp.v.([]byte)
+	if !ok {
+		vp.err = errors.New("value is not of type []byte")
+		return nil
+	}
+
+	return b
+}
+
 // Float64 parses the value as a float64.
 func (vp *valueParser) Float64() float64 {
 	if vp.err != nil {
@@ -72,6 +89,27 @@ func (vp *valueParser) Int() int {
 	}
 }
 
+// Hex parses a hexadecimal string value as a uint64.
+func (vp *valueParser) Hex() uint64 {
+	if vp.err != nil {
+		return 0
+	}
+
+	s, ok := vp.v.(string)
+	if !ok {
+		vp.err = errors.New("value for hexadecimal integer is not of type string")
+		return 0
+	}
+
+	u, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		vp.err = fmt.Errorf("invalid hexadecimal integer: %q", s)
+		return 0
+	}
+
+	return u
+}
+
 // IP parses a value as a net.IP
 func (vp *valueParser) IP() net.IP {
 	if vp.err != nil {
@@ -124,6 +162,36 @@ func (vp *valueParser) String() string {
 	return s
 }
 
+// Strings parses the value as a slice of strings.
+func (vp *valueParser) Strings() []string {
+	if vp.err != nil {
+		return nil
+	}
+
+	s, ok := vp.v.([]string)
+	if !ok {
+		vp.err = errors.New("value is not of type []string")
+		return nil
+	}
+
+	return s
+}
+
+// Uint32s parses the value as a slice of uint32s.
+func (vp *valueParser) Uint32s() []uint32 {
+	if vp.err != nil {
+		return nil
+	}
+
+	u, ok := vp.v.([]uint32)
+	if !ok {
+		vp.err = errors.New("value is not of type []uint32")
+		return nil
+	}
+
+	return u
+}
+
 // Uint64 parses the value as a uint64.
 func (vp *valueParser) Uint64() uint64 {
 	if vp.err != nil {
@@ -139,6 +207,47 @@ func (vp *valueParser) Uint64() uint64 {
 	return u
 }
 
+// Time parses a ModemManager timestamp string value as a time.Time. An empty
+// string is parsed as the zero time.
+func (vp *valueParser) Time() time.Time {
+	if vp.err != nil {
+		return time.Time{}
+	}
+
+	s, ok := vp.v.(string)
+	if !ok {
+		vp.err = errors.New("value for time is not of type string")
+		return time.Time{}
+	}
+
+	if s == "" {
+		return time.Time{}
+	}
+
+	t, err := ParseModemTime(s)
+	if err != nil {
+		vp.err = err
+		return time.Time{}
+	}
+
+	return t
+}
+
+// ObjectPath parses the value as a dbus.ObjectPath.
+func (vp *valueParser) ObjectPath() dbus.ObjectPath {
+	if vp.err != nil {
+		return ""
+	}
+
+	op, ok := vp.v.(dbus.ObjectPath)
+	if !ok {
+		vp.err = errors.New("value is not a D-Bus object path")
+		return ""
+	}
+
+	return op
+}
+
 // ObjectPaths parses the value as a slice of dbus.ObjectPaths.
 func (vp *valueParser) ObjectPaths() []dbus.ObjectPath {
 	if vp.err != nil {
@@ -200,6 +309,299 @@ func (vp *valueParser) Ports() []Port {
 	return ps
 }
 
+// PreferredNetworks parses the value as a slice of PreferredNetworks.
+func (vp *valueParser) PreferredNetworks() []PreferredNetwork {
+	if vp.err != nil {
+		return nil
+	}
+
+	// Like Ports, the networks are packed in a slice of tuple slices:
+	//
+	// [["310410", 16384], ["310260", 16384]], etc.
+
+	ss, ok := vp.v.([][]interface{})
+	if !ok {
+		vp.err = errors.New("value is not a preferred networks list")
+		return nil
+	}
+
+	ns := make([]PreferredNetwork, 0, len(ss))
+	for _, s := range ss {
+		if len(s) != 2 {
+			vp.err = errors.New("invalid preferred networks list slice")
+			return nil
+		}
+
+		code, ok := s[0].(string)
+		if !ok {
+			vp.err = errors.New("invalid preferred network operator code string")
+			return nil
+		}
+
+		at, ok := s[1].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid preferred network access technology uint32")
+			return nil
+		}
+
+		ns = append(ns, PreferredNetwork{
+			OperatorCode:     code,
+			AccessTechnology: AccessTechnology(at),
+		})
+	}
+
+	return ns
+}
+
+// ChannelRanges parses the value as a slice of ChannelRanges.
+func (vp *valueParser) ChannelRanges() []ChannelRange {
+	if vp.err != nil {
+		return nil
+	}
+
+	// Like Ports, the ranges are packed in a slice of tuple slices:
+	//
+	// [[4370, 4383], [4400, 4400]], etc.
+
+	ss, ok := vp.v.([][]interface{})
+	if !ok {
+		vp.err = errors.New("value is not a channel ranges list")
+		return nil
+	}
+
+	crs := make([]ChannelRange, 0, len(ss))
+	for _, s := range ss {
+		if len(s) != 2 {
+			vp.err = errors.New("invalid channel ranges list slice")
+			return nil
+		}
+
+		start, ok := s[0].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid channel range start uint32")
+			return nil
+		}
+
+		end, ok := s[1].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid channel range end uint32")
+			return nil
+		}
+
+		crs = append(crs, ChannelRange{
+			Start: int(start),
+			End:   int(end),
+		})
+	}
+
+	return crs
+}
+
+// OMAPendingSessions parses the value as a slice of OMAPendingSessions.
+func (vp *valueParser) OMAPendingSessions() []OMAPendingSession {
+	if vp.err != nil {
+		return nil
+	}
+
+	// Like Ports, the sessions are packed in a slice of tuple slices:
+	//
+	// [[20, 1], [21, 2]], etc.
+
+	ss, ok := vp.v.([][]interface{})
+	if !ok {
+		vp.err = errors.New("value is not an OMA pending sessions list")
+		return nil
+	}
+
+	oss := make([]OMAPendingSession, 0, len(ss))
+	for _, s := range ss {
+		if len(s) != 2 {
+			vp.err = errors.New("invalid OMA pending sessions list slice")
+			return nil
+		}
+
+		typ, ok := s[0].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid OMA pending session type uint32")
+			return nil
+		}
+
+		id, ok := s[1].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid OMA pending session ID uint32")
+			return nil
+		}
+
+		oss = append(oss, OMAPendingSession{
+			Type: OMASessionType(typ),
+			ID:   int(id),
+		})
+	}
+
+	return oss
+}
+
+// PCO parses the value as a slice of PCOs.
+func (vp *valueParser) PCO() []PCO {
+	if vp.err != nil {
+		return nil
+	}
+
+	// PCO data is packed as a slice of (session ID, complete, data) tuples:
+	//
+	// [[1, true, [0x27, ...]]], etc.
+
+	ss, ok := vp.v.([][]interface{})
+	if !ok {
+		vp.err = errors.New("value is not a PCO list")
+		return nil
+	}
+
+	ps := make([]PCO, 0, len(ss))
+	for _, s := range ss {
+		if len(s) != 3 {
+			vp.err = errors.New("invalid PCO list slice")
+			return nil
+		}
+
+		id, ok := s[0].(uint32)
+		if !ok {
+			vp.err = errors.New("invalid PCO session ID uint32")
+			return nil
+		}
+
+		complete, ok := s[1].(bool)
+		if !ok {
+			vp.err = errors.New("invalid PCO complete bool")
+			return nil
+		}
+
+		data, ok := s[2].([]byte)
+		if !ok {
+			vp.err = errors.New("invalid PCO data []byte")
+			return nil
+		}
+
+		ps = append(ps, PCO{
+			SessionID: int(id),
+			Complete:  complete,
+			Data:      data,
+		})
+	}
+
+	return ps
+}
+
+// Validity parses the value as an SMS validity type and period. Only relative
+// validity periods, which ModemManager reports in minutes, are parsed as a
+// time.Duration.
+func (vp *valueParser) Validity() (SMSValidityType, time.Duration) {
+	if vp.err != nil {
+		return 0, 0
+	}
+
+	// Validity is packed as a (type, value) tuple whose value depends on the
+	// type:
+	//
+	// [1, <uint32 minutes>], etc.
+
+	s, ok := vp.v.([]interface{})
+	if !ok || len(s) != 2 {
+		vp.err = errors.New("value is not a validity tuple")
+		return 0, 0
+	}
+
+	typ, ok := s[0].(uint32)
+	if !ok {
+		vp.err = errors.New("invalid validity type uint32")
+		return 0, 0
+	}
+
+	if SMSValidityType(typ) != SMSValidityTypeRelative {
+		return SMSValidityType(typ), 0
+	}
+
+	v, ok := s[1].(dbus.Variant)
+	if !ok {
+		vp.err = errors.New("invalid validity value variant")
+		return 0, 0
+	}
+
+	mins, ok := v.Value().(uint32)
+	if !ok {
+		vp.err = errors.New("invalid relative validity uint32")
+		return 0, 0
+	}
+
+	return SMSValidityTypeRelative, time.Duration(mins) * time.Minute
+}
+
+// SignalQuality parses the value as a signal quality percentage and whether
+// that signal quality was recently taken.
+func (vp *valueParser) SignalQuality() (int, bool) {
+	if vp.err != nil {
+		return 0, false
+	}
+
+	// Signal quality is packed as a (percent, recent) tuple.
+	s, ok := vp.v.([]interface{})
+	if !ok || len(s) != 2 {
+		vp.err = errors.New("value is not a signal quality tuple")
+		return 0, false
+	}
+
+	percent, ok := s[0].(uint32)
+	if !ok {
+		vp.err = errors.New("invalid signal quality percent uint32")
+		return 0, false
+	}
+
+	recent, ok := s[1].(bool)
+	if !ok {
+		vp.err = errors.New("invalid signal quality recent boolean")
+		return 0, false
+	}
+
+	return int(percent), recent
+}
+
+// UnlockRetries parses the value as a map of Locks to the number of remaining
+// unlock attempts.
+func (vp *valueParser) UnlockRetries() map[Lock]int {
+	if vp.err != nil {
+		return nil
+	}
+
+	m, ok := vp.v.(map[uint32]uint32)
+	if !ok {
+		vp.err = errors.New("value is not an unlock retries map")
+		return nil
+	}
+
+	retries := make(map[Lock]int, len(m))
+	for k, v := range m {
+		retries[Lock(k)] = int(v)
+	}
+
+	return retries
+}
+
+// Locations parses a value as a map of location sources to their location
+// values.
+func (vp *valueParser) Locations() map[uint32]dbus.Variant {
+	if vp.err != nil {
+		return nil
+	}
+
+	ls, ok := vp.v.(map[uint32]dbus.Variant)
+	if !ok {
+		vp.err = errors.New("value is not a locations map")
+		return nil
+	}
+
+	return ls
+}
+
 // Properties parses a value as a D-Bus properties map.
 func (vp *valueParser) Properties() map[string]dbus.Variant {
 	if vp.err != nil {
@@ -19,6 +19,13 @@ func Test_valueParserErrors(t *testing.T) {
 				_ = vp.Bool()
 			},
 		},
+		{
+			name: "bytes",
+			v:    dbus.MakeVariant("foo"),
+			fn: func(vp *valueParser) {
+				_ = vp.Bytes()
+			},
+		},
 		{
 			name: "float64",
 			v:    dbus.MakeVariant("foo"),
@@ -33,6 +40,20 @@ func Test_valueParserErrors(t *testing.T) {
 				_ = vp.Int()
 			},
 		},
+		{
+			name: "hex type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.Hex()
+			},
+		},
+		{
+			name: "hex invalid",
+			v:    dbus.MakeVariant("zz"),
+			fn: func(vp *valueParser) {
+				_ = vp.Hex()
+			},
+		},
 		{
 			name: "IP type",
 			v:    dbus.MakeVariant(1.0),
@@ -61,6 +82,20 @@ func Test_valueParserErrors(t *testing.T) {
 				_ = vp.String()
 			},
 		},
+		{
+			name: "strings",
+			v:    dbus.MakeVariant("foo"),
+			fn: func(vp *valueParser) {
+				_ = vp.Strings()
+			},
+		},
+		{
+			name: "uint32s",
+			v:    dbus.MakeVariant("foo"),
+			fn: func(vp *valueParser) {
+				_ = vp.Uint32s()
+			},
+		},
 		{
 			name: "uint64",
 			v:    dbus.MakeVariant("foo"),
@@ -68,6 +103,27 @@ func Test_valueParserErrors(t *testing.T) {
 				_ = vp.Uint64()
 			},
 		},
+		{
+			name: "time type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.Time()
+			},
+		},
+		{
+			name: "time invalid",
+			v:    dbus.MakeVariant("foo"),
+			fn: func(vp *valueParser) {
+				_ = vp.Time()
+			},
+		},
+		{
+			name: "object path",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.ObjectPath()
+			},
+		},
 		{
 			name: "object paths",
 			v:    dbus.MakeVariant(1),
@@ -75,6 +131,181 @@ func Test_valueParserErrors(t *testing.T) {
 				_ = vp.ObjectPaths()
 			},
 		},
+		{
+			name: "preferred networks type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.PreferredNetworks()
+			},
+		},
+		{
+			name: "preferred networks slice",
+			v:    dbus.MakeVariant([][]interface{}{{"310410"}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PreferredNetworks()
+			},
+		},
+		{
+			name: "preferred networks code",
+			v:    dbus.MakeVariant([][]interface{}{{1, uint32(1)}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PreferredNetworks()
+			},
+		},
+		{
+			name: "preferred networks access technology",
+			v:    dbus.MakeVariant([][]interface{}{{"310410", "foo"}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PreferredNetworks()
+			},
+		},
+		{
+			name: "channel ranges type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.ChannelRanges()
+			},
+		},
+		{
+			name: "channel ranges slice",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1)}}),
+			fn: func(vp *valueParser) {
+				_ = vp.ChannelRanges()
+			},
+		},
+		{
+			name: "channel ranges start",
+			v:    dbus.MakeVariant([][]interface{}{{"foo", uint32(1)}}),
+			fn: func(vp *valueParser) {
+				_ = vp.ChannelRanges()
+			},
+		},
+		{
+			name: "channel ranges end",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1), "foo"}}),
+			fn: func(vp *valueParser) {
+				_ = vp.ChannelRanges()
+			},
+		},
+		{
+			name: "OMA pending sessions type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.OMAPendingSessions()
+			},
+		},
+		{
+			name: "OMA pending sessions slice",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1)}}),
+			fn: func(vp *valueParser) {
+				_ = vp.OMAPendingSessions()
+			},
+		},
+		{
+			name: "OMA pending sessions type value",
+			v:    dbus.MakeVariant([][]interface{}{{"foo", uint32(1)}}),
+			fn: func(vp *valueParser) {
+				_ = vp.OMAPendingSessions()
+			},
+		},
+		{
+			name: "OMA pending sessions ID",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1), "foo"}}),
+			fn: func(vp *valueParser) {
+				_ = vp.OMAPendingSessions()
+			},
+		},
+		{
+			name: "PCO type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.PCO()
+			},
+		},
+		{
+			name: "PCO slice",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1), true}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PCO()
+			},
+		},
+		{
+			name: "PCO session ID",
+			v:    dbus.MakeVariant([][]interface{}{{"foo", true, []byte{0x80}}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PCO()
+			},
+		},
+		{
+			name: "PCO complete",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1), "foo", []byte{0x80}}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PCO()
+			},
+		},
+		{
+			name: "PCO data",
+			v:    dbus.MakeVariant([][]interface{}{{uint32(1), true, "foo"}}),
+			fn: func(vp *valueParser) {
+				_ = vp.PCO()
+			},
+		},
+		{
+			name: "validity type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_, _ = vp.Validity()
+			},
+		},
+		{
+			name: "validity tuple type",
+			v:    dbus.MakeVariant([]interface{}{"foo", dbus.MakeVariant(uint32(1))}),
+			fn: func(vp *valueParser) {
+				_, _ = vp.Validity()
+			},
+		},
+		{
+			name: "validity relative",
+			v:    dbus.MakeVariant([]interface{}{uint32(SMSValidityTypeRelative), dbus.MakeVariant("foo")}),
+			fn: func(vp *valueParser) {
+				_, _ = vp.Validity()
+			},
+		},
+		{
+			name: "signal quality type",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_, _ = vp.SignalQuality()
+			},
+		},
+		{
+			name: "signal quality percent",
+			v:    dbus.MakeVariant([]interface{}{"foo", true}),
+			fn: func(vp *valueParser) {
+				_, _ = vp.SignalQuality()
+			},
+		},
+		{
+			name: "signal quality recent",
+			v:    dbus.MakeVariant([]interface{}{uint32(50), "foo"}),
+			fn: func(vp *valueParser) {
+				_, _ = vp.SignalQuality()
+			},
+		},
+		{
+			name: "unlock retries",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.UnlockRetries()
+			},
+		},
+		{
+			name: "locations",
+			v:    dbus.MakeVariant(1),
+			fn: func(vp *valueParser) {
+				_ = vp.Locations()
+			},
+		},
 		{
 			name: "ports type",
 			v:    dbus.MakeVariant(1),
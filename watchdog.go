@@ -0,0 +1,156 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrWatchdogReset indicates that a Watchdog reset its Modem, after which the
+// Modem must be fetched again.
+var ErrWatchdogReset = errors.New("modem reset by watchdog")
+
+// A Watchdog recovers a Modem which is stuck without service. It periodically
+// checks the Modem's Status, and when the Modem remains stuck for too long,
+// performs increasingly disruptive RecoveryActions until the Modem recovers.
+//
+// A Modem is considered stuck when it has failed, is searching for a network,
+// or is enabled but reports no signal.
+type Watchdog struct {
+	// CheckInterval is the interval at which the Modem's Status is checked.
+	CheckInterval time.Duration
+
+	// FailedTimeout and SearchingTimeout are how long the Modem may remain
+	// failed, or searching without signal, before recovery begins.
+	FailedTimeout, SearchingTimeout time.Duration
+
+	// ActionInterval is how long to wait for the Modem to recover after a
+	// RecoveryAction before escalating to the next one.
+	ActionInterval time.Duration
+
+	// OnAction, if set, is called after each RecoveryAction is performed with
+	// the Status which triggered it and the result of the action.
+	OnAction func(action RecoveryAction, s *Status, err error)
+
+	m *Modem
+}
+
+// A RecoveryAction is an action performed by a Watchdog to recover a Modem.
+type RecoveryAction int
+
+// Possible RecoveryAction values, in the order a Watchdog escalates them.
+const (
+	RecoveryActionUnknown RecoveryAction = iota
+	RecoveryActionDisconnect
+	RecoveryActionReenable
+	RecoveryActionReset
+)
+
+// NewWatchdog creates a Watchdog for the Modem with default settings, which
+// may be modified before calling Run.
+func NewWatchdog(m *Modem) *Watchdog {
+	return &Watchdog{
+		CheckInterval:    10 * time.Second,
+		FailedTimeout:    time.Minute,
+		SearchingTimeout: 5 * time.Minute,
+		ActionInterval:   2 * time.Minute,
+
+		m: m,
+	}
+}
+
+// Run watches and recovers the Modem until ctx is canceled, and then returns
+// nil. Checks which fail to fetch the Modem's Status are skipped.
+//
+// A reset removes the Modem, so once the Modem is reset, Run returns
+// ErrWatchdogReset. The Modem may then be fetched again and watched by a new
+// Watchdog.
+func (w *Watchdog) Run(ctx context.Context) error {
+	t := time.NewTicker(w.CheckInterval)
+	defer t.Stop()
+
+	var (
+		stuckSince, actedAt time.Time
+		next                = RecoveryActionDisconnect
+	)
+
+	for {
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return nil
+		}
+
+		s, err := w.m.GetStatus(ctx)
+		if err != nil {
+			continue
+		}
+
+		now := time.Now()
+		timeout, stuck := w.timeout(s)
+		if !stuck {
+			// The Modem has recovered, so begin again with the least
+			// disruptive action if it becomes stuck later.
+			stuckSince, actedAt = time.Time{}, time.Time{}
+			next = RecoveryActionDisconnect
+			continue
+		}
+
+		if stuckSince.IsZero() {
+			stuckSince = now
+		}
+		if now.Sub(stuckSince) < timeout {
+			continue
+		}
+		if !actedAt.IsZero() && now.Sub(actedAt) < w.ActionInterval {
+			continue
+		}
+
+		err = w.act(ctx, next)
+		if w.OnAction != nil {
+			w.OnAction(next, s, err)
+		}
+		if next == RecoveryActionReset && err == nil {
+			return ErrWatchdogReset
+		}
+
+		actedAt = now
+		if next < RecoveryActionReset {
+			next++
+		}
+	}
+}
+
+// timeout reports whether the Modem is stuck according to s and, if so, how
+// long it may remain stuck before recovery begins.
+func (w *Watchdog) timeout(s *Status) (time.Duration, bool) {
+	switch {
+	case s.State == StateFailed:
+		return w.FailedTimeout, true
+	case s.State == StateSearching:
+		return w.SearchingTimeout, true
+	case s.State >= StateEnabled && s.SignalQuality == 0:
+		return w.SearchingTimeout, true
+	default:
+		return 0, false
+	}
+}
+
+// act performs a RecoveryAction on the Modem.
+func (w *Watchdog) act(ctx context.Context, action RecoveryAction) error {
+	switch action {
+	case RecoveryActionDisconnect:
+		return w.m.Disconnect(ctx, nil)
+	case RecoveryActionReenable:
+		if err := w.m.Enable(ctx, false); err != nil {
+			return err
+		}
+
+		return w.m.Enable(ctx, true)
+	case RecoveryActionReset:
+		return w.m.Reset(ctx)
+	default:
+		panicf("modemmanager: unhandled recovery action: %s", action)
+		return nil
+	}
+}
@@ -0,0 +1,164 @@
+package modemmanager
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWatchdogRun(t *testing.T) {
+	var (
+		failed    = testStatus(StateFailed, 0)
+		searching = testStatus(StateSearching, 0)
+		noSignal  = testStatus(StateRegistered, 0)
+		connected = testStatus(StateConnected, 50)
+	)
+
+	tests := []struct {
+		name     string
+		statuses []map[string]dbus.Variant
+		calls    []string
+		actions  []RecoveryAction
+	}{
+		{
+			name:     "searching",
+			statuses: []map[string]dbus.Variant{searching},
+			calls: []string{
+				"org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Reset",
+			},
+			actions: []RecoveryAction{
+				RecoveryActionDisconnect,
+				RecoveryActionReenable,
+				RecoveryActionReset,
+			},
+		},
+		{
+			name:     "no signal",
+			statuses: []map[string]dbus.Variant{noSignal, noSignal, noSignal},
+			calls: []string{
+				"org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Reset",
+			},
+			actions: []RecoveryAction{
+				RecoveryActionDisconnect,
+				RecoveryActionReenable,
+				RecoveryActionReset,
+			},
+		},
+		{
+			name:     "recovered",
+			statuses: []map[string]dbus.Variant{failed, connected, failed},
+			calls: []string{
+				"org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+				"org.freedesktop.ModemManager1.Modem.Simple.Disconnect",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Enable",
+				"org.freedesktop.ModemManager1.Modem.Reset",
+			},
+			actions: []RecoveryAction{
+				RecoveryActionDisconnect,
+				RecoveryActionDisconnect,
+				RecoveryActionReenable,
+				RecoveryActionReset,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				calls    []string
+				statuses = tt.statuses
+			)
+
+			m := &Modem{
+				c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+					if method != "org.freedesktop.ModemManager1.Modem.Simple.GetStatus" {
+						calls = append(calls, method)
+						return nil
+					}
+
+					// Report the next Status, repeating the last one.
+					s := statuses[0]
+					if len(statuses) > 1 {
+						statuses = statuses[1:]
+					}
+
+					return dbus.Store([]interface{}{s}, out)
+				}},
+			}
+
+			// Act immediately to keep the test fast.
+			w := NewWatchdog(m)
+			w.CheckInterval = time.Millisecond
+			w.FailedTimeout = 0
+			w.SearchingTimeout = 0
+			w.ActionInterval = 0
+
+			var actions []RecoveryAction
+			w.OnAction = func(action RecoveryAction, _ *Status, err error) {
+				if err != nil {
+					t.Fatalf("failed to perform %s: %v", action, err)
+				}
+
+				actions = append(actions, action)
+			}
+
+			if err := w.Run(context.Background()); !errors.Is(err, ErrWatchdogReset) {
+				t.Fatalf("expected watchdog reset, but got: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.calls, calls); diff != "" {
+				t.Fatalf("unexpected method calls (-want +got):\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tt.actions, actions); diff != "" {
+				t.Fatalf("unexpected recovery actions (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWatchdogRunTimeout(t *testing.T) {
+	m := &Modem{
+		c: &Client{call: func(_ context.Context, method string, _ dbus.ObjectPath, out interface{}, _ ...interface{}) error {
+			if diff := cmp.Diff("org.freedesktop.ModemManager1.Modem.Simple.GetStatus", method); diff != "" {
+				t.Fatalf("unexpected method (-want +got):\n%s", diff)
+			}
+
+			return dbus.Store([]interface{}{testStatus(StateFailed, 0)}, out)
+		}},
+	}
+
+	// The Modem is never stuck for long enough to take action.
+	w := NewWatchdog(m)
+	w.CheckInterval = time.Millisecond
+	w.OnAction = func(action RecoveryAction, _ *Status, _ error) {
+		t.Fatalf("unexpected recovery action: %s", action)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := w.Run(ctx); err != nil {
+		t.Fatalf("failed to run watchdog: %v", err)
+	}
+}
+
+// testStatus returns Simple.GetStatus properties with the input State and
+// signal quality.
+func testStatus(state State, quality int) map[string]dbus.Variant {
+	return map[string]dbus.Variant{
+		"signal-quality": dbus.MakeVariant([]interface{}{uint32(quality), true}),
+		"state":          dbus.MakeVariant(int32(state)),
+	}
+}